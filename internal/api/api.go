@@ -232,6 +232,9 @@ func New(
 		t.RegisterEndpoint("/stats", "Exposes service-wide metrics in the format configured.", wHandlerFunc)
 		t.RegisterEndpoint("/metrics", "Exposes service-wide metrics in the format configured.", wHandlerFunc)
 	}
+	if jHandlerFunc := metrics.JSONHandlerFunc(stats); jHandlerFunc != nil {
+		t.RegisterEndpoint("/metrics/json", "Exposes a JSON snapshot of the current values of service-wide metrics.", jHandlerFunc)
+	}
 
 	for _, opt := range opts {
 		opt(t)
@@ -183,3 +183,34 @@ func TestAPIBasicAuth(t *testing.T) {
 		}(tc))
 	}
 }
+
+type jsonSnapshotStats struct {
+	metrics.DudType
+}
+
+func (j jsonSnapshotStats) JSONHandlerFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"foo":10}`))
+	}
+}
+
+func TestAPIMetricsJSON(t *testing.T) {
+	s, err := api.New("", "", api.NewConfig(), nil, log.Noop(), metrics.NewNamespaced(jsonSnapshotStats{}))
+	require.NoError(t, err)
+
+	request, _ := http.NewRequest("GET", "/metrics/json", http.NoBody)
+	response := httptest.NewRecorder()
+	s.Handler().ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, `{"foo":10}`, response.Body.String())
+
+	s, err = api.New("", "", api.NewConfig(), nil, log.Noop(), metrics.Noop())
+	require.NoError(t, err)
+
+	request, _ = http.NewRequest("GET", "/metrics/json", http.NoBody)
+	response = httptest.NewRecorder()
+	s.Handler().ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -70,6 +71,27 @@ func (d *dynamicConfMgr) Remove(id string) {
 
 //------------------------------------------------------------------------------
 
+type badRequestError struct {
+	err error
+}
+
+func (e *badRequestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *badRequestError) Unwrap() error {
+	return e.err
+}
+
+// NewBadRequestError wraps an error returned by an OnUpdate func in order to
+// indicate that the submitted configuration is invalid, which results in a 400
+// status code rather than a 502.
+func NewBadRequestError(err error) error {
+	return &badRequestError{err: err}
+}
+
+//------------------------------------------------------------------------------
+
 // Dynamic is a type for exposing CRUD operations on dynamic broker
 // configurations as an HTTP interface. Events can be registered for listening
 // to configuration changes, and these events should be forwarded to the
@@ -286,7 +308,12 @@ func (d *Dynamic) HandleCRUD(w http.ResponseWriter, r *http.Request) {
 			r.Body.Close()
 		}
 		if httpErr != nil {
-			http.Error(w, fmt.Sprintf("Error: %v", httpErr), http.StatusBadGateway)
+			status := http.StatusBadGateway
+			var bErr *badRequestError
+			if errors.As(httpErr, &bErr) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, fmt.Sprintf("Error: %v", httpErr), status)
 			return
 		}
 	}()
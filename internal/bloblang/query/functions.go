@@ -267,7 +267,7 @@ var _ = registerSimpleFunction(
 var _ = registerFunction(
 	NewFunctionSpec(
 		FunctionCategoryGeneral, "count",
-		"The `count` function is a counter starting at 1 which increments after each time it is called. Count takes an argument which is an identifier for the counter, allowing you to specify multiple unique counters in your configuration.",
+		"The `count` function is a counter starting at 1 which increments after each time it is called. Count takes an argument which is an identifier for the counter, allowing you to specify multiple unique counters in your configuration. Counters are shared by all mappings within a Benthos process, and therefore calls with the same identifier from different components, such as a `switch` check and a `read_until` check, increment the same counter.",
 		NewExampleSpec("",
 			`root = this
 root.id = count("bloblang_function_example")`,
@@ -276,6 +276,15 @@ root.id = count("bloblang_function_example")`,
 			`{"message":"bar"}`,
 			`{"id":2,"message":"bar"}`,
 		),
+		NewExampleSpec("The modulo of a counter can be used to sample every Nth message, here we keep every third message.",
+			`root = if count("bloblang_sample_example") % 3 != 0 { deleted() }`,
+			`{"message":"foo"}`,
+			`<Message deleted>`,
+			`{"message":"bar"}`,
+			`<Message deleted>`,
+			`{"message":"baz"}`,
+			`{"message":"baz"}`,
+		),
 	).Param(ParamString("name", "An identifier for the counter.")).MarkImpure(),
 	countFunction,
 )
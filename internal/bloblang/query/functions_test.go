@@ -277,6 +277,24 @@ func TestKsuidFunction(t *testing.T) {
 	assert.NotEmpty(t, res)
 }
 
+func TestCountFunctionShared(t *testing.T) {
+	a, err := InitFunctionHelper("count", "test_count_function_shared")
+	require.NoError(t, err)
+
+	b, err := InitFunctionHelper("count", "test_count_function_shared")
+	require.NoError(t, err)
+
+	for i, exp := range []int64{1, 2, 3, 4} {
+		e := a
+		if i%2 == 1 {
+			e = b
+		}
+		res, err := e.Exec(FunctionContext{})
+		require.NoError(t, err)
+		assert.Equal(t, exp, res)
+	}
+}
+
 func TestRandomInt(t *testing.T) {
 	e, err := InitFunctionHelper("random_int")
 	require.Nil(t, err)
@@ -23,6 +23,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -87,7 +88,7 @@ var _ = registerSimpleMethod(
 		"encode", "",
 	).InCategory(
 		MethodCategoryEncoding,
-		"Encodes a string or byte array target according to a chosen scheme and returns a string result. Available schemes are: `base64`, `base64url` [(RFC 4648 with padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `base64rawurl` [(RFC 4648 without padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `hex`, `ascii85`.",
+		"Encodes a string or byte array target according to a chosen scheme and returns a string result. Available schemes are: `base64`, `base64rawstd` [(RFC 4648 without padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `base64url` [(RFC 4648 with padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `base64rawurl` [(RFC 4648 without padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `hex`, `ascii85`.",
 		// NOTE: z85 has been removed from the list until we can support
 		// misaligned data automatically. It'll still be supported for backwards
 		// compatibility, but given it behaves differently to `ascii85` I think
@@ -119,6 +120,14 @@ var _ = registerSimpleMethod(
 				e.Close()
 				return buf.String(), nil
 			}
+		case "base64rawstd":
+			schemeFn = func(b []byte) (string, error) {
+				var buf bytes.Buffer
+				e := base64.NewEncoder(base64.RawStdEncoding, &buf)
+				_, _ = e.Write(b)
+				e.Close()
+				return buf.String(), nil
+			}
 		case "base64url":
 			schemeFn = func(b []byte) (string, error) {
 				var buf bytes.Buffer
@@ -193,7 +202,7 @@ var _ = registerSimpleMethod(
 		"decode", "",
 	).InCategory(
 		MethodCategoryEncoding,
-		"Decodes an encoded string target according to a chosen scheme and returns the result as a byte array. When mapping the result to a JSON field the value should be cast to a string using the method [`string`][methods.string], or encoded using the method [`encode`][methods.encode], otherwise it will be base64 encoded by default.\n\nAvailable schemes are: `base64`, `base64url` [(RFC 4648 with padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `base64rawurl` [(RFC 4648 without padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `hex`, `ascii85`.",
+		"Decodes an encoded string target according to a chosen scheme and returns the result as a byte array. When mapping the result to a JSON field the value should be cast to a string using the method [`string`][methods.string], or encoded using the method [`encode`][methods.encode], otherwise it will be base64 encoded by default.\n\nAvailable schemes are: `base64`, `base64rawstd` [(RFC 4648 without padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `base64url` [(RFC 4648 with padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `base64rawurl` [(RFC 4648 without padding characters)](https://rfc-editor.org/rfc/rfc4648.html), `hex`, `ascii85`.",
 		// NOTE: z85 has been removed from the list until we can support
 		// misaligned data automatically. It'll still be supported for backwards
 		// compatibility, but given it behaves differently to `ascii85` I think
@@ -222,6 +231,11 @@ var _ = registerSimpleMethod(
 				e := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(b))
 				return io.ReadAll(e)
 			}
+		case "base64rawstd":
+			schemeFn = func(b []byte) ([]byte, error) {
+				e := base64.NewDecoder(base64.RawStdEncoding, bytes.NewReader(b))
+				return io.ReadAll(e)
+			}
 		case "base64url":
 			schemeFn = func(b []byte) ([]byte, error) {
 				e := base64.NewDecoder(base64.URLEncoding, bytes.NewReader(b))
@@ -1068,6 +1082,120 @@ func parseCSVMethod(args *ParsedParams) (simpleMethod, error) {
 	}, nil
 }
 
+var _ = registerSimpleMethod(
+	NewMethodSpec(
+		"format_csv", "",
+	).InCategory(
+		MethodCategoryParsing,
+		"Serializes an array of objects or an array of row arrays into a string following the CSV format described in RFC 4180. Rows must either all be objects or all be arrays. When the rows are objects a header row is written first, where the columns are the sorted keys of the first object unless a list of `headers` is provided.",
+		NewExampleSpec("Serializes an array of objects with a header row",
+			`root.orders = this.orders.format_csv()`,
+			`{"orders":[{"foo":"foo 1","bar":"bar 1"},{"foo":"foo 2","bar":2}]}`,
+			`{"orders":"bar,foo\nbar 1,foo 1\n2,foo 2\n"}`,
+		),
+		NewExampleSpec("Serializes an array of objects with an explicit column order",
+			`root.orders = this.orders.format_csv(headers: ["foo","bar"])`,
+			`{"orders":[{"foo":"foo 1","bar":"bar 1"},{"foo":"foo 2"}]}`,
+			`{"orders":"foo,bar\nfoo 1,bar 1\nfoo 2,\n"}`,
+		),
+		NewExampleSpec("Serializes an array of row arrays delimited by tabs",
+			`root.orders = this.orders.format_csv(delimiter: "\t")`,
+			`{"orders":[["foo 1","bar 1"],["foo 2","bar 2"]]}`,
+			`{"orders":"foo 1\tbar 1\nfoo 2\tbar 2\n"}`,
+		)).
+		Param(ParamArray("headers", "An optional list of column names, which determines the header row and the order of columns when serializing objects.").Optional()).
+		Param(ParamString("delimiter", "The delimiter to use for separating values in each record. It must be a single character.").Default(",")).
+		AtVersion("4.18.0"),
+	formatCSVMethod,
+)
+
+var errMixedCSVRows = errors.New("rows must either all be objects or all be arrays")
+
+func formatCSVMethod(args *ParsedParams) (simpleMethod, error) {
+	delimStr, err := args.FieldString("delimiter")
+	if err != nil {
+		return nil, err
+	}
+	delimRunes := []rune(delimStr)
+	if len(delimRunes) != 1 {
+		return nil, errors.New("delimiter value must be exactly one character")
+	}
+
+	var headers []string
+	headersArg, err := args.FieldOptionalArray("headers")
+	if err != nil {
+		return nil, err
+	}
+	if headersArg != nil {
+		for _, h := range *headersArg {
+			headers = append(headers, IToString(h))
+		}
+	}
+
+	return func(v any, ctx FunctionContext) (any, error) {
+		rows, ok := v.([]any)
+		if !ok {
+			return nil, NewTypeError(v, ValueArray)
+		}
+
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Comma = delimRunes[0]
+
+		rowHeaders := headers
+		var objectRows bool
+		for i, row := range rows {
+			var record []string
+			switch t := row.(type) {
+			case map[string]any:
+				if i > 0 && !objectRows {
+					return nil, fmt.Errorf("row %v: %w", i, errMixedCSVRows)
+				}
+				objectRows = true
+				if rowHeaders == nil {
+					rowHeaders = make([]string, 0, len(t))
+					for k := range t {
+						rowHeaders = append(rowHeaders, k)
+					}
+					sort.Strings(rowHeaders)
+				}
+				if i == 0 {
+					if err := w.Write(rowHeaders); err != nil {
+						return nil, err
+					}
+				}
+				record = make([]string, len(rowHeaders))
+				for j, h := range rowHeaders {
+					if e, exists := t[h]; exists && e != nil {
+						record[j] = IToString(e)
+					}
+				}
+			case []any:
+				if objectRows {
+					return nil, fmt.Errorf("row %v: %w", i, errMixedCSVRows)
+				}
+				record = make([]string, len(t))
+				for j, e := range t {
+					if e != nil {
+						record[j] = IToString(e)
+					}
+				}
+			default:
+				return nil, fmt.Errorf("row %v: %w", i, NewTypeError(row, ValueObject, ValueArray))
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	}, nil
+}
+
 //------------------------------------------------------------------------------
 
 var _ = registerSimpleMethod(
@@ -1604,6 +1732,11 @@ var _ = registerSimpleMethod(
 			`{"value":"option1: value1"}`,
 			`{"matches":{"0":"option1: value1","key":"option1","value":"value1"}}`,
 		),
+		NewExampleSpec("Named groups can be used in order to extract parts of a string into fields of a structured document.",
+			`root = this.message.re_find_object("^(?P<level>[A-Z]+) (?P<text>.*)$").without("0")`,
+			`{"message":"WARN disk is nearly full"}`,
+			`{"level":"WARN","text":"disk is nearly full"}`,
+		),
 	).Param(ParamString("pattern", "The pattern to match against.")),
 	func(args *ParsedParams) (simpleMethod, error) {
 		reStr, err := args.FieldString("pattern")
@@ -1770,6 +1903,11 @@ var _ = registerSimpleMethod(
 			`{"value":"foo ADD 70"}`,
 			`{"new_value":"foo +(70)"}`,
 		),
+		NewExampleSpec("Named submatches can be referenced within the value with the syntax `${name}`.",
+			`root.new_value = this.value.re_replace_all("(?P<key>\\w+)=(?P<value>\\w+)","${value}=${key}")`,
+			`{"value":"foo=bar baz=buz"}`,
+			`{"new_value":"bar=foo buz=baz"}`,
+		),
 	).
 		Param(ParamString("pattern", "The pattern to match against.")).
 		Param(ParamString("value", "The value to replace with.")),
@@ -2020,3 +2158,59 @@ root.description = this.description.trim_suffix("_foobar")`,
 		}, nil
 	},
 )
+
+var _ = registerSimpleMethod(
+	NewMethodSpec(
+		"repeat", "",
+	).InCategory(
+		MethodCategoryStrings,
+		"Returns a string consisting of a number of copies of the target string.",
+		NewExampleSpec("",
+			`root.divider = this.char.repeat(this.width)`,
+			`{"char":"-","width":5}`,
+			`{"divider":"-----"}`,
+		),
+	).Param(ParamInt64("count", "The number of times to repeat the string.")).
+		AtVersion("4.18.0"),
+	func(args *ParsedParams) (simpleMethod, error) {
+		count, err := args.FieldInt64("count")
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, fmt.Errorf("count must not be negative, got %v", count)
+		}
+		return func(v any, ctx FunctionContext) (any, error) {
+			switch t := v.(type) {
+			case string:
+				if err := checkRepeatLength(len(t), count); err != nil {
+					return nil, err
+				}
+				return strings.Repeat(t, int(count)), nil
+			case []byte:
+				if err := checkRepeatLength(len(t), count); err != nil {
+					return nil, err
+				}
+				return bytes.Repeat(t, int(count)), nil
+			}
+			return nil, NewTypeError(v, ValueString)
+		}, nil
+	},
+)
+
+// maxRepeatLength is the largest result, in bytes, that the repeat method is
+// allowed to produce.
+const maxRepeatLength = 1 << 28
+
+func checkRepeatLength(size int, count int64) error {
+	if size == 0 {
+		if count > maxRepeatLength {
+			return fmt.Errorf("repeat count %v exceeds the maximum of %v", count, maxRepeatLength)
+		}
+		return nil
+	}
+	if count > int64(maxRepeatLength/size) {
+		return fmt.Errorf("repeating %v bytes %v times exceeds the maximum result length of %v bytes", size, count, maxRepeatLength)
+	}
+	return nil
+}
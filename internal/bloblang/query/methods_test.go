@@ -170,6 +170,42 @@ func TestMethods(t *testing.T) {
 			),
 			err: "string literal: record on line 2: wrong number of fields",
 		},
+		"check format csv objects": {
+			input: methods(
+				jsonFn(`[{"foo":"1","bar":2},{"foo":"a,b","bar":null}]`),
+				method("format_csv"),
+			),
+			output: "bar,foo\n2,1\n,\"a,b\"\n",
+		},
+		"check format csv arrays": {
+			input: methods(
+				jsonFn(`[["a","b"],[1,true]]`),
+				method("format_csv"),
+			),
+			output: "a,b\n1,true\n",
+		},
+		"check format csv round trip": {
+			input: methods(
+				literalFn("foo,bar\nfoo 1,bar 1\nfoo 2,bar 2"),
+				method("parse_csv"),
+				method("format_csv", []any{"foo", "bar"}),
+			),
+			output: "foo,bar\nfoo 1,bar 1\nfoo 2,bar 2\n",
+		},
+		"check format csv bad row": {
+			input: methods(
+				jsonFn(`["foo"]`),
+				method("format_csv"),
+			),
+			err: "array literal: row 0: expected object or array value, got string (\"foo\")",
+		},
+		"check format csv mixed rows": {
+			input: methods(
+				jsonFn(`[["a","b"],{"foo":"bar"}]`),
+				method("format_csv"),
+			),
+			err: "array literal: row 1: rows must either all be objects or all be arrays",
+		},
 		"check explode 1": {
 			input: methods(
 				jsonFn(`{"foo":[1,2,3],"id":"bar"}`),
@@ -865,6 +901,21 @@ func TestMethods(t *testing.T) {
 			),
 			output: `hello world`,
 		},
+		"check base64rawstd encode": {
+			input: methods(
+				literalFn("<<???>>"),
+				method("encode", "base64rawstd"),
+			),
+			output: `PDw/Pz8+Pg`,
+		},
+		"check base64rawstd decode": {
+			input: methods(
+				literalFn("PDw/Pz8+Pg"),
+				method("decode", "base64rawstd"),
+				method("string"),
+			),
+			output: `<<???>>`,
+		},
 		"check base64url encode": {
 			input: methods(
 				literalFn("<<???>>"),
@@ -1064,6 +1115,30 @@ func TestMethods(t *testing.T) {
 			},
 			output: []byte("the foo bar"),
 		},
+		"check repeat": {
+			input: methods(
+				literalFn("ab"),
+				method("repeat", int64(3)),
+			),
+			output: "ababab",
+		},
+		"check repeat bytes": {
+			input: methods(
+				function(`content`),
+				method("repeat", int64(2)),
+			),
+			messages: []easyMsg{
+				{content: `foo`},
+			},
+			output: []byte("foofoo"),
+		},
+		"check repeat too large": {
+			input: methods(
+				literalFn("ab"),
+				method("repeat", int64(1)<<62),
+			),
+			err: "string literal: repeating 2 bytes 4611686018427387904 times exceeds the maximum result length of 268435456 bytes",
+		},
 		"check capitalize": {
 			input: methods(
 				literalFn("the foo bar"),
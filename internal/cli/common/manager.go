@@ -16,6 +16,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/api"
 	"github.com/benthosdev/benthos/v4/internal/bundle"
+	"github.com/benthosdev/benthos/v4/internal/component/connection"
 	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/config"
 	"github.com/benthosdev/benthos/v4/internal/docs"
@@ -70,6 +71,12 @@ func CreateManager(
 		return
 	}
 
+	connEvents := connection.NewBus()
+	if err = connection.Subscribe(conf.ConnectionEvents, logger, connEvents); err != nil {
+		err = fmt.Errorf("failed to initialise connection events: %w", err)
+		return
+	}
+
 	// Create HTTP API with a sanitised service config.
 	var sanitNode yaml.Node
 	if err = sanitNode.Encode(conf); err == nil {
@@ -95,6 +102,7 @@ func CreateManager(
 		manager.OptSetLogger(logger),
 		manager.OptSetMetrics(stats),
 		manager.OptSetTracer(trac),
+		manager.OptSetConnectionEvents(connEvents),
 		manager.OptSetStreamsMode(streamsMode),
 	}, mgrOpts...)
 
@@ -1,16 +1,26 @@
 package common
 
 import (
+	"os"
+
 	"github.com/benthosdev/benthos/v4/internal/config"
 	"github.com/benthosdev/benthos/v4/internal/filepath/ifs"
+	"github.com/benthosdev/benthos/v4/internal/secrets"
 
 	"github.com/urfave/cli/v2"
 )
 
+// EnvSetPrefix is the prefix of environment variables that express config
+// field overrides with the same syntax as the --set flag, e.g.
+// `BENTHOS_SET_BROKER="input.kafka.addresses=localhost:9092"`.
+const EnvSetPrefix = "BENTHOS_SET_"
+
 // ReadConfig attempts to read a general service wide config via a returned
 // config.Reader based on input CLI flags. This includes applying any config
-// overrides expressed by the --set flag.
-func ReadConfig(c *cli.Context, streamsMode bool) (mainPath string, inferred bool, conf *config.Reader) {
+// overrides expressed by environment variables prefixed with EnvSetPrefix,
+// followed by those expressed by the --set flag, and secrets lookups expressed
+// by the --secrets flag.
+func ReadConfig(c *cli.Context, streamsMode bool) (mainPath string, inferred bool, conf *config.Reader, err error) {
 	path := c.String("config")
 	if path == "" {
 		// Iterate default config paths
@@ -26,12 +36,20 @@ func ReadConfig(c *cli.Context, streamsMode bool) (mainPath string, inferred boo
 			}
 		}
 	}
+	lookupFn, err := secrets.ParseLookupURNs(c.StringSlice("secrets")...)
+	if err != nil {
+		return
+	}
 	opts := []config.OptFunc{
+		config.OptAddOverrides(config.OverridesFromEnviron(EnvSetPrefix, os.Environ())...),
 		config.OptAddOverrides(c.StringSlice("set")...),
 		config.OptTestSuffix("_benthos_test"),
+		config.OptUseEnvLookupFunc(func(name string) (string, bool, error) {
+			return lookupFn(c.Context, name)
+		}),
 	}
 	if streamsMode {
 		opts = append(opts, config.OptSetStreamPaths(c.Args().Slice()...))
 	}
-	return path, inferred, config.NewReader(path, c.StringSlice("resources"), opts...)
+	return path, inferred, config.NewReader(path, c.StringSlice("resources"), opts...), nil
 }
@@ -0,0 +1,27 @@
+//go:build !wasm
+
+package common
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/benthosdev/benthos/v4/internal/config"
+	"github.com/benthosdev/benthos/v4/internal/manager"
+)
+
+// watchReloadSignal reloads the main config every time the process receives a
+// SIGHUP. Failures are logged by the config reader and the running pipeline is
+// left untouched.
+func watchReloadSignal(confReader *config.Reader, mgr *manager.Type, strict bool) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+
+	go func() {
+		for range sigChan {
+			mgr.Logger().Infoln("Received SIGHUP, attempting to reload the main config")
+			_ = confReader.ReloadMain(mgr, strict)
+		}
+	}()
+}
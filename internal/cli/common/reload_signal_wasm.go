@@ -0,0 +1,11 @@
+//go:build wasm
+
+package common
+
+import (
+	"github.com/benthosdev/benthos/v4/internal/config"
+	"github.com/benthosdev/benthos/v4/internal/manager"
+)
+
+// watchReloadSignal does nothing in WASM builds as signals are not supported.
+func watchReloadSignal(confReader *config.Reader, mgr *manager.Type, strict bool) {}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -21,7 +22,11 @@ import (
 // RunService runs a service command (either the default or the streams
 // subcommand).
 func RunService(c *cli.Context, version, dateBuilt string, streamsMode bool) int {
-	mainPath, inferredMainPath, confReader := ReadConfig(c, streamsMode)
+	mainPath, inferredMainPath, confReader, err := ReadConfig(c, streamsMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration file read error: %v\n", err)
+		return 1
+	}
 
 	conf, lints, err := confReader.Read()
 	if err != nil {
@@ -75,6 +80,9 @@ func RunService(c *cli.Context, version, dateBuilt string, streamsMode bool) int
 		stoppableStream = initStreamsMode(strict, watching, enableStreamsAPI, confReader, stoppableManager.Manager())
 	} else {
 		stoppableStream, dataStreamClosedChan = initNormalMode(conf, strict, watching, confReader, stoppableManager.Manager())
+		if mainPath != "" {
+			watchReloadSignal(confReader, stoppableManager.Manager(), strict)
+		}
 	}
 
 	return RunManagerUntilStopped(c, conf, stoppableManager, stoppableStream, dataStreamClosedChan)
@@ -178,9 +186,14 @@ func initNormalMode(
 
 	stoppedChan = make(chan struct{})
 	var closeOnce sync.Once
-	streamInit := func() (Stoppable, error) {
+
+	// Each stream is tagged with a generation that is bumped once it has been
+	// replaced, which prevents streams closed by a config reload from shutting
+	// down the service when the watcher isn't enabled.
+	var streamGen int64
+	streamInit := func(gen int64) (Stoppable, error) {
 		return stream.New(conf.Config, mgr, stream.OptOnClose(func() {
-			if !watching {
+			if !watching && gen >= atomic.LoadInt64(&streamGen) {
 				closeOnce.Do(func() {
 					close(stoppedChan)
 				})
@@ -189,7 +202,7 @@ func initNormalMode(
 	}
 
 	var stoppableStream *SwappableStopper
-	if initStream, err := streamInit(); err != nil {
+	if initStream, err := streamInit(0); err != nil {
 		logger.Errorf("Service closing due to: %v\n", err)
 		os.Exit(1)
 	} else {
@@ -201,10 +214,25 @@ func initNormalMode(
 		ctx, done := context.WithTimeout(context.Background(), 30*time.Second)
 		defer done()
 		// NOTE: We're ignoring observability field changes for now.
-		return stoppableStream.Replace(ctx, func() (Stoppable, error) {
+		prevConfig := conf.Config
+		if err := stoppableStream.Swap(ctx, func() (Stoppable, error) {
+			// The updated pipeline is created while the previous one is still
+			// running, which is only stopped once the new one is ready.
+			gen := atomic.LoadInt64(&streamGen) + 1
 			conf.Config = newStreamConf.Config
-			return streamInit()
-		})
+			strm, err := streamInit(gen)
+			if err != nil {
+				return nil, err
+			}
+			atomic.StoreInt64(&streamGen, gen)
+			return strm, nil
+		}); err != nil {
+			// Fail safe by leaving the previous pipeline running.
+			conf.Config = prevConfig
+			logger.Warnln("Keeping the previous pipeline running after the updated config failed to initialise")
+			return config.NewErrNoReread(err)
+		}
+		return nil
 	}); err != nil {
 		logger.Errorf("Failed to create config file watcher: %v", err)
 		os.Exit(1)
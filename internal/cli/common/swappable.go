@@ -92,3 +92,29 @@ func (s *SwappableStopper) Replace(ctx context.Context, fn func() (Stoppable, er
 	s.current = newStoppable
 	return nil
 }
+
+// Swap the resource with something new, which is constructed by the provided
+// closure before the existing resource is stopped. If the closure returns an
+// error then the existing resource is left running and the error is returned.
+func (s *SwappableStopper) Swap(ctx context.Context, fn func() (Stoppable, error)) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.stopped {
+		// If the outer stream has been stopped then do not create a new one.
+		return nil
+	}
+
+	newStoppable, err := fn()
+	if err != nil {
+		return fmt.Errorf("failed to init updated stream: %w", err)
+	}
+
+	// As with Replace, an error here indicates that the existing resource
+	// hasn't fully cleaned up before reaching the context deadline, but we
+	// proceed regardless as the replacement is already running.
+	_ = s.current.Stop(ctx)
+
+	s.current = newStoppable
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"sort"
 	"sync"
 
 	"github.com/fatih/color"
@@ -218,6 +219,15 @@ func LintAction(c *cli.Context, stderr io.Writer) int {
 		return 0
 	}
 
+	// Targets are linted in parallel, so sort the results in order to print
+	// them consistently.
+	sort.SliceStable(pathLints, func(i, j int) bool {
+		if pathLints[i].source != pathLints[j].source {
+			return pathLints[i].source < pathLints[j].source
+		}
+		return pathLints[i].lint.Line < pathLints[j].lint.Line
+	})
+
 	for _, lint := range pathLints {
 		lintText := fmt.Sprintf("%v%v\n", lint.source, lint.lint.Error())
 		if lint.lint.Type == docs.LintFailedRead || lint.lint.Type == docs.LintComponentMissing {
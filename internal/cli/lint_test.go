@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -130,3 +131,42 @@ output:
 		})
 	}
 }
+
+func TestLintsSorted(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"a.yaml": `
+input:
+  generate:
+    mapping: 'root.id = uuid_v4()'
+    aaa: nope
+output:
+  drop: {}
+  bbb: nope
+`,
+		"b.yaml": `
+input:
+  generate:
+    mapping: 'root.id = uuid_v4()'
+    ccc: nope
+output:
+  drop: {}
+`,
+	}
+	for name, c := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(c), 0o644))
+	}
+
+	code, outStr := executeLintSubcmd(t, []string{
+		"benthos", "lint", filepath.Join(tmpDir, "b.yaml"), filepath.Join(tmpDir, "a.yaml"),
+	})
+	assert.Equal(t, 1, code)
+
+	aIndex, bIndex, cIndex := strings.Index(outStr, "aaa"), strings.Index(outStr, "bbb"), strings.Index(outStr, "ccc")
+	require.NotEqual(t, -1, aIndex, outStr)
+	require.NotEqual(t, -1, bIndex, outStr)
+	require.NotEqual(t, -1, cIndex, outStr)
+	assert.Less(t, aIndex, bIndex, outStr)
+	assert.Less(t, bIndex, cIndex, outStr)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/config/schema"
 	"github.com/benthosdev/benthos/v4/internal/cuegen"
+	"github.com/benthosdev/benthos/v4/internal/docs"
 )
 
 func listCliCommand() *cli.Command {
@@ -24,7 +25,8 @@ components will be shown.
 
   benthos list
   benthos list --format json inputs output
-  benthos list rate-limits buffers`[1:],
+  benthos list rate-limits buffers
+  benthos list --summaries processors`[1:],
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:  "format",
@@ -36,16 +38,70 @@ components will be shown.
 				Value: "",
 				Usage: "Filter the component list to only those matching the given status. Options are stable, beta or experimental.",
 			},
+			&cli.BoolFlag{
+				Name:  "summaries",
+				Value: false,
+				Usage: "Print a short summary of each component alongside its name, only applies to the text format.",
+			},
 		},
 		Action: func(c *cli.Context) error {
-			listComponents(c)
+			if err := listComponents(c); err != nil {
+				fmt.Fprintf(os.Stderr, "List error: %v\n", err)
+				os.Exit(1)
+			}
 			os.Exit(0)
 			return nil
 		},
 	}
 }
 
-func listComponents(c *cli.Context) {
+// shortSummary reduces a markdown summary or description down to its first
+// sentence.
+func shortSummary(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, "\n\n"); i != -1 {
+		s = s[:i]
+	}
+	s = strings.ReplaceAll(s, "\n", " ")
+	if i := strings.Index(s, ". "); i != -1 {
+		s = s[:i+1]
+	}
+	return strings.TrimSpace(s)
+}
+
+func componentSummaries(schema schema.Full) map[string]map[string]string {
+	summaries := map[string]map[string]string{}
+	addComponents := func(k string, specs []docs.ComponentSpec) {
+		m := map[string]string{}
+		for _, s := range specs {
+			m[s.Name] = shortSummary(s.Summary)
+		}
+		summaries[k] = m
+	}
+	addComponents("inputs", schema.Inputs)
+	addComponents("processors", schema.Processors)
+	addComponents("outputs", schema.Outputs)
+	addComponents("caches", schema.Caches)
+	addComponents("rate-limits", schema.RateLimits)
+	addComponents("buffers", schema.Buffers)
+	addComponents("metrics", schema.Metrics)
+	addComponents("tracers", schema.Tracers)
+
+	funcs := map[string]string{}
+	for _, f := range schema.BloblangFunctions {
+		funcs[f.Name] = shortSummary(f.Description)
+	}
+	summaries["bloblang-functions"] = funcs
+
+	methods := map[string]string{}
+	for _, m := range schema.BloblangMethods {
+		methods[m.Name] = shortSummary(m.Description)
+	}
+	summaries["bloblang-methods"] = methods
+	return summaries
+}
+
+func listComponents(c *cli.Context) error {
 	ofTypes := map[string]struct{}{}
 	for _, k := range c.Args().Slice() {
 		ofTypes[k] = struct{}{}
@@ -59,6 +115,10 @@ func listComponents(c *cli.Context) {
 	switch c.String("format") {
 	case "text":
 		flat := schema.Flattened()
+		var summaries map[string]map[string]string
+		if c.Bool("summaries") {
+			summaries = componentSummaries(schema)
+		}
 		i := 0
 		for _, k := range []string{
 			"inputs",
@@ -83,7 +143,11 @@ func listComponents(c *cli.Context) {
 			title := cases.Title(language.English).String(strings.ReplaceAll(k, "-", " "))
 			fmt.Printf("%v:\n", title)
 			for _, t := range flat[k] {
-				fmt.Printf("  - %v\n", t)
+				if summary := summaries[k][t]; summary != "" {
+					fmt.Printf("  - %v: %v\n", t, summary)
+				} else {
+					fmt.Printf("  - %v\n", t)
+				}
 			}
 		}
 	case "json":
@@ -119,5 +183,8 @@ func listComponents(c *cli.Context) {
 			panic(err)
 		}
 		fmt.Println(string(source))
+	default:
+		return fmt.Errorf("format not recognised: %v", c.String("format"))
 	}
+	return nil
 }
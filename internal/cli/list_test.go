@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListShortSummary(t *testing.T) {
+	for in, exp := range map[string]string{
+		"":                                  "",
+		"Does a thing.":                     "Does a thing.",
+		"\nDoes a thing. And another.":      "Does a thing.",
+		"Does a\nthing.\n\nMore detail.":    "Does a thing.",
+		"Uses `foo.bar` paths, and things.": "Uses `foo.bar` paths, and things.",
+	} {
+		assert.Equal(t, exp, shortSummary(in), in)
+	}
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"runtime/debug"
@@ -101,6 +102,10 @@ func App() *cli.App {
 			Aliases: []string{"t"},
 			Usage:   "EXPERIMENTAL: import Benthos templates, supports glob patterns (requires quotes)",
 		},
+		&cli.StringSliceFlag{
+			Name:  "secrets",
+			Usage: "attempt to resolve config variable references such as `${FOO}` from one or more secret stores, tried in order, e.g. `\"aws_secrets_manager://?region=eu-west-1\"` or `\"vault://localhost:8200/secret\"`, followed by `env:` in order to fall back to environment variables",
+		},
 		&cli.BoolFlag{
 			Name:  "chilled",
 			Value: false,
@@ -189,9 +194,26 @@ This simple command is useful for sanity checking a config if it isn't
 behaving as expected, as it shows you a normalised version after environment
 variables have been resolved:
 
-  benthos -c ./config.yaml echo | less`[1:],
+  benthos -c ./config.yaml echo | less
+  benthos -c ./config.yaml echo --skip-defaults --format json`[1:],
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Value: "yaml",
+						Usage: "Print the config in a specific format. Options are yaml or json.",
+					},
+					&cli.BoolFlag{
+						Name:  "skip-defaults",
+						Value: false,
+						Usage: "Omit fields that are set to their default values.",
+					},
+				},
 				Action: func(c *cli.Context) error {
-					_, _, confReader := common.ReadConfig(c, false)
+					_, _, confReader, err := common.ReadConfig(c, false)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Configuration file read error: %v\n", err)
+						os.Exit(1)
+					}
 					conf, _, err := confReader.Read()
 					if err != nil {
 						fmt.Fprintf(os.Stderr, "Configuration file read error: %v\n", err)
@@ -202,12 +224,28 @@ variables have been resolved:
 						sanitConf := docs.NewSanitiseConfig()
 						sanitConf.RemoveTypeField = true
 						sanitConf.ScrubSecrets = true
+						if c.Bool("skip-defaults") {
+							sanitConf.Filter = docs.ShouldDropDefaults()
+						}
 						err = config.Spec().SanitiseYAML(&node, sanitConf)
 					}
 					if err == nil {
-						var configYAML []byte
-						if configYAML, err = config.MarshalYAML(node); err == nil {
-							fmt.Println(string(configYAML))
+						switch format := c.String("format"); format {
+						case "yaml":
+							var configYAML []byte
+							if configYAML, err = config.MarshalYAML(node); err == nil {
+								fmt.Println(string(configYAML))
+							}
+						case "json":
+							var configAny any
+							if err = node.Decode(&configAny); err == nil {
+								var configJSON []byte
+								if configJSON, err = json.MarshalIndent(configAny, "", "  "); err == nil {
+									fmt.Println(string(configJSON))
+								}
+							}
+						default:
+							err = fmt.Errorf("format not recognised: %v", format)
 						}
 					}
 					if err != nil {
@@ -0,0 +1,150 @@
+//go:build !windows && !wasm
+
+package cli_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	icli "github.com/benthosdev/benthos/v4/internal/cli"
+)
+
+func TestRunCLIReloadSignalWithoutWatcher(t *testing.T) {
+	// Ensures that a SIGHUP received before the service registers its own
+	// handler doesn't terminate the test process.
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	tmpDir := t.TempDir()
+	confPath := filepath.Join(tmpDir, "foo.yaml")
+	outPath := filepath.Join(tmpDir, "out.txt")
+
+	writeConf := func(id string) {
+		require.NoError(t, os.WriteFile(confPath, fmt.Appendf(nil, `
+input:
+  generate:
+    mapping: 'root.id = "%v"'
+    interval: "10ms"
+output:
+  file:
+    codec: lines
+    path: %v
+`, id, outPath), 0o644))
+	}
+	writeConf("foobar")
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*3))
+	defer cancel()
+
+	exitChan := make(chan error, 1)
+	go func() {
+		exitChan <- icli.App().RunContext(ctx, []string{"benthos", "-c", confPath})
+	}()
+
+	require.Eventually(t, func() bool {
+		data, _ := os.ReadFile(outPath)
+		return len(data) > 0
+	}, time.Second, time.Millisecond*10)
+
+	writeConf("barbaz")
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	require.Eventually(t, func() bool {
+		data, _ := os.ReadFile(outPath)
+		return strings.Contains(string(data), "barbaz")
+	}, time.Second, time.Millisecond*10)
+
+	select {
+	case err := <-exitChan:
+		t.Fatalf("service stopped after reload: %v", err)
+	case <-time.After(time.Millisecond * 200):
+	}
+
+	// The service should only stop once the run context deadline is reached.
+	select {
+	case err := <-exitChan:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 10):
+		t.Fatal("service did not stop")
+	}
+}
+
+func TestRunCLIReloadSignalInitFailure(t *testing.T) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	tmpDir := t.TempDir()
+	confPath := filepath.Join(tmpDir, "foo.yaml")
+	outPath := filepath.Join(tmpDir, "out.txt")
+
+	require.NoError(t, os.WriteFile(confPath, fmt.Appendf(nil, `
+input:
+  generate:
+    mapping: 'root.id = "foobar"'
+    interval: "10ms"
+output:
+  file:
+    codec: lines
+    path: %v
+`, outPath), 0o644))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*3))
+	defer cancel()
+
+	exitChan := make(chan error, 1)
+	go func() {
+		exitChan <- icli.App().RunContext(ctx, []string{"benthos", "-c", confPath})
+	}()
+
+	require.Eventually(t, func() bool {
+		data, _ := os.ReadFile(outPath)
+		return len(data) > 0
+	}, time.Second, time.Millisecond*10)
+
+	// A config that passes linting but fails to initialise as the referenced
+	// output resource doesn't exist.
+	require.NoError(t, os.WriteFile(confPath, []byte(`
+input:
+  generate:
+    mapping: 'root.id = "barbaz"'
+    interval: "10ms"
+output:
+  resource: does_not_exist
+`), 0o644))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	// The previous pipeline should continue writing data.
+	<-time.After(time.Millisecond * 200)
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	prevLen := len(data)
+
+	require.Eventually(t, func() bool {
+		data, _ := os.ReadFile(outPath)
+		return len(data) > prevLen
+	}, time.Second, time.Millisecond*10)
+
+	select {
+	case err := <-exitChan:
+		t.Fatalf("service stopped after failed reload: %v", err)
+	case <-time.After(time.Millisecond * 200):
+	}
+
+	select {
+	case err := <-exitChan:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 10):
+		t.Fatal("service did not stop")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	yaml "gopkg.in/yaml.v3"
 
@@ -144,6 +145,15 @@ func (c *Case) ExecuteFrom(dir string, provider ProcProvider) (failures []CaseFa
 			return nil, fmt.Errorf("failed to initialise processors '%v': %v", c.TargetProcessors, err)
 		}
 	}
+	defer func() {
+		// Processors are initialised for each test case and must be closed in
+		// order to avoid leaking any resources they hold.
+		ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+		defer done()
+		for _, proc := range procSet {
+			_ = proc.Close(ctx)
+		}
+	}()
 
 	reportFailure := func(reason string) {
 		failures = append(failures, CaseFailure{
@@ -1,6 +1,7 @@
 package test_test
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/cli/test"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+	"github.com/benthosdev/benthos/v4/internal/message"
 
 	_ "github.com/benthosdev/benthos/v4/internal/impl/pure"
 )
@@ -329,6 +331,41 @@ output_batches:
 	}, fails)
 }
 
+type closeCountingProc struct {
+	closed int
+}
+
+func (c *closeCountingProc) ProcessBatch(ctx context.Context, b message.Batch) ([]message.Batch, error) {
+	return []message.Batch{b}, nil
+}
+
+func (c *closeCountingProc) Close(ctx context.Context) error {
+	c.closed++
+	return nil
+}
+
+func TestCaseClosesProcessors(t *testing.T) {
+	proc := &closeCountingProc{}
+	provider := mockProvider{
+		"/pipeline/processors": []processor.V1{proc},
+	}
+
+	c := test.NewCase()
+	require.NoError(t, yaml.Unmarshal([]byte(`
+name: passthrough
+input_batch:
+  - content: foo
+output_batches:
+-
+  - content_equals: foo
+`), &c))
+
+	fails, err := c.ExecuteFrom("", provider)
+	require.NoError(t, err)
+	assert.Empty(t, fails)
+	assert.Equal(t, 1, proc.closed)
+}
+
 func TestFileCaseConditions(t *testing.T) {
 	color.NoColor = true
 
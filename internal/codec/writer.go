@@ -1,12 +1,18 @@
 package codec
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/benthosdev/benthos/v4/internal/docs"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -18,8 +24,11 @@ var WriterDocs = docs.FieldString(
 ).HasAnnotatedOptions(
 	"all-bytes", "Only applicable to file based outputs. Writes each message to a file in full, if the file already exists the old content is deleted.",
 	"append", "Append each message to the output stream without any delimiter or special encoding.",
-	"lines", "Append each message to the output stream followed by a line break.",
+	"lines", "Append each message to the output stream followed by a line break. When messages are single line JSON documents this results in newline delimited JSON (NDJSON).",
 	"delim:x", "Append each message to the output stream followed by a custom delimiter.",
+	"json-array", "Write each message as an element of a single JSON array, messages must be valid JSON documents. If the file already exists the old content is deleted, and therefore this codec cannot be used with an interpolated path.",
+	"length-prefixed", "Append each message to the output stream prefixed by its length in bytes as a four byte big endian unsigned integer.",
+	"tar", "Write each message as a file entry of a tar archive. Entries are named after the metadata field `tar_name` when present, otherwise by the index of the message within the archive. If the file already exists the old content is deleted, and therefore this codec cannot be used with an interpolated path.",
 )
 
 //------------------------------------------------------------------------------
@@ -36,6 +45,10 @@ type WriterConfig struct {
 	Append     bool
 	Truncate   bool
 	CloseAfter bool
+
+	// Document is true when the codec encodes all messages written to a file
+	// as a single document, which cannot be appended to once it is closed.
+	Document bool
 }
 
 // WriterConstructor creates a writer from an io.WriteCloser.
@@ -54,6 +67,12 @@ func GetWriter(codec string) (WriterConstructor, WriterConfig, error) {
 		}, customDelimConfig, nil
 	case "lines":
 		return newLinesWriter, linesWriterConfig, nil
+	case "json-array":
+		return newJSONArrayWriter, jsonArrayWriterConfig, nil
+	case "length-prefixed":
+		return newLengthPrefixedWriter, lengthPrefixedWriterConfig, nil
+	case "tar":
+		return newTarWriter, tarWriterConfig, nil
 	}
 	if strings.HasPrefix(codec, "delim:") {
 		by := strings.TrimPrefix(codec, "delim:")
@@ -148,3 +167,130 @@ func (d *customDelimWriter) Write(ctx context.Context, p *message.Part) error {
 func (d *customDelimWriter) Close(ctx context.Context) error {
 	return d.w.Close()
 }
+
+//------------------------------------------------------------------------------
+
+var jsonArrayWriterConfig = WriterConfig{
+	Truncate: true,
+	Document: true,
+}
+
+type jsonArrayWriter struct {
+	w       io.WriteCloser
+	written bool
+}
+
+func newJSONArrayWriter(w io.WriteCloser) (Writer, error) {
+	return &jsonArrayWriter{w: w}, nil
+}
+
+func (j *jsonArrayWriter) Write(ctx context.Context, p *message.Part) error {
+	partBytes := bytes.TrimSpace(p.AsBytes())
+	if !json.Valid(partBytes) {
+		return errors.New("message is not a valid JSON document")
+	}
+
+	prefix := []byte(",")
+	if !j.written {
+		prefix = []byte("[")
+	}
+	if _, err := j.w.Write(prefix); err != nil {
+		return err
+	}
+	j.written = true
+
+	_, err := j.w.Write(partBytes)
+	return err
+}
+
+func (j *jsonArrayWriter) Close(ctx context.Context) error {
+	closing := []byte("]")
+	if !j.written {
+		closing = []byte("[]")
+	}
+	_, err := j.w.Write(closing)
+	if cErr := j.w.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+//------------------------------------------------------------------------------
+
+var lengthPrefixedWriterConfig = WriterConfig{
+	Append: true,
+}
+
+type lengthPrefixedWriter struct {
+	w io.WriteCloser
+}
+
+func newLengthPrefixedWriter(w io.WriteCloser) (Writer, error) {
+	return &lengthPrefixedWriter{w: w}, nil
+}
+
+func (l *lengthPrefixedWriter) Write(ctx context.Context, p *message.Part) error {
+	partBytes := p.AsBytes()
+	if uint64(len(partBytes)) > math.MaxUint32 {
+		return fmt.Errorf("message size %v exceeds the maximum length prefix", len(partBytes))
+	}
+
+	var prefix [4]byte
+	binary.BigEndian.PutUint32(prefix[:], uint32(len(partBytes)))
+	if _, err := l.w.Write(prefix[:]); err != nil {
+		return err
+	}
+	_, err := l.w.Write(partBytes)
+	return err
+}
+
+func (l *lengthPrefixedWriter) Close(ctx context.Context) error {
+	return l.w.Close()
+}
+
+//------------------------------------------------------------------------------
+
+var tarWriterConfig = WriterConfig{
+	Truncate: true,
+	Document: true,
+}
+
+type tarWriter struct {
+	w     io.WriteCloser
+	tw    *tar.Writer
+	count int
+}
+
+func newTarWriter(w io.WriteCloser) (Writer, error) {
+	return &tarWriter{w: w, tw: tar.NewWriter(w)}, nil
+}
+
+func (t *tarWriter) Write(ctx context.Context, p *message.Part) error {
+	name := p.MetaGetStr("tar_name")
+	if name == "" {
+		name = strconv.Itoa(t.count)
+	}
+	t.count++
+
+	partBytes := p.AsBytes()
+	if err := t.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(partBytes)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	if _, err := t.tw.Write(partBytes); err != nil {
+		return err
+	}
+	return t.tw.Flush()
+}
+
+func (t *tarWriter) Close(ctx context.Context) error {
+	err := t.tw.Close()
+	if cErr := t.w.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package codec
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/message"
+)
+
+type noopWriteCloser struct {
+	io.Writer
+}
+
+func (n noopWriteCloser) Close() error {
+	return nil
+}
+
+func testWriterSuite(t *testing.T, codec string, parts ...*message.Part) []byte {
+	t.Helper()
+
+	ctor, _, err := GetWriter(codec)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := ctor(noopWriteCloser{&buf})
+	require.NoError(t, err)
+
+	for _, p := range parts {
+		require.NoError(t, w.Write(context.Background(), p))
+	}
+	require.NoError(t, w.Close(context.Background()))
+	return buf.Bytes()
+}
+
+func TestLinesWriter(t *testing.T) {
+	assert.Equal(t, "foo\nbar\n", string(testWriterSuite(t, "lines",
+		message.NewPart([]byte("foo")),
+		message.NewPart([]byte("bar\n")),
+	)))
+}
+
+func TestJSONArrayWriter(t *testing.T) {
+	assert.Equal(t, `[{"a":1},"b",3]`, string(testWriterSuite(t, "json-array",
+		message.NewPart([]byte(`{"a":1}`)),
+		message.NewPart([]byte(`"b"`+"\n")),
+		message.NewPart([]byte(`3`)),
+	)))
+
+	assert.Equal(t, `[]`, string(testWriterSuite(t, "json-array")))
+
+	ctor, _, err := GetWriter("json-array")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := ctor(noopWriteCloser{&buf})
+	require.NoError(t, err)
+	require.Error(t, w.Write(context.Background(), message.NewPart([]byte(`not json`))))
+}
+
+func TestLengthPrefixedWriter(t *testing.T) {
+	assert.Equal(t, []byte("\x00\x00\x00\x03foo\x00\x00\x00\x00\x00\x00\x00\x05hello"), testWriterSuite(t, "length-prefixed",
+		message.NewPart([]byte("foo")),
+		message.NewPart(nil),
+		message.NewPart([]byte("hello")),
+	))
+}
+
+func TestTarWriter(t *testing.T) {
+	named := message.NewPart([]byte("bar"))
+	named.MetaSetMut("tar_name", "foo.txt")
+
+	data := testWriterSuite(t, "tar",
+		message.NewPart([]byte("first")),
+		named,
+		message.NewPart([]byte("third")),
+	)
+
+	exp := [][2]string{
+		{"0", "first"},
+		{"foo.txt", "bar"},
+		{"2", "third"},
+	}
+
+	tr := tar.NewReader(bytes.NewReader(data))
+	for _, e := range exp {
+		hdr, err := tr.Next()
+		require.NoError(t, err)
+		assert.Equal(t, e[0], hdr.Name)
+
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		assert.Equal(t, e[1], string(b))
+	}
+	_, err := tr.Next()
+	assert.Equal(t, io.EOF, err)
+}
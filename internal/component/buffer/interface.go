@@ -2,6 +2,7 @@ package buffer
 
 import (
 	"context"
+	"time"
 
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
@@ -30,3 +31,23 @@ type Streamed interface {
 	// shutting down and cleaning up resources.
 	WaitForClose(ctx context.Context) error
 }
+
+// Backlog describes the messages held within a buffer that are yet to be read.
+type Backlog struct {
+	// Messages is the number of messages waiting to be read.
+	Messages int
+
+	// Bytes is the total size of the messages waiting to be read.
+	Bytes int
+
+	// Oldest is the time at which the oldest message waiting to be read was
+	// written to the buffer, or the zero value when the buffer is empty.
+	Oldest time.Time
+}
+
+// Backlogger is an optional interface that can be implemented by a buffer
+// ReaderWriter in order to report on the messages it holds. When implemented
+// the backlog is exposed as gauge metrics.
+type Backlogger interface {
+	Backlog() Backlog
+}
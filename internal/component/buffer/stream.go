@@ -215,6 +215,40 @@ func (m *Stream) outputLoop() {
 	}
 }
 
+// backlogInterval is the period at which the backlog of buffers implementing
+// Backlogger is measured.
+var backlogInterval = time.Second
+
+// backlogLoop is an internal loop that periodically reports the backlog of a
+// buffer as gauges.
+func (m *Stream) backlogLoop(b Backlogger) {
+	var (
+		mBacklogMessages = m.stats.GetGauge("buffer_backlog_messages")
+		mBacklogBytes    = m.stats.GetGauge("buffer_backlog_bytes")
+		mBacklogAge      = m.stats.GetGauge("buffer_backlog_age_ns")
+	)
+
+	ticker := time.NewTicker(backlogInterval)
+	defer ticker.Stop()
+
+	for {
+		backlog := b.Backlog()
+		mBacklogMessages.Set(int64(backlog.Messages))
+		mBacklogBytes.Set(int64(backlog.Bytes))
+		if backlog.Oldest.IsZero() {
+			mBacklogAge.Set(0)
+		} else {
+			mBacklogAge.Set(time.Since(backlog.Oldest).Nanoseconds())
+		}
+
+		select {
+		case <-ticker.C:
+		case <-m.shutSig.HasClosedChan():
+			return
+		}
+	}
+}
+
 // Consume assigns a messages channel for the output to read.
 func (m *Stream) Consume(msgs <-chan message.Transaction) error {
 	if m.messagesIn != nil {
@@ -225,6 +259,9 @@ func (m *Stream) Consume(msgs <-chan message.Transaction) error {
 	m.closedWG.Add(2)
 	go m.inputLoop()
 	go m.outputLoop()
+	if b, ok := m.buffer.(Backlogger); ok {
+		go m.backlogLoop(b)
+	}
 	go func() {
 		m.closedWG.Wait()
 		m.shutSig.ShutdownComplete()
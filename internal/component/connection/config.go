@@ -0,0 +1,42 @@
+package connection
+
+import (
+	"github.com/benthosdev/benthos/v4/internal/docs"
+)
+
+// Config describes how connection events are surfaced.
+type Config struct {
+	LogLevel string        `json:"log_level" yaml:"log_level"`
+	Webhook  WebhookConfig `json:"webhook" yaml:"webhook"`
+}
+
+// WebhookConfig describes an HTTP endpoint that connection events are posted
+// to.
+type WebhookConfig struct {
+	URL     string `json:"url" yaml:"url"`
+	Timeout string `json:"timeout" yaml:"timeout"`
+}
+
+// NewConfig returns a connection events config with default values.
+func NewConfig() Config {
+	return Config{
+		LogLevel: "DEBUG",
+		Webhook: WebhookConfig{
+			URL:     "",
+			Timeout: "5s",
+		},
+	}
+}
+
+// Spec returns a field spec for the connection events configuration fields.
+func Spec() docs.FieldSpecs {
+	return docs.FieldSpecs{
+		docs.FieldString("log_level", "The severity level at which connection events are logged. Failed connection attempts are already logged as errors by the component and are therefore only posted to the webhook.").HasOptions(
+			"NONE", "ERROR", "WARN", "INFO", "DEBUG", "TRACE",
+		).HasDefault("DEBUG"),
+		docs.FieldObject("webhook", "Optionally post each connection event as a JSON object to an HTTP endpoint.").WithChildren(
+			docs.FieldString("url", "The URL to post connection events to, leave this field empty to disable the webhook.", "http://localhost:8080/events").HasDefault(""),
+			docs.FieldString("timeout", "The maximum period of time to wait for each webhook request to complete.").HasDefault("5s"),
+		).Advanced(),
+	}
+}
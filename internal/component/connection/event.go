@@ -0,0 +1,98 @@
+package connection
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType describes the kind of change in the connectivity of a component.
+type EventType string
+
+// EventType variants.
+const (
+	EventConnected    EventType = "connected"
+	EventDisconnected EventType = "disconnected"
+	EventFailed       EventType = "failed"
+)
+
+// Event describes a change in the connectivity of an input or output.
+type Event struct {
+	Type          EventType `json:"type"`
+	ComponentType string    `json:"component_type"`
+	Name          string    `json:"name"`
+	Label         string    `json:"label"`
+	Path          string    `json:"path"`
+	Stream        string    `json:"stream,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// Bus distributes connection events published by components to any number of
+// subscribers. Subscribers are called synchronously by the publishing
+// component and should therefore avoid blocking.
+type Bus struct {
+	mut         sync.RWMutex
+	subscribers []func(Event)
+	closers     []func()
+}
+
+// NewBus returns a connection event bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds a function to be called with each event published to the bus.
+func (b *Bus) Subscribe(fn func(Event)) {
+	b.mut.Lock()
+	b.subscribers = append(b.subscribers, fn)
+	b.mut.Unlock()
+}
+
+// OnClose adds a function to be called when the bus is closed, allowing
+// subscribers to clean up any resources they own.
+func (b *Bus) OnClose(fn func()) {
+	b.mut.Lock()
+	b.closers = append(b.closers, fn)
+	b.mut.Unlock()
+}
+
+// Close removes all subscribers from the bus and calls any functions
+// registered with OnClose. Events published after the bus is closed are
+// dropped.
+func (b *Bus) Close() {
+	b.mut.Lock()
+	closers := b.closers
+	b.subscribers, b.closers = nil, nil
+	b.mut.Unlock()
+
+	for _, fn := range closers {
+		fn()
+	}
+}
+
+// Publish an event to all subscribers of the bus.
+func (b *Bus) Publish(ev Event) {
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now()
+	}
+	b.mut.RLock()
+	defer b.mut.RUnlock()
+	for _, fn := range b.subscribers {
+		fn(ev)
+	}
+}
+
+// Publisher is an optional interface implemented by observability providers,
+// such as the service-wide manager, that accept connection events from the
+// components they create.
+type Publisher interface {
+	PublishConnectionEvent(ev Event)
+}
+
+// Publish a connection event to an observability provider if it implements
+// Publisher, otherwise the event is dropped.
+func Publish(o any, ev Event) {
+	if p, ok := o.(Publisher); ok {
+		p.PublishConnectionEvent(ev)
+	}
+}
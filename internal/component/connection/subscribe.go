@@ -0,0 +1,160 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/benthosdev/benthos/v4/internal/log"
+)
+
+// Subscribe adds the subscribers described by a config to a bus, where events
+// are logged with the provided logger and optionally posted to a webhook. The
+// resources of the subscribers are released when the bus is closed.
+func Subscribe(conf Config, logger log.Modular, bus *Bus) error {
+	logFn, err := eventLogger(conf.LogLevel, logger)
+	if err != nil {
+		return err
+	}
+	if logFn != nil {
+		bus.Subscribe(logFn)
+	}
+
+	if conf.Webhook.URL != "" {
+		timeout, err := time.ParseDuration(conf.Webhook.Timeout)
+		if err != nil {
+			return fmt.Errorf("failed to parse webhook timeout: %w", err)
+		}
+		w := newWebhook(conf.Webhook.URL, timeout, logger)
+		bus.Subscribe(w.publish)
+		bus.OnClose(w.close)
+	}
+	return nil
+}
+
+func eventMessage(ev Event) string {
+	cType := ev.ComponentType
+	if cType != "" {
+		cType = strings.ToUpper(cType[:1]) + cType[1:]
+	}
+	switch ev.Type {
+	case EventConnected:
+		return fmt.Sprintf("%v %v connected", cType, ev.Name)
+	case EventDisconnected:
+		return fmt.Sprintf("%v %v lost connection", cType, ev.Name)
+	case EventFailed:
+		return fmt.Sprintf("%v %v failed to connect: %v", cType, ev.Name, ev.Error)
+	}
+	return fmt.Sprintf("%v %v connection event: %v", cType, ev.Name, ev.Type)
+}
+
+func eventLogger(level string, logger log.Modular) (func(Event), error) {
+	var logFn func(l log.Modular, msg string)
+	switch strings.ToUpper(level) {
+	case "NONE", "OFF":
+		return nil, nil
+	case "ERROR":
+		logFn = func(l log.Modular, msg string) { l.Errorln(msg) }
+	case "WARN":
+		logFn = func(l log.Modular, msg string) { l.Warnln(msg) }
+	case "INFO":
+		logFn = func(l log.Modular, msg string) { l.Infoln(msg) }
+	case "DEBUG":
+		logFn = func(l log.Modular, msg string) { l.Debugln(msg) }
+	case "TRACE":
+		logFn = func(l log.Modular, msg string) { l.Traceln(msg) }
+	default:
+		return nil, fmt.Errorf("connection events log level '%v' not recognized", level)
+	}
+	return func(ev Event) {
+		// Failed connection attempts are already logged as errors by the
+		// component itself.
+		if ev.Type == EventFailed {
+			return
+		}
+		fields := map[string]string{
+			"label": ev.Label,
+			"path":  ev.Path,
+		}
+		if ev.Stream != "" {
+			fields["stream"] = ev.Stream
+		}
+		logFn(logger.WithFields(fields), eventMessage(ev))
+	}, nil
+}
+
+//------------------------------------------------------------------------------
+
+// webhook posts events to an HTTP endpoint from a background goroutine so that
+// publishing components are never blocked by a slow endpoint. Events are
+// dropped when the queue of pending events is full. Once closed the goroutine
+// exits after posting any events still pending.
+type webhook struct {
+	url     string
+	client  *http.Client
+	log     log.Modular
+	pending chan Event
+}
+
+func newWebhook(url string, timeout time.Duration, logger log.Modular) *webhook {
+	w := &webhook{
+		url:     url,
+		client:  &http.Client{Timeout: timeout},
+		log:     logger,
+		pending: make(chan Event, 1024),
+	}
+	go w.loop()
+	return w
+}
+
+func (w *webhook) publish(ev Event) {
+	select {
+	case w.pending <- ev:
+	default:
+		w.log.Warnln("Dropping connection event as the webhook queue is full")
+	}
+}
+
+// close must only be called once the webhook no longer receives events, which
+// is guaranteed by the bus.
+func (w *webhook) close() {
+	close(w.pending)
+}
+
+func (w *webhook) loop() {
+	for ev := range w.pending {
+		if err := w.post(ev); err != nil {
+			w.log.Warnf("Failed to post connection event to webhook: %v\n", err)
+		}
+	}
+}
+
+func (w *webhook) post(ev Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code: %v", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package connection_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/component/connection"
+	"github.com/benthosdev/benthos/v4/internal/filepath/ifs"
+	"github.com/benthosdev/benthos/v4/internal/log"
+)
+
+func TestConnectionEventsLogging(t *testing.T) {
+	logConf := log.NewConfig()
+	logConf.AddTimeStamp = false
+	logConf.StaticFields = map[string]string{}
+	logConf.LogLevel = "INFO"
+
+	var buf bytes.Buffer
+	logger, err := log.New(&buf, ifs.OS(), logConf)
+	require.NoError(t, err)
+
+	conf := connection.NewConfig()
+	conf.LogLevel = "WARN"
+
+	bus := connection.NewBus()
+	require.NoError(t, connection.Subscribe(conf, logger, bus))
+
+	bus.Publish(connection.Event{
+		Type: connection.EventConnected, ComponentType: "input", Name: "kafka",
+		Label: "foo", Path: "root.input",
+	})
+	bus.Publish(connection.Event{
+		Type: connection.EventFailed, ComponentType: "output", Name: "http_client",
+		Label: "", Path: "root.output", Stream: "bar", Error: "nope",
+	})
+	bus.Publish(connection.Event{
+		Type: connection.EventDisconnected, ComponentType: "input", Name: "kafka",
+		Label: "foo", Path: "root.input",
+	})
+
+	assert.Equal(t, `level=warning msg="Input kafka connected" label=foo path=root.input
+level=warning msg="Input kafka lost connection" label=foo path=root.input
+`, buf.String())
+}
+
+func TestConnectionEventsLoggingDisabled(t *testing.T) {
+	logConf := log.NewConfig()
+	logConf.LogLevel = "ALL"
+
+	var buf bytes.Buffer
+	logger, err := log.New(&buf, ifs.OS(), logConf)
+	require.NoError(t, err)
+
+	conf := connection.NewConfig()
+	conf.LogLevel = "NONE"
+
+	bus := connection.NewBus()
+	require.NoError(t, connection.Subscribe(conf, logger, bus))
+
+	bus.Publish(connection.Event{Type: connection.EventConnected, ComponentType: "input", Name: "kafka"})
+	assert.Empty(t, buf.String())
+
+	conf.LogLevel = "nope"
+	require.EqualError(t, connection.Subscribe(conf, logger, bus), "connection events log level 'nope' not recognized")
+}
+
+func TestConnectionEventsWebhook(t *testing.T) {
+	events := make(chan connection.Event, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var ev connection.Event
+		require.NoError(t, json.Unmarshal(b, &ev))
+		events <- ev
+	}))
+	defer server.Close()
+
+	conf := connection.NewConfig()
+	conf.LogLevel = "NONE"
+	conf.Webhook.URL = server.URL
+
+	bus := connection.NewBus()
+	require.NoError(t, connection.Subscribe(conf, log.Noop(), bus))
+
+	ts := time.Unix(100, 0).UTC()
+	bus.Publish(connection.Event{
+		Type: connection.EventFailed, ComponentType: "output", Name: "http_client",
+		Label: "foo", Path: "root.output", Error: "nope", Timestamp: ts,
+	})
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, connection.Event{
+			Type: connection.EventFailed, ComponentType: "output", Name: "http_client",
+			Label: "foo", Path: "root.output", Error: "nope", Timestamp: ts,
+		}, ev)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for webhook event")
+	}
+
+	// Events pending when the bus is closed are still posted, those published
+	// afterwards are dropped.
+	bus.Publish(connection.Event{Type: connection.EventConnected, Name: "http_client"})
+	bus.Close()
+	bus.Publish(connection.Event{Type: connection.EventDisconnected, Name: "http_client"})
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, connection.EventConnected, ev.Type)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for webhook event")
+	}
+
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected event after close: %v", ev)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/connection"
 	"github.com/benthosdev/benthos/v4/internal/message"
 	"github.com/benthosdev/benthos/v4/internal/shutdown"
 	"github.com/benthosdev/benthos/v4/internal/tracing"
@@ -76,6 +77,18 @@ func AsyncReaderWithConnBackOff(boff backoff.BackOff) func(a *AsyncReader) {
 
 //------------------------------------------------------------------------------
 
+func (r *AsyncReader) publishConnEvent(t connection.EventType, err error) {
+	ev := connection.Event{
+		Type:          t,
+		ComponentType: "input",
+		Name:          r.typeStr,
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	connection.Publish(r.mgr, ev)
+}
+
 func (r *AsyncReader) loop() {
 	// Metrics paths
 	var (
@@ -121,6 +134,7 @@ func (r *AsyncReader) loop() {
 				}
 				r.mgr.Logger().Errorf("Failed to connect to %v: %v\n", r.typeStr, err)
 				mFailedConn.Incr(1)
+				r.publishConnEvent(connection.EventFailed, err)
 
 				nextBoff := r.connBackoff.NextBackOff()
 				if nextBoff == backoff.Stop {
@@ -143,6 +157,7 @@ func (r *AsyncReader) loop() {
 		return
 	}
 	mConn.Incr(1)
+	r.publishConnEvent(connection.EventConnected, nil)
 	atomic.StoreInt32(&r.connected, 1)
 
 	for {
@@ -151,6 +166,7 @@ func (r *AsyncReader) loop() {
 		// If our reader says it is not connected.
 		if errors.Is(err, component.ErrNotConnected) {
 			mLostConn.Incr(1)
+			r.publishConnEvent(connection.EventDisconnected, nil)
 			atomic.StoreInt32(&r.connected, 0)
 
 			// Continue to try to reconnect while still active.
@@ -158,6 +174,7 @@ func (r *AsyncReader) loop() {
 				return
 			}
 			mConn.Incr(1)
+			r.publishConnEvent(connection.EventConnected, nil)
 			atomic.StoreInt32(&r.connected, 1)
 			continue
 		}
@@ -14,7 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/connection"
 	"github.com/benthosdev/benthos/v4/internal/component/input"
+	"github.com/benthosdev/benthos/v4/internal/manager"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
@@ -309,6 +311,81 @@ func TestAsyncReaderCanReconnect(t *testing.T) {
 	require.NoError(t, r.WaitForClose(tCtx))
 }
 
+func TestAsyncReaderConnectionEvents(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	var eventsMut sync.Mutex
+	var events []connection.Event
+
+	bus := connection.NewBus()
+	bus.Subscribe(func(ev connection.Event) {
+		eventsMut.Lock()
+		events = append(events, ev)
+		eventsMut.Unlock()
+	})
+
+	mgr, err := manager.New(manager.NewResourceConfig(), manager.OptSetConnectionEvents(bus))
+	require.NoError(t, err)
+
+	readerImpl := newMockAsyncReader()
+
+	r, err := input.NewAsyncReader("foo", readerImpl, mgr.IntoPath("input"))
+	require.NoError(t, err)
+
+	go func() {
+		select {
+		case readerImpl.connChan <- errors.New("nope"):
+		case <-time.After(time.Second):
+		}
+		select {
+		case readerImpl.connChan <- nil:
+		case <-time.After(time.Second):
+		}
+		select {
+		case readerImpl.readChan <- component.ErrNotConnected:
+		case <-time.After(time.Second):
+		}
+		select {
+		case readerImpl.connChan <- nil:
+		case <-time.After(time.Second):
+		}
+	}()
+
+	assert.Eventually(t, func() bool {
+		eventsMut.Lock()
+		defer eventsMut.Unlock()
+		return len(events) == 4
+	}, time.Second*5, time.Millisecond*10)
+
+	r.TriggerStopConsuming()
+	go func() {
+		select {
+		case readerImpl.readChan <- nil:
+		case <-time.After(time.Second):
+		}
+	}()
+	require.NoError(t, r.WaitForClose(tCtx))
+
+	eventsMut.Lock()
+	defer eventsMut.Unlock()
+
+	var types []connection.EventType
+	for _, ev := range events {
+		assert.Equal(t, "input", ev.ComponentType)
+		assert.Equal(t, "foo", ev.Name)
+		assert.Equal(t, "root.input", ev.Path)
+		types = append(types, ev.Type)
+	}
+	assert.Equal(t, []connection.EventType{
+		connection.EventFailed,
+		connection.EventConnected,
+		connection.EventDisconnected,
+		connection.EventConnected,
+	}, types)
+	assert.Equal(t, "nope", events[0].Error)
+}
+
 func TestAsyncReaderFailsReconnect(t *testing.T) {
 	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
 	defer done()
@@ -170,6 +170,13 @@ func (c *combinedWrapper) HandlerFunc() http.HandlerFunc {
 	return c.t2.HandlerFunc()
 }
 
+func (c *combinedWrapper) JSONHandlerFunc() http.HandlerFunc {
+	if h := JSONHandlerFunc(c.t1); h != nil {
+		return h
+	}
+	return JSONHandlerFunc(c.t2)
+}
+
 func (c *combinedWrapper) Close() error {
 	c.t1.Close()
 	c.t2.Close()
@@ -2,9 +2,10 @@ package metrics
 
 // StatsdConfig is config for the Statsd metrics type.
 type StatsdConfig struct {
-	Address     string `json:"address" yaml:"address"`
-	FlushPeriod string `json:"flush_period" yaml:"flush_period"`
-	TagFormat   string `json:"tag_format" yaml:"tag_format"`
+	Address     string            `json:"address" yaml:"address"`
+	FlushPeriod string            `json:"flush_period" yaml:"flush_period"`
+	TagFormat   string            `json:"tag_format" yaml:"tag_format"`
+	Tags        map[string]string `json:"tags" yaml:"tags"`
 }
 
 // NewStatsdConfig creates an StatsdConfig struct with default values.
@@ -13,5 +14,6 @@ func NewStatsdConfig() StatsdConfig {
 		Address:     "",
 		FlushPeriod: "100ms",
 		TagFormat:   "none",
+		Tags:        map[string]string{},
 	}
 }
@@ -84,7 +84,7 @@ func (m *Mapping) mapPath(path string, labelNames, labelValues []string) (outPat
 		Value: &v,
 	}); err != nil {
 		m.logger.Errorf("Failed to apply path mapping on '%v': %v\n", path, err)
-		return path, nil, nil
+		return path, labelNames, labelValues
 	}
 
 	_ = outPart.MetaIterStr(func(k, v string) error {
@@ -58,7 +58,54 @@ func TestPathMapping(t *testing.T) {
 		{
 			name:    "throw an error",
 			mapping: `root = throw("nope")`,
-			cases:   []testCase{{input: "foo", output: "foo"}},
+			cases: []testCase{
+				{input: "foo", output: "foo"},
+				{
+					input:    "foo",
+					inLabels: []string{"label", "path"},
+					inValues: []string{"bar", "root.output"},
+					output:   "foo",
+					labels:   []string{"label", "path"},
+					values:   []string{"bar", "root.output"},
+				},
+			},
+		},
+		{
+			name: "allow list by regex and drop a label",
+			mapping: `meta path = deleted()
+root = if !this.re_match("^(input|output)_") { deleted() }`,
+			cases: []testCase{
+				{
+					input:    "output_sent",
+					inLabels: []string{"label", "path"},
+					inValues: []string{"foo", "root.output.broker.outputs.3"},
+					output:   "output_sent",
+					labels:   []string{"label"},
+					values:   []string{"foo"},
+				},
+				{
+					input:    "processor_sent",
+					inLabels: []string{"label", "path"},
+					inValues: []string{"bar", "root.pipeline.processors.0"},
+					output:   "",
+				},
+			},
+		},
+		{
+			name: "relabel by regex",
+			mapping: `root = this.re_replace_all("^output_", "sink_")
+meta path = meta("path").re_replace_all("\\.outputs\\.[0-9]+", ".outputs").catch(deleted())`,
+			cases: []testCase{
+				{
+					input:    "output_sent",
+					inLabels: []string{"label", "path"},
+					inValues: []string{"", "root.output.broker.outputs.12"},
+					output:   "sink_sent",
+					labels:   []string{"label", "path"},
+					values:   []string{"", "root.output.broker.outputs"},
+				},
+				{input: "output_sent", output: "sink_sent"},
+			},
 		},
 		{
 			name: "set a static label",
@@ -74,6 +74,12 @@ func (n *Namespaced) HandlerFunc() http.HandlerFunc {
 	return n.child.HandlerFunc()
 }
 
+// JSONHandlerFunc returns the JSON snapshot http handler of the child, or nil
+// if the child does not support it.
+func (n *Namespaced) JSONHandlerFunc() http.HandlerFunc {
+	return JSONHandlerFunc(n.child)
+}
+
 //------------------------------------------------------------------------------
 
 func (n *Namespaced) getPathAndLabels(path string) (newPath string, labelKeys, labelValues []string) {
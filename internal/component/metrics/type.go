@@ -88,3 +88,21 @@ type Type interface {
 	// Close stops aggregating stats and cleans up resources.
 	Close() error
 }
+
+// JSONSnapshotter is an optional interface implemented by metrics types that
+// are able to serve a snapshot of the current values of all metrics as a JSON
+// object.
+type JSONSnapshotter interface {
+	// JSONHandlerFunc returns an HTTP request handler that serves a JSON
+	// snapshot of metrics, or nil if the implementation is unable to.
+	JSONHandlerFunc() http.HandlerFunc
+}
+
+// JSONHandlerFunc returns an HTTP request handler that serves a JSON snapshot
+// of the metrics of a Type, or nil if the Type does not support it.
+func JSONHandlerFunc(t Type) http.HandlerFunc {
+	if js, ok := t.(JSONSnapshotter); ok {
+		return js.JSONHandlerFunc()
+	}
+	return nil
+}
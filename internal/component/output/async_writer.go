@@ -13,6 +13,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/batch"
 	"github.com/benthosdev/benthos/v4/internal/bloblang/mapping"
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/connection"
 	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -52,6 +53,7 @@ type AsyncWriter struct {
 	log    log.Modular
 	stats  metrics.Type
 	tracer trace.TracerProvider
+	obs    component.Observability
 
 	transactions <-chan message.Transaction
 
@@ -67,6 +69,7 @@ func NewAsyncWriter(typeStr string, maxInflight int, w AsyncSink, mgr component.
 		log:          mgr.Logger(),
 		stats:        mgr.Metrics(),
 		tracer:       mgr.Tracer(),
+		obs:          mgr,
 		transactions: nil,
 		shutSig:      shutdown.NewSignaller(),
 	}
@@ -114,6 +117,18 @@ func (w *AsyncWriter) injectSpans(msg message.Batch, spans []*tracing.Span) {
 	}
 }
 
+func (w *AsyncWriter) publishConnEvent(t connection.EventType, err error) {
+	ev := connection.Event{
+		Type:          t,
+		ComponentType: "output",
+		Name:          w.typeStr,
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	connection.Publish(w.obs, ev)
+}
+
 // loop is an internal loop that brokers incoming messages to output pipe.
 func (w *AsyncWriter) loop() {
 	// Metrics paths
@@ -152,6 +167,7 @@ func (w *AsyncWriter) loop() {
 				}
 				w.log.Errorf("Failed to connect to %v: %v\n", w.typeStr, err)
 				mFailedConn.Incr(1)
+				w.publishConnEvent(connection.EventFailed, err)
 				select {
 				case <-time.After(connBackoff.NextBackOff()):
 				case <-closeLeisureCtx.Done():
@@ -167,6 +183,7 @@ func (w *AsyncWriter) loop() {
 		return
 	}
 	mConn.Incr(1)
+	w.publishConnEvent(connection.EventConnected, nil)
 	atomic.StoreInt32(&w.isConnected, 1)
 
 	wg := sync.WaitGroup{}
@@ -189,6 +206,7 @@ func (w *AsyncWriter) loop() {
 			}
 		}
 		mLostConn.Incr(1)
+		w.publishConnEvent(connection.EventDisconnected, nil)
 
 		// Continue to try to reconnect while still active.
 		for {
@@ -199,6 +217,7 @@ func (w *AsyncWriter) loop() {
 			if latency, err = w.latencyMeasuringWrite(closeLeisureCtx, msg); err != component.ErrNotConnected {
 				atomic.StoreInt32(&w.isConnected, 1)
 				mConn.Incr(1)
+				w.publishConnEvent(connection.EventConnected, nil)
 				return
 			} else if err != nil {
 				mError.Incr(1)
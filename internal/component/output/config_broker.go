@@ -7,6 +7,7 @@ type BrokerConfig struct {
 	Copies   int                `json:"copies" yaml:"copies"`
 	Pattern  string             `json:"pattern" yaml:"pattern"`
 	Outputs  []Config           `json:"outputs" yaml:"outputs"`
+	Weights  []int              `json:"weights" yaml:"weights"`
 	Batching batchconfig.Config `json:"batching" yaml:"batching"`
 }
 
@@ -16,6 +17,7 @@ func NewBrokerConfig() BrokerConfig {
 		Copies:   1,
 		Pattern:  "fan_out",
 		Outputs:  []Config{},
+		Weights:  []int{},
 		Batching: batchconfig.NewConfig(),
 	}
 }
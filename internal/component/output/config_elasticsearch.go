@@ -36,6 +36,8 @@ type ElasticsearchConfig struct {
 	Timeout         string                  `json:"timeout" yaml:"timeout"`
 	TLS             btls.Config             `json:"tls" yaml:"tls"`
 	Auth            ElasticsearchAuthConfig `json:"basic_auth" yaml:"basic_auth"`
+	APIKey          string                  `json:"api_key" yaml:"api_key"`
+	CloudID         string                  `json:"cloud_id" yaml:"cloud_id"`
 	AWS             OptionalAWSConfig       `json:"aws" yaml:"aws"`
 	GzipCompression bool                    `json:"gzip_compression" yaml:"gzip_compression"`
 	MaxInFlight     int                     `json:"max_in_flight" yaml:"max_in_flight"`
@@ -62,6 +64,8 @@ func NewElasticsearchConfig() ElasticsearchConfig {
 		Routing:     "",
 		Timeout:     "5s",
 		TLS:         btls.NewConfig(),
+		APIKey:      "",
+		CloudID:     "",
 		AWS: OptionalAWSConfig{
 			Enabled: false,
 			Config:  sess.NewConfig(),
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
 
@@ -257,3 +258,34 @@ func TestBatchProcessorAirGapIndividualErrors(t *testing.T) {
 	assert.NoError(t, msgs[0][1].ErrorGet())
 	assert.EqualError(t, msgs[0][2].ErrorGet(), "invalid character 'a' looking for beginning of value")
 }
+
+type localObservability struct {
+	component.Observability
+	stats *metrics.Local
+}
+
+func (l localObservability) Metrics() metrics.Type {
+	return l.stats
+}
+
+func TestProcessorAirGapLatency(t *testing.T) {
+	tCtx := context.Background()
+
+	stats := metrics.NewLocal()
+	obs := localObservability{Observability: component.NoopObservability(), stats: stats}
+
+	agrp := NewAutoObservedProcessor("foo", &fnProcessor{
+		fn: func(c context.Context, m *message.Part) ([]*message.Part, error) {
+			time.Sleep(time.Millisecond * 10)
+			return []*message.Part{m}, nil
+		},
+	}, obs)
+
+	_, err := agrp.ProcessBatch(tCtx, message.QuickBatch([][]byte{[]byte("foo")}))
+	require.NoError(t, err)
+
+	timing, exists := stats.GetTimings()["processor_latency_ns"]
+	require.True(t, exists)
+	assert.Equal(t, int64(1), timing.Count())
+	assert.GreaterOrEqual(t, timing.Max(), (time.Millisecond * 10).Nanoseconds())
+}
@@ -5,6 +5,7 @@ type DedupeConfig struct {
 	Cache          string `json:"cache" yaml:"cache"`
 	Key            string `json:"key" yaml:"key"`
 	DropOnCacheErr bool   `json:"drop_on_err" yaml:"drop_on_err"`
+	TTL            string `json:"ttl" yaml:"ttl"`
 }
 
 // NewDedupeConfig returns a DedupeConfig with default values.
@@ -13,5 +14,6 @@ func NewDedupeConfig() DedupeConfig {
 		Cache:          "",
 		Key:            "",
 		DropOnCacheErr: true,
+		TTL:            "",
 	}
 }
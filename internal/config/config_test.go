@@ -94,6 +94,22 @@ input:
 	assert.Equal(t, "drop", conf.Output.Type)
 }
 
+func TestOverridesFromEnviron(t *testing.T) {
+	assert.Equal(t, []string{
+		"input.generate.count=5",
+		"output.type=drop",
+		"input.generate.mapping=root = \"a=b\"",
+	}, config.OverridesFromEnviron("BENTHOS_SET_", []string{
+		"HOME=/root",
+		"BENTHOS_SET_B=output.type=drop",
+		"BENTHOS_SET_A=input.generate.count=5",
+		"BENTHOS_SETX=input.generate.interval=1s",
+		"BENTHOS_SET_C=input.generate.mapping=root = \"a=b\"",
+	}))
+
+	assert.Empty(t, config.OverridesFromEnviron("BENTHOS_SET_", []string{"HOME=/root"}))
+}
+
 func TestResources(t *testing.T) {
 	dir := t.TempDir()
 
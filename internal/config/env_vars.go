@@ -25,7 +25,6 @@ type ErrMissingEnvVars struct {
 
 // Error returns a rather sweet error message.
 func (e *ErrMissingEnvVars) Error() string {
-	// TODO: Deduplicate the variables as they might be repeated.
 	return fmt.Sprintf("required environment variables were not set: %v", e.Variables)
 }
 
@@ -40,6 +39,7 @@ func (e *ErrMissingEnvVars) Error() string {
 // value is used or the field will be left empty.
 func ReplaceEnvVariables(inBytes []byte, lookupFn func(string) (string, bool)) (replaced []byte, err error) {
 	var missingVarsErr ErrMissingEnvVars
+	missingVarsSeen := map[string]struct{}{}
 
 	replaced = envRegex.ReplaceAllFunc(inBytes, func(content []byte) []byte {
 		var value string
@@ -48,7 +48,11 @@ func ReplaceEnvVariables(inBytes []byte, lookupFn func(string) (string, bool)) (
 			if colonIndex := bytes.IndexByte(content, ':'); colonIndex == -1 {
 				varName := string(content[2 : len(content)-1])
 				if value, ok = lookupFn(varName); !ok {
-					missingVarsErr.Variables = append(missingVarsErr.Variables, varName)
+					// The same variable might be referenced multiple times.
+					if _, seen := missingVarsSeen[varName]; !seen {
+						missingVarsSeen[varName] = struct{}{}
+						missingVarsErr.Variables = append(missingVarsErr.Variables, varName)
+					}
 				}
 			} else {
 				targetVar := content[2:colonIndex]
@@ -49,6 +49,7 @@ func TestEnvSwapping(t *testing.T) {
 		"foo ${BENTHOS.TEST.BAR} baz":                                              {result: "foo test\\nbar baz"},
 		"foo ${BENTHOS_TEST_THIS_DOESNT_EXIST_LOL} baz":                            {errContains: "required environment variables were not set: [BENTHOS_TEST_THIS_DOESNT_EXIST_LOL]"},
 		"foo ${BENTHOS_TEST_NOPE_A} baz ${BENTHOS_TEST_NOPE_B} buz":                {errContains: "required environment variables were not set: [BENTHOS_TEST_NOPE_A BENTHOS_TEST_NOPE_B]"},
+		"foo ${BENTHOS_TEST_NOPE_A} baz ${BENTHOS_TEST_NOPE_A} buz":                {errContains: "required environment variables were not set: [BENTHOS_TEST_NOPE_A]"},
 		"foo ${DOES_NOT_EXIST::} baz":                                              {result: "foo : baz"},
 	}
 
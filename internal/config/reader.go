@@ -8,7 +8,9 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Jeffail/gabs/v2"
@@ -45,6 +47,9 @@ type Reader struct {
 	// The filesystem used for reading config files.
 	fs ifs.FS
 
+	// Used for resolving variable references within config files.
+	lookupEnvFn func(name string) (string, bool, error)
+
 	bootstrapConf *Type
 
 	// Used for linting configs
@@ -76,6 +81,10 @@ type Reader struct {
 	streamUpdateFn StreamUpdateFunc
 	watcher        fileWatcher
 
+	// Prevents update triggers from the file watcher and manual reloads from
+	// running concurrently.
+	triggerMut sync.Mutex
+
 	changeFlushPeriod  time.Duration
 	changeDelayPeriod  time.Duration
 	filesRefreshPeriod time.Duration
@@ -90,6 +99,7 @@ func NewReader(mainPath string, resourcePaths []string, opts ...OptFunc) *Reader
 	r := &Reader{
 		testSuffix:         "_benthos_test",
 		fs:                 ifs.OS(),
+		lookupEnvFn:        osLookupEnv,
 		bootstrapConf:      &defaultBootstrapConf,
 		lintConf:           docs.NewLintConfig(),
 		mainPath:           mainPath,
@@ -130,6 +140,35 @@ func OptAddOverrides(overrides ...string) OptFunc {
 	}
 }
 
+// OverridesFromEnviron extracts override expressions from a list of
+// environment variables of the form `KEY=value`, as returned by os.Environ,
+// where the key begins with the provided prefix and the value is an override
+// expression of the form `path=value`. Overrides are returned sorted by their
+// variable keys.
+func OverridesFromEnviron(prefix string, environ []string) []string {
+	type keyedOverride struct {
+		key, override string
+	}
+
+	var keyed []keyedOverride
+	for _, kv := range environ {
+		key, override, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		keyed = append(keyed, keyedOverride{key: key, override: override})
+	}
+	sort.Slice(keyed, func(i, j int) bool {
+		return keyed[i].key < keyed[j].key
+	})
+
+	overrides := make([]string, len(keyed))
+	for i, k := range keyed {
+		overrides[i] = k.override
+	}
+	return overrides
+}
+
 // OptSetBootstrapConfig sets a config to be used as the default for each parse.
 // This can be used to change the default behaviours of benthos configs.
 func OptSetBootstrapConfig(conf *Type) OptFunc {
@@ -163,6 +202,54 @@ func OptUseFS(fs ifs.FS) OptFunc {
 	}
 }
 
+// OptUseEnvLookupFunc sets the function used for resolving variable references
+// of the form `${FOO}` within config files. By default environment variables
+// are used. An error returned by the function fails the read of the config
+// file.
+func OptUseEnvLookupFunc(fn func(name string) (string, bool, error)) OptFunc {
+	return func(r *Reader) {
+		r.lookupEnvFn = fn
+	}
+}
+
+func osLookupEnv(name string) (string, bool, error) {
+	v, ok := os.LookupEnv(name)
+	return v, ok, nil
+}
+
+// readFileEnvSwap reads a config file and replaces any variable references
+// with the configured lookup function. Each variable is looked up at most once
+// per read, and a failed lookup fails the read rather than the variable being
+// treated as missing.
+func (r *Reader) readFileEnvSwap(path string) (configBytes []byte, lints []docs.Lint, modTime time.Time, err error) {
+	type lookupResult struct {
+		value string
+		ok    bool
+	}
+	results := map[string]lookupResult{}
+
+	var lookupErr error
+	lookupFn := func(name string) (string, bool) {
+		if res, exists := results[name]; exists {
+			return res.value, res.ok
+		}
+		v, ok, err := r.lookupEnvFn(name)
+		if err != nil {
+			if lookupErr == nil {
+				lookupErr = err
+			}
+			return "", false
+		}
+		results[name] = lookupResult{value: v, ok: ok}
+		return v, ok
+	}
+
+	if configBytes, lints, modTime, err = ReadFileEnvSwap(r.fs, path, lookupFn); err == nil && lookupErr != nil {
+		err = lookupErr
+	}
+	return
+}
+
 //------------------------------------------------------------------------------
 
 func (r *Reader) lintCtx() docs.LintContext {
@@ -287,7 +374,7 @@ func (r *Reader) readMain(mainPath string, conf *Type) (lints []string, err erro
 	if mainPath != "" {
 		var dLints []docs.Lint
 		var modTime time.Time
-		if confBytes, dLints, modTime, err = ReadFileEnvSwap(r.fs, mainPath, os.LookupEnv); err != nil {
+		if confBytes, dLints, modTime, err = r.readFileEnvSwap(mainPath); err != nil {
 			return
 		}
 		for _, l := range dLints {
@@ -320,6 +407,19 @@ func (r *Reader) readMain(mainPath string, conf *Type) (lints []string, err erro
 	return
 }
 
+// ReloadMain attempts to re-read the main configuration file and trigger the
+// function registered with SubscribeConfigChanges, regardless of whether the
+// file has been modified. This is safe to call whilst file watching is active.
+func (r *Reader) ReloadMain(mgr bundle.NewManagement, strict bool) error {
+	r.triggerMut.Lock()
+	defer r.triggerMut.Unlock()
+
+	if r.mainPath == "" {
+		return errors.New("a main config file was not specified")
+	}
+	return r.TriggerMainUpdate(mgr, strict, r.mainPath)
+}
+
 // TriggerMainUpdate attempts to re-read the main configuration file, trigger
 // the provided main update func, and apply changes to resources to the provided
 // manager as appropriate.
@@ -239,3 +239,107 @@ processor_resources:
 	assert.True(t, testMgr.ProbeProcessor("c"))
 	assert.True(t, testMgr.ProbeProcessor("d"))
 }
+
+func TestReloadMain(t *testing.T) {
+	testFS := &testFS{m: fstest.MapFS{
+		"foo_main.yaml": &fstest.MapFile{
+			Data: []byte(`
+input:
+  label: fooin
+  inproc: foo
+
+output:
+  label: fooout
+  inproc: bar
+`),
+		},
+	}}
+	rdr := newDummyReader("foo_main.yaml", nil, OptUseFS(testFS))
+
+	conf, lints, err := rdr.Read()
+	require.NoError(t, err)
+	require.Empty(t, lints)
+	assert.Equal(t, "fooin", conf.Input.Label)
+
+	var updatedConf *Type
+	require.NoError(t, rdr.SubscribeConfigChanges(func(conf *Type) error {
+		updatedConf = conf
+		return nil
+	}))
+
+	testFS.m["foo_main.yaml"] = &fstest.MapFile{
+		Data: []byte(`
+input:
+  label: barin
+  inproc: foo
+
+output:
+  label: barout
+  inproc: bar
+`),
+	}
+
+	mgr, err := manager.New(manager.NewResourceConfig())
+	require.NoError(t, err)
+
+	require.NoError(t, rdr.ReloadMain(mgr, true))
+	require.NotNil(t, updatedConf)
+	assert.Equal(t, "barin", updatedConf.Input.Label)
+	assert.Equal(t, "barout", updatedConf.Output.Label)
+
+	updatedConf = nil
+	testFS.m["foo_main.yaml"] = &fstest.MapFile{
+		Data: []byte(`
+input:
+  label: bazin
+  nope: foo
+`),
+	}
+
+	require.Error(t, rdr.ReloadMain(mgr, true))
+	assert.Nil(t, updatedConf)
+
+	require.Error(t, newDummyReader("", nil, OptUseFS(testFS)).ReloadMain(mgr, true))
+}
+
+func TestReaderEnvLookupFunc(t *testing.T) {
+	testFS := &testFS{m: fstest.MapFS{
+		"foo_main.yaml": &fstest.MapFile{
+			Data: []byte(`
+input:
+  label: ${FOO}
+  inproc: ${FOO}
+
+output:
+  label: ${BAR:barout}
+  inproc: bar
+`),
+		},
+	}}
+
+	lookups := map[string]int{}
+	var lookupErr error
+	rdr := newDummyReader("foo_main.yaml", nil, OptUseFS(testFS), OptUseEnvLookupFunc(func(name string) (string, bool, error) {
+		lookups[name]++
+		if name == "FOO" {
+			return "fooin", true, lookupErr
+		}
+		return "", false, nil
+	}))
+
+	conf, lints, err := rdr.Read()
+	require.NoError(t, err)
+	require.Empty(t, lints)
+	assert.Equal(t, "fooin", conf.Input.Label)
+	assert.Equal(t, "barout", conf.Output.Label)
+	assert.Equal(t, map[string]int{"FOO": 1, "BAR": 1}, lookups)
+
+	// Results are only reused within a single read.
+	_, _, err = rdr.Read()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"FOO": 2, "BAR": 2}, lookups)
+
+	lookupErr = errors.New("nope")
+	_, _, err = rdr.Read()
+	require.EqualError(t, err, "foo_main.yaml: nope")
+}
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
-	"os"
 	"path/filepath"
 	"time"
 
@@ -208,7 +207,7 @@ func (r *Reader) readResource(path string, conf *manager.ResourceConfig) (lints
 	var confBytes []byte
 	var dLints []docs.Lint
 	var modTime time.Time
-	if confBytes, dLints, modTime, err = ReadFileEnvSwap(r.fs, path, os.LookupEnv); err != nil {
+	if confBytes, dLints, modTime, err = r.readFileEnvSwap(path); err != nil {
 		return
 	}
 	for _, l := range dLints {
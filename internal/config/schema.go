@@ -5,6 +5,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/api"
 	tdocs "github.com/benthosdev/benthos/v4/internal/cli/test/docs"
+	"github.com/benthosdev/benthos/v4/internal/component/connection"
 	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/tracer"
 	"github.com/benthosdev/benthos/v4/internal/docs"
@@ -18,12 +19,13 @@ type Type struct {
 	HTTP                   api.Config `json:"http" yaml:"http"`
 	stream.Config          `json:",inline" yaml:",inline"`
 	manager.ResourceConfig `json:",inline" yaml:",inline"`
-	Logger                 log.Config     `json:"logger" yaml:"logger"`
-	Metrics                metrics.Config `json:"metrics" yaml:"metrics"`
-	Tracer                 tracer.Config  `json:"tracer" yaml:"tracer"`
-	SystemCloseDelay       string         `json:"shutdown_delay" yaml:"shutdown_delay"`
-	SystemCloseTimeout     string         `json:"shutdown_timeout" yaml:"shutdown_timeout"`
-	Tests                  []any          `json:"tests,omitempty" yaml:"tests,omitempty"`
+	Logger                 log.Config        `json:"logger" yaml:"logger"`
+	Metrics                metrics.Config    `json:"metrics" yaml:"metrics"`
+	Tracer                 tracer.Config     `json:"tracer" yaml:"tracer"`
+	ConnectionEvents       connection.Config `json:"connection_events" yaml:"connection_events"`
+	SystemCloseDelay       string            `json:"shutdown_delay" yaml:"shutdown_delay"`
+	SystemCloseTimeout     string            `json:"shutdown_timeout" yaml:"shutdown_timeout"`
+	Tests                  []any             `json:"tests,omitempty" yaml:"tests,omitempty"`
 }
 
 // New returns a new configuration with default values.
@@ -35,6 +37,7 @@ func New() Type {
 		Logger:             log.NewConfig(),
 		Metrics:            metrics.NewConfig(),
 		Tracer:             tracer.NewConfig(),
+		ConnectionEvents:   connection.NewConfig(),
 		SystemCloseDelay:   "",
 		SystemCloseTimeout: "20s",
 		Tests:              nil,
@@ -56,6 +59,7 @@ var observabilityFields = docs.FieldSpecs{
 	docs.FieldObject("logger", "Describes how operational logs should be emitted.").WithChildren(log.Spec()...),
 	docs.FieldMetrics("metrics", "A mechanism for exporting metrics.").Optional(),
 	docs.FieldTracer("tracer", "A mechanism for exporting traces.").Optional(),
+	docs.FieldObject("connection_events", "Configures how changes in the connectivity of inputs and outputs are surfaced.").WithChildren(connection.Spec()...).Advanced(),
 	docs.FieldString("shutdown_delay", "A period of time to wait for metrics and traces to be pulled or pushed from the process.").HasDefault("0s"),
 	docs.FieldString("shutdown_timeout", "The maximum period of time to wait for a clean shutdown. If this time is exceeded Benthos will forcefully close.").HasDefault("20s"),
 }
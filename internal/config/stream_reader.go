@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -49,7 +48,7 @@ func (r *Reader) readStreamFileConfig(path string) (conf stream.Config, lints []
 	var confBytes []byte
 	var dLints []docs.Lint
 	var modTime time.Time
-	if confBytes, dLints, modTime, err = ReadFileEnvSwap(r.fs, path, os.LookupEnv); err != nil {
+	if confBytes, dLints, modTime, err = r.readFileEnvSwap(path); err != nil {
 		return
 	}
 	for _, l := range dLints {
@@ -127,17 +126,20 @@ func (r *Reader) streamPathsExpanded() ([]string, error) {
 				return nil
 			}
 
+			// Only the file name is checked for the test suffix, as the
+			// inferred ID of a nested file could otherwise collide with it.
+			if len(r.testSuffix) > 0 {
+				name := strings.TrimSuffix(strings.TrimSuffix(info.Name(), ".yaml"), ".yml")
+				if strings.HasSuffix(name, r.testSuffix) {
+					return nil
+				}
+			}
+
 			id, err := inferStreamID(target, path)
 			if err != nil {
 				return err
 			}
 
-			// TODO: This is quite lazy and might run into issues e.g. the path
-			// `foo/bar.yaml` would collide with a test suffix of `_bar`.
-			if len(r.testSuffix) > 0 && strings.HasSuffix(id, r.testSuffix) {
-				return nil
-			}
-
 			path = filepath.Clean(path)
 			if _, exists := r.streamFileInfo[path]; !exists {
 				r.streamFileInfo[path] = streamFileInfo{id: id}
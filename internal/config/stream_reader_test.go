@@ -104,3 +104,38 @@ pipeline:
 	assert.Equal(t, `root = "second"`, streamConfs["inner_second"].Pipeline.Processors[0].Bloblang)
 	assert.Equal(t, `root = "third"`, streamConfs["inner_third"].Pipeline.Processors[0].Bloblang)
 }
+
+func TestStreamsDirectoryWalkTestSuffix(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "foo_benthos"), 0o755))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo_benthos", "test.yaml"), []byte(`
+pipeline:
+  processors:
+    - bloblang: 'root = "nested"'
+`), 0o644))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bar.yaml"), []byte(`
+pipeline:
+  processors:
+    - bloblang: 'root = "bar"'
+`), 0o644))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bar_benthos_test.yaml"), []byte(`
+tests: []
+`), 0o644))
+
+	rdr := config.NewReader("", nil, config.OptSetStreamPaths(dir))
+
+	streamConfs := map[string]stream.Config{}
+	lints, err := rdr.ReadStreams(streamConfs)
+	require.NoError(t, err)
+	require.Len(t, lints, 0)
+
+	require.Len(t, streamConfs, 2)
+	require.Contains(t, streamConfs, "bar")
+	require.Contains(t, streamConfs, "foo_benthos_test")
+
+	assert.Equal(t, `root = "nested"`, streamConfs["foo_benthos_test"].Pipeline.Processors[0].Bloblang)
+}
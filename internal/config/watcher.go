@@ -23,6 +23,13 @@ func noReread(err error) error {
 	return &ErrNoReread{wrapped: err}
 }
 
+// NewErrNoReread wraps an error returned from an update subscription in order
+// to indicate that the update should not be attempted again unless the source
+// file is modified.
+func NewErrNoReread(err error) error {
+	return noReread(err)
+}
+
 // ShouldReread returns true if the error returned from an update trigger is non
 // nil and also temporal, and therefore it is worth trying the update again even
 // if the content has not changed.
@@ -167,6 +174,7 @@ func (r *Reader) BeginFileWatching(mgr bundle.NewManagement, strict bool) error
 					collapsedChanges[cleanPath] = fileChange{at: time.Now()}
 				}
 			case <-changeTicker.C:
+				r.triggerMut.Lock()
 				for nameClean, change := range collapsedChanges {
 					if time.Since(change.at) < r.changeDelayPeriod {
 						continue
@@ -186,6 +194,7 @@ func (r *Reader) BeginFileWatching(mgr bundle.NewManagement, strict bool) error
 						collapsedChanges[nameClean] = change
 					}
 				}
+				r.triggerMut.Unlock()
 			case <-filesTicker.C:
 				if err := refreshFiles(); err != nil {
 					mgr.Logger().Errorf("Failed to refresh watched paths: %v", err)
@@ -3,8 +3,11 @@ package docs
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/benthosdev/benthos/v4/public/bloblang"
 )
 
@@ -633,6 +636,58 @@ func ShouldDropDeprecated(b bool) FieldFilter {
 	}
 }
 
+// ShouldDropDefaults returns a field filter that removes all fields with a
+// value matching their default, as well as objects where all fields match their
+// defaults. Only fields with a value of type *yaml.Node are checked.
+func ShouldDropDefaults() FieldFilter {
+	return func(spec FieldSpec, v any) bool {
+		node, ok := v.(*yaml.Node)
+		if !ok {
+			return true
+		}
+		return !isDefaultYAML(spec, node)
+	}
+}
+
+func isDefaultYAML(spec FieldSpec, node *yaml.Node) bool {
+	if spec.Default == nil {
+		if spec.Kind != KindScalar || spec.Type != FieldTypeObject || len(spec.Children) == 0 {
+			return false
+		}
+		if node = unwrapDocumentNode(node); node.Kind != yaml.MappingNode {
+			return false
+		}
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			var childSpec *FieldSpec
+			for j, c := range spec.Children {
+				if c.Name == node.Content[i].Value {
+					childSpec = &spec.Children[j]
+					break
+				}
+			}
+			if childSpec == nil || !isDefaultYAML(*childSpec, node.Content[i+1]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Round trip the default through YAML so that it has the same
+	// representation as the value.
+	var defaultNode yaml.Node
+	if err := defaultNode.Encode(*spec.Default); err != nil {
+		return false
+	}
+	var defaultValue, value any
+	if err := defaultNode.Decode(&defaultValue); err != nil {
+		return false
+	}
+	if err := node.Decode(&value); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(defaultValue, value)
+}
+
 //------------------------------------------------------------------------------
 
 // LintConfig describes which rules apply when linting benthos configs, and also
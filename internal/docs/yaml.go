@@ -182,9 +182,15 @@ func SanitiseYAML(cType Type, node *yaml.Node, conf SanitiseConfig) error {
 	var keys []string
 	for i := 0; i < len(node.Content)-1; i += 2 {
 		if node.Content[i].Value == "label" {
-			if _, omit := labelField.shouldOmitYAML(nil, node.Content[i+1], node); !omit {
-				newNodes = append(newNodes, node.Content[i], node.Content[i+1])
+			if _, omit := labelField.shouldOmitYAML(nil, node.Content[i+1], node); omit {
+				break
+			}
+			// An empty label is equivalent to no label, which is exposed to
+			// filters as a default.
+			if conf.Filter.shouldDrop(labelField.HasDefault(""), node.Content[i+1]) {
+				break
 			}
+			newNodes = append(newNodes, node.Content[i], node.Content[i+1])
 			break
 		}
 	}
@@ -260,6 +266,9 @@ func SanitiseYAML(cType Type, node *yaml.Node, conf SanitiseConfig) error {
 			if _, omit := spec.shouldOmitYAML(nil, node.Content[i+1], node); omit {
 				continue
 			}
+			if conf.Filter.shouldDrop(spec, node.Content[i+1]) {
+				continue
+			}
 			if err := spec.SanitiseYAML(node.Content[i+1], conf); err != nil {
 				return err
 			}
@@ -1342,3 +1342,52 @@ processors:
 		})
 	}
 }
+
+func TestSanitiseYAMLDropDefaults(t *testing.T) {
+	spec := docs.FieldSpecs{
+		docs.FieldString("a", "").HasDefault("adefault"),
+		docs.FieldInt("b", "").HasDefault(10),
+		docs.FieldString("c", "").Array().HasDefault([]string{}),
+		docs.FieldObject("d", "").WithChildren(
+			docs.FieldBool("e", "").HasDefault(true),
+			docs.FieldString("f", "").HasDefault("fdefault"),
+		),
+		docs.FieldString("g", ""),
+	}
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`
+a: adefault
+b: 11
+c: []
+d:
+  e: true
+  f: notdefault
+g: gvalue
+`), &node))
+
+	sanitConf := docs.NewSanitiseConfig()
+	sanitConf.Filter = docs.ShouldDropDefaults()
+	require.NoError(t, spec.SanitiseYAML(&node, sanitConf))
+
+	resBytes, err := yaml.Marshal(node.Content[0])
+	require.NoError(t, err)
+	assert.Equal(t, `b: 11
+d:
+    f: notdefault
+g: gvalue
+`, string(resBytes))
+
+	require.NoError(t, yaml.Unmarshal([]byte(`
+a: adefault
+d:
+  e: true
+g: gvalue
+`), &node))
+	require.NoError(t, spec.SanitiseYAML(&node, sanitConf))
+
+	resBytes, err = yaml.Marshal(node.Content[0])
+	require.NoError(t, err)
+	assert.Equal(t, `g: gvalue
+`, string(resBytes))
+}
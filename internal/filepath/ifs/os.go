@@ -37,17 +37,34 @@ func ReadFile(f fs.FS, name string) ([]byte, error) {
 // WriteFile opens a file with O_WRONLY|O_CREATE|O_TRUNC flags and writes the
 // data to it.
 func WriteFile(f fs.FS, name string, data []byte, perm fs.FileMode) error {
-	h, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	var h fs.File
+	var err error
+	if ef, ok := f.(FS); ok {
+		h, err = ef.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	} else {
+		h, err = os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	}
 	if err != nil {
 		return err
 	}
-	_, err = h.Write(data)
+	_, err = FileWrite(h, data)
 	if err1 := h.Close(); err1 != nil && err == nil {
 		err = err1
 	}
 	return err
 }
 
+// Rename renames (moves) a file within the provided filesystem, provided that
+// the filesystem supports renaming via a Rename method.
+func Rename(f FS, oldpath, newpath string) error {
+	if rf, ok := f.(interface {
+		Rename(oldpath, newpath string) error
+	}); ok {
+		return rf.Rename(oldpath, newpath)
+	}
+	return errors.New("filesystem does not support renaming files")
+}
+
 // FileWrite attempts to write to an fs.File provided it supports io.Writer.
 func FileWrite(file fs.File, data []byte) (int, error) {
 	writer, isw := file.(io.Writer)
@@ -93,3 +110,7 @@ func (o *osPT) Remove(name string) error {
 func (o *osPT) MkdirAll(path string, perm fs.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
+
+func (o *osPT) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
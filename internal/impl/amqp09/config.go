@@ -21,19 +21,21 @@ const (
 	prefetchSizeField            = "prefetch_size"
 
 	// Output
-	exchangeField               = "exchange"
-	exchangeDeclareField        = "exchange_declare"
-	exchangeDeclareEnabledField = "enabled"
-	exchangeDeclareTypeField    = "type"
-	exchangeDeclareDurableField = "durable"
-	keyField                    = "key"
-	typeField                   = "type"
-	contentTypeField            = "content_type"
-	contentEncodingField        = "content_encoding"
-	metadataFilterField         = "metadata"
-	priorityField               = "priority"
-	persistentField             = "persistent"
-	mandatoryField              = "mandatory"
-	immediateField              = "immediate"
-	timeoutField                = "timeout"
+	exchangeField                  = "exchange"
+	exchangeDeclareField           = "exchange_declare"
+	exchangeDeclareEnabledField    = "enabled"
+	exchangeDeclareTypeField       = "type"
+	exchangeDeclareDurableField    = "durable"
+	exchangeDeclareAutoDeleteField = "auto_delete"
+	exchangeDeclareArgumentsField  = "arguments"
+	keyField                       = "key"
+	typeField                      = "type"
+	contentTypeField               = "content_type"
+	contentEncodingField           = "content_encoding"
+	metadataFilterField            = "metadata"
+	priorityField                  = "priority"
+	persistentField                = "persistent"
+	mandatoryField                 = "mandatory"
+	immediateField                 = "immediate"
+	timeoutField                   = "timeout"
 )
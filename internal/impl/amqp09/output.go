@@ -48,6 +48,16 @@ The fields 'key' and 'type' can be dynamically set using function interpolations
 				service.NewBoolField(exchangeDeclareDurableField).
 					Description("Whether the exchange should be durable.").
 					Default(true),
+				service.NewBoolField(exchangeDeclareAutoDeleteField).
+					Description("Whether the exchange should be deleted once all queues have been unbound from it.").
+					Default(false).
+					Version("4.18.0"),
+				service.NewStringMapField(exchangeDeclareArgumentsField).
+					Description("Optional arguments to set when declaring the exchange, such as an `alternate-exchange` that receives messages which cannot be routed, or the `x-delayed-type` required by the delayed message exchange plugin.").
+					Example(map[string]any{"alternate-exchange": "unroutable"}).
+					Example(map[string]any{"x-delayed-type": "direct"}).
+					Default(map[string]any{}).
+					Version("4.18.0"),
 			).
 				Description(`Optionally declare the target exchange (passive).`).
 				Advanced().
@@ -130,6 +140,8 @@ type amqp09Writer struct {
 	exchangeDeclare        bool
 	exchangeDeclareType    string
 	exchangeDeclareDurable bool
+	exchangeDeclareAutoDel bool
+	exchangeDeclareArgs    amqp.Table
 
 	log *service.Logger
 
@@ -194,6 +206,19 @@ func amqp09WriterFromParsed(conf *service.ParsedConfig, mgr *service.Resources)
 		if a.exchangeDeclareDurable, err = edConf.FieldBool(exchangeDeclareDurableField); err != nil {
 			return nil, err
 		}
+		if a.exchangeDeclareAutoDel, err = edConf.FieldBool(exchangeDeclareAutoDeleteField); err != nil {
+			return nil, err
+		}
+		var args map[string]string
+		if args, err = edConf.FieldStringMap(exchangeDeclareArgumentsField); err != nil {
+			return nil, err
+		}
+		if len(args) > 0 {
+			a.exchangeDeclareArgs = amqp.Table{}
+			for k, v := range args {
+				a.exchangeDeclareArgs[k] = v
+			}
+		}
 	}
 
 	if a.key, err = conf.FieldInterpolatedString(keyField); err != nil {
@@ -218,6 +243,22 @@ func amqp09WriterFromParsed(conf *service.ParsedConfig, mgr *service.Resources)
 	return &a, nil
 }
 
+type exchangeDeclarer interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+}
+
+func (a *amqp09Writer) declareExchange(ch exchangeDeclarer) error {
+	return ch.ExchangeDeclare(
+		a.exchange,               // name of the exchange
+		a.exchangeDeclareType,    // type
+		a.exchangeDeclareDurable, // durable
+		a.exchangeDeclareAutoDel, // delete when complete
+		false,                    // internal
+		false,                    // noWait
+		a.exchangeDeclareArgs,    // arguments
+	)
+}
+
 func (a *amqp09Writer) Connect(ctx context.Context) error {
 	a.connLock.Lock()
 	defer a.connLock.Unlock()
@@ -234,15 +275,7 @@ func (a *amqp09Writer) Connect(ctx context.Context) error {
 	}
 
 	if a.exchangeDeclare {
-		if err = amqpChan.ExchangeDeclare(
-			a.exchange,               // name of the exchange
-			a.exchangeDeclareType,    // type
-			a.exchangeDeclareDurable, // durable
-			false,                    // delete when complete
-			false,                    // internal
-			false,                    // noWait
-			nil,                      // arguments
-		); err != nil {
+		if err = a.declareExchange(amqpChan); err != nil {
 			conn.Close()
 			return fmt.Errorf("amqp failed to declare exchange: %v", err)
 		}
@@ -374,10 +407,11 @@ func (a *amqp09Writer) Write(ctx context.Context, msg *service.Message) error {
 	}
 	if returnChan != nil {
 		select {
-		case _, open := <-returnChan:
+		case ret, open := <-returnChan:
 			if !open {
 				return fmt.Errorf("acknowledgement not supported, ensure server supports immediate and mandatory flags")
 			}
+			a.log.Debugf("Message returned by server with code %v: %v\n", ret.ReplyCode, ret.ReplyText)
 			return component.ErrNoAck
 		default:
 		}
@@ -0,0 +1,51 @@
+package amqp09
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+type mockExchangeDeclarer struct {
+	name, kind          string
+	durable, autoDelete bool
+	args                amqp.Table
+}
+
+func (m *mockExchangeDeclarer) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	m.name, m.kind = name, kind
+	m.durable, m.autoDelete = durable, autoDelete
+	m.args = args
+	return nil
+}
+
+func TestAMQP09OutputExchangeDeclare(t *testing.T) {
+	conf, err := amqp09OutputSpec().ParseYAML(`
+urls: [ amqp://localhost:5672 ]
+exchange: foo
+exchange_declare:
+  enabled: true
+  type: topic
+  durable: false
+  auto_delete: true
+  arguments:
+    alternate-exchange: bar
+`, nil)
+	require.NoError(t, err)
+
+	w, err := amqp09WriterFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+
+	var m mockExchangeDeclarer
+	require.NoError(t, w.declareExchange(&m))
+
+	assert.Equal(t, "foo", m.name)
+	assert.Equal(t, "topic", m.kind)
+	assert.False(t, m.durable)
+	assert.True(t, m.autoDelete)
+	assert.Equal(t, amqp.Table{"alternate-exchange": "bar"}, m.args)
+}
@@ -29,8 +29,8 @@ func dynCacheConfig() *service.ConfigSpec {
 		Version("3.36.0").
 		Summary(`Stores key/value pairs as a single document in a DynamoDB table. The key is stored as a string value and used as the table hash key. The value is stored as
 a binary value using the ` + "`data_key`" + ` field name.`).
-		Description(`A prefix can be specified to allow multiple cache types to share a single DynamoDB table. An optional TTL duration (` + "`ttl`" + `) and field
-(` + "`ttl_key`" + `) can be specified if the backing table has TTL enabled.
+		Description(`An optional TTL duration (` + "`default_ttl`" + `) and field (` + "`ttl_key`" + `) can be specified if the backing table has TTL enabled. Since DynamoDB
+deletes expired items in the background some time after they expire, items with an expired TTL are treated as missing by Get and Add commands.
 
 Strong read consistency can be enabled using the ` + "`consistent_read`" + ` configuration field.`).
 		Field(service.NewStringField("table").
@@ -221,12 +221,29 @@ func (d *dynamodbCache) get(key string) ([]byte, error) {
 	}
 
 	val, ok := res.Item[d.dataKey]
-	if !ok || val.B == nil {
+	if !ok || val.B == nil || d.isExpired(res.Item) {
 		return nil, service.ErrKeyNotFound
 	}
 	return val.B, nil
 }
 
+// isExpired returns true if an item has a TTL that has passed but has not yet
+// been deleted by DynamoDB.
+func (d *dynamodbCache) isExpired(item map[string]*dynamodb.AttributeValue) bool {
+	if d.ttlKey == nil {
+		return false
+	}
+	ttlVal, ok := item[*d.ttlKey]
+	if !ok || ttlVal.N == nil {
+		return false
+	}
+	expiry, err := strconv.ParseInt(*ttlVal.N, 10, 64)
+	if err != nil {
+		return false
+	}
+	return expiry <= time.Now().Unix()
+}
+
 func (d *dynamodbCache) Set(ctx context.Context, key string, value []byte, ttl *time.Duration) error {
 	boff := d.boffPool.Get().(backoff.BackOff)
 	defer func() {
@@ -326,13 +343,19 @@ func (d *dynamodbCache) Add(ctx context.Context, key string, value []byte, ttl *
 func (d *dynamodbCache) add(key string, value []byte, ttl *time.Duration) error {
 	input := d.putItemInput(key, value, ttl)
 
-	expr, err := expression.NewBuilder().
-		WithCondition(expression.AttributeNotExists(expression.Name(d.hashKey))).
-		Build()
+	// Items that have expired but have not yet been deleted by DynamoDB are
+	// overwritten.
+	cond := expression.AttributeNotExists(expression.Name(d.hashKey))
+	if d.ttlKey != nil {
+		cond = cond.Or(expression.Name(*d.ttlKey).LessThanEqual(expression.Value(time.Now().Unix())))
+	}
+
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
 	if err != nil {
 		return err
 	}
 	input.ExpressionAttributeNames = expr.Names()
+	input.ExpressionAttributeValues = expr.Values()
 	input.ConditionExpression = expr.Condition()
 
 	if _, err = d.client.PutItem(input); err != nil {
@@ -1,13 +1,20 @@
 package aws
 
 import (
+	"context"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
 )
 
 func TestDynamoDBCacheConfig(t *testing.T) {
@@ -86,3 +93,49 @@ ttl_key: buz
 		})
 	}
 }
+
+type mockDynamoDBCacheClient struct {
+	dynamodbiface.DynamoDBAPI
+
+	item     map[string]*dynamodb.AttributeValue
+	putInput *dynamodb.PutItemInput
+}
+
+func (m *mockDynamoDBCacheClient) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{Item: m.item}, nil
+}
+
+func (m *mockDynamoDBCacheClient) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	m.putInput = input
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func TestDynamoDBCacheExpiredItems(t *testing.T) {
+	tCtx := context.Background()
+
+	client := &mockDynamoDBCacheClient{}
+	c := newDynamodbCache(client, "foo", "id", "data", false, aws.String("ttl"), nil, backoff.NewExponentialBackOff())
+
+	expiryAttr := func(d time.Duration) *dynamodb.AttributeValue {
+		return &dynamodb.AttributeValue{N: aws.String(strconv.FormatInt(time.Now().Add(d).Unix(), 10))}
+	}
+
+	client.item = map[string]*dynamodb.AttributeValue{
+		"id":   {S: aws.String("a")},
+		"data": {B: []byte("hello")},
+		"ttl":  expiryAttr(time.Hour),
+	}
+	v, err := c.Get(tCtx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(v))
+
+	client.item["ttl"] = expiryAttr(-time.Hour)
+	_, err = c.Get(tCtx, "a")
+	assert.Equal(t, service.ErrKeyNotFound, err)
+
+	require.NoError(t, c.Add(tCtx, "a", []byte("world"), nil))
+	require.NotNil(t, client.putInput.ConditionExpression)
+	assert.Contains(t, *client.putInput.ConditionExpression, "attribute_not_exists")
+	assert.Contains(t, *client.putInput.ConditionExpression, "<=")
+	assert.Len(t, client.putInput.ExpressionAttributeValues, 1)
+}
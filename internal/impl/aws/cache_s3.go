@@ -30,6 +30,11 @@ func s3CacheConfig() *service.ConfigSpec {
 		Description(`It is not possible to atomically upload S3 objects exclusively when the target does not already exist, therefore this cache is not suitable for deduplication.`).
 		Field(service.NewStringField("bucket").
 			Description("The S3 bucket to store items in.")).
+		Field(service.NewStringField("prefix").
+			Description("An optional prefix to add to the path of each item, allowing multiple caches to share a single bucket.").
+			Example("benthos/cache/").
+			Default("").
+			Version("4.18.0")).
 		Field(service.NewStringField("content_type").
 			Description("The content type to set for each item.").
 			Default("application/octet-stream")).
@@ -66,6 +71,10 @@ func newS3CacheFromConfig(conf *service.ParsedConfig) (*s3Cache, error) {
 	if err != nil {
 		return nil, err
 	}
+	prefix, err := conf.FieldString("prefix")
+	if err != nil {
+		return nil, err
+	}
 	contentType, err := conf.FieldString("content_type")
 	if err != nil {
 		return nil, err
@@ -88,7 +97,7 @@ func newS3CacheFromConfig(conf *service.ParsedConfig) (*s3Cache, error) {
 		return nil, err
 	}
 
-	return newS3Cache(bucket, contentType, backOff, client), nil
+	return newS3Cache(bucket, prefix, contentType, backOff, client), nil
 }
 
 //------------------------------------------------------------------------------
@@ -97,16 +106,18 @@ type s3Cache struct {
 	s3 s3iface.S3API
 
 	bucket      string
+	prefix      string
 	contentType string
 
 	boffPool sync.Pool
 }
 
-func newS3Cache(bucket, contentType string, backOff *backoff.ExponentialBackOff, s3 s3iface.S3API) *s3Cache {
+func newS3Cache(bucket, prefix, contentType string, backOff *backoff.ExponentialBackOff, s3 s3iface.S3API) *s3Cache {
 	return &s3Cache{
 		s3: s3,
 
 		bucket:      bucket,
+		prefix:      prefix,
 		contentType: contentType,
 
 		boffPool: sync.Pool{
@@ -128,6 +139,8 @@ func (s *s3Cache) Get(ctx context.Context, key string) (body []byte, err error)
 		s.boffPool.Put(boff)
 	}()
 
+	key = s.prefix + key
+
 	var obj *s3.GetObjectOutput
 	for {
 		if obj, err = s.s3.GetObjectWithContext(ctx, &s3.GetObjectInput{
@@ -164,6 +177,7 @@ func (s *s3Cache) Set(ctx context.Context, key string, value []byte, _ *time.Dur
 		s.boffPool.Put(boff)
 	}()
 
+	key = s.prefix + key
 	for {
 		if _, err = s.s3.PutObjectWithContext(ctx, &s3.PutObjectInput{
 			Bucket:      &s.bucket,
@@ -189,7 +203,7 @@ func (s *s3Cache) Set(ctx context.Context, key string, value []byte, _ *time.Dur
 func (s *s3Cache) Add(ctx context.Context, key string, value []byte, _ *time.Duration) error {
 	if _, err := s.s3.HeadObject(&s3.HeadObjectInput{
 		Bucket: &s.bucket,
-		Key:    &key,
+		Key:    aws.String(s.prefix + key),
 	}); err == nil {
 		return service.ErrKeyAlreadyExists
 	}
@@ -203,6 +217,7 @@ func (s *s3Cache) Delete(ctx context.Context, key string) (err error) {
 		s.boffPool.Put(boff)
 	}()
 
+	key = s.prefix + key
 	for {
 		if _, err = s.s3.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
 			Bucket: &s.bucket,
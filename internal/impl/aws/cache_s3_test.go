@@ -0,0 +1,76 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+type mockS3CacheClient struct {
+	s3iface.S3API
+
+	objects map[string][]byte
+}
+
+func (m *mockS3CacheClient) GetObjectWithContext(_ aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	v, exists := m.objects[*input.Key]
+	if !exists {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "nope", nil)
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(v))}, nil
+}
+
+func (m *mockS3CacheClient) PutObjectWithContext(_ aws.Context, input *s3.PutObjectInput, _ ...request.Option) (*s3.PutObjectOutput, error) {
+	v, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	m.objects[*input.Key] = v
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (m *mockS3CacheClient) HeadObject(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	if _, exists := m.objects[*input.Key]; !exists {
+		return nil, awserr.New("NotFound", "nope", nil)
+	}
+	return &s3.HeadObjectOutput{}, nil
+}
+
+func (m *mockS3CacheClient) DeleteObjectWithContext(_ aws.Context, input *s3.DeleteObjectInput, _ ...request.Option) (*s3.DeleteObjectOutput, error) {
+	delete(m.objects, *input.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func TestS3CachePrefix(t *testing.T) {
+	tCtx := context.Background()
+
+	client := &mockS3CacheClient{objects: map[string][]byte{}}
+	c := newS3Cache("foo", "bar/", "text/plain", backoff.NewExponentialBackOff(), client)
+
+	require.NoError(t, c.Set(tCtx, "a", []byte("hello"), nil))
+	assert.Equal(t, map[string][]byte{"bar/a": []byte("hello")}, client.objects)
+
+	v, err := c.Get(tCtx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(v))
+
+	assert.Equal(t, service.ErrKeyAlreadyExists, c.Add(tCtx, "a", []byte("world"), nil))
+	require.NoError(t, c.Add(tCtx, "b", []byte("world"), nil))
+	assert.Equal(t, []byte("world"), client.objects["bar/b"])
+
+	require.NoError(t, c.Delete(tCtx, "a"))
+	_, err = c.Get(tCtx, "a")
+	assert.Equal(t, service.ErrKeyNotFound, err)
+}
@@ -0,0 +1,137 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+
+	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/output"
+	"github.com/benthosdev/benthos/v4/internal/impl/aws/config"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	// Lambda Output Fields
+	lambdaoFieldFunction = "function"
+	lambdaoFieldTimeout  = "timeout"
+)
+
+type lambdaoConfig struct {
+	Function string
+	Timeout  time.Duration
+
+	session *session.Session
+}
+
+func lambdaoConfigFromParsed(pConf *service.ParsedConfig) (conf lambdaoConfig, err error) {
+	if conf.Function, err = pConf.FieldString(lambdaoFieldFunction); err != nil {
+		return
+	}
+	if conf.Timeout, err = pConf.FieldDuration(lambdaoFieldTimeout); err != nil {
+		return
+	}
+	if conf.session, err = GetSession(pConf); err != nil {
+		return
+	}
+	return
+}
+
+func lambdaoOutputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.18.0").
+		Categories("Services", "AWS").
+		Summary(`Asynchronously invokes an AWS lambda function for each message, where the contents of the message is the payload of the invocation.`).
+		Description(output.Description(true, false, `
+Functions are invoked with the `+"`Event`"+` invocation type, meaning this output does not wait for the function to complete and the result of the invocation is discarded. A message is only considered delivered once the invocation has been successfully queued by AWS. In order to capture the result of an invocation use the `+"[`aws_lambda` processor](/docs/components/processors/aws_lambda)"+` instead.
+
+### Credentials
+
+By default Benthos will use a shared credentials file when connecting to AWS services. It's also possible to set them explicitly at the component level, allowing you to transfer data across accounts. You can find out more [in this document](/docs/guides/cloud/aws).`)).
+		Fields(
+			service.NewStringField(lambdaoFieldFunction).
+				Description("The function to invoke."),
+			service.NewOutputMaxInFlightField(),
+			service.NewDurationField(lambdaoFieldTimeout).
+				Description("The maximum period to wait on an invocation before abandoning it and reattempting.").
+				Advanced().
+				Default("5s"),
+		).
+		Fields(config.SessionFields()...)
+}
+
+func init() {
+	err := service.RegisterOutput("aws_lambda", lambdaoOutputSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.Output, maxInFlight int, err error) {
+			if maxInFlight, err = conf.FieldMaxInFlight(); err != nil {
+				return
+			}
+			var wConf lambdaoConfig
+			if wConf, err = lambdaoConfigFromParsed(conf); err != nil {
+				return
+			}
+			out, err = newLambdaWriter(wConf, mgr)
+			return
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+type lambdaWriter struct {
+	conf   lambdaoConfig
+	lambda lambdaiface.LambdaAPI
+	log    *service.Logger
+}
+
+func newLambdaWriter(conf lambdaoConfig, mgr *service.Resources) (*lambdaWriter, error) {
+	if conf.Function == "" {
+		return nil, errors.New("lambda function must not be empty")
+	}
+	l := &lambdaWriter{
+		conf: conf,
+		log:  mgr.Logger(),
+	}
+	return l, nil
+}
+
+func (l *lambdaWriter) Connect(ctx context.Context) error {
+	if l.lambda != nil {
+		return nil
+	}
+	l.lambda = lambda.New(l.conf.session)
+
+	l.log.Infof("Invoking AWS lambda function: %v\n", l.conf.Function)
+	return nil
+}
+
+func (l *lambdaWriter) Write(wctx context.Context, msg *service.Message) error {
+	if l.lambda == nil {
+		return component.ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(wctx, l.conf.Timeout)
+	defer cancel()
+
+	mBytes, err := msg.AsBytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = l.lambda.InvokeWithContext(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String(l.conf.Function),
+		InvocationType: aws.String(lambda.InvocationTypeEvent),
+		Payload:        mBytes,
+	})
+	return err
+}
+
+func (l *lambdaWriter) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestLambdaOutputWrite(t *testing.T) {
+	var payloads []string
+	mock := &mockLambda{
+		fn: func(ii *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			assert.Equal(t, "foofn", *ii.FunctionName)
+			assert.Equal(t, lambda.InvocationTypeEvent, *ii.InvocationType)
+			if string(ii.Payload) == "bad" {
+				return nil, errors.New("nope")
+			}
+			payloads = append(payloads, string(ii.Payload))
+			return &lambda.InvokeOutput{}, nil
+		},
+	}
+
+	w, err := newLambdaWriter(lambdaoConfig{
+		Function: "foofn",
+		Timeout:  time.Second,
+	}, service.MockResources())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.Error(t, w.Write(ctx, service.NewMessage([]byte("foo"))))
+
+	w.lambda = mock
+	require.NoError(t, w.Connect(ctx))
+
+	require.NoError(t, w.Write(ctx, service.NewMessage([]byte("foo"))))
+	require.NoError(t, w.Write(ctx, service.NewMessage([]byte("bar"))))
+	require.EqualError(t, w.Write(ctx, service.NewMessage([]byte("bad"))), "nope")
+
+	assert.Equal(t, []string{"foo", "bar"}, payloads)
+	require.NoError(t, w.Close(ctx))
+}
+
+func TestLambdaOutputNoFunction(t *testing.T) {
+	_, err := newLambdaWriter(lambdaoConfig{}, service.MockResources())
+	require.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 
 	"github.com/benthosdev/benthos/v4/internal/bloblang/query"
@@ -34,6 +35,7 @@ const (
 	s3oFieldWebsiteRedirectLocation = "website_redirect_location"
 	s3oFieldMetadata                = "metadata"
 	s3oFieldStorageClass            = "storage_class"
+	s3oFieldACL                     = "acl"
 	s3oFieldTimeout                 = "timeout"
 	s3oFieldKMSKeyID                = "kms_key_id"
 	s3oFieldServerSideEncryption    = "server_side_encryption"
@@ -58,6 +60,7 @@ type s3oConfig struct {
 	WebsiteRedirectLocation *service.InterpolatedString
 	Metadata                *service.MetadataExcludeFilter
 	StorageClass            *service.InterpolatedString
+	ACL                     string
 	Timeout                 time.Duration
 	KMSKeyID                string
 	ServerSideEncryption    string
@@ -116,6 +119,22 @@ func s3oConfigFromParsed(pConf *service.ParsedConfig) (conf s3oConfig, err error
 	if conf.StorageClass, err = pConf.FieldInterpolatedString(s3oFieldStorageClass); err != nil {
 		return
 	}
+	if conf.ACL, err = pConf.FieldString(s3oFieldACL); err != nil {
+		return
+	}
+	if conf.ACL != "" {
+		var validACL bool
+		for _, v := range s3.ObjectCannedACL_Values() {
+			if v == conf.ACL {
+				validACL = true
+				break
+			}
+		}
+		if !validACL {
+			err = fmt.Errorf("unrecognised canned acl: %v", conf.ACL)
+			return
+		}
+	}
 	if conf.Timeout, err = pConf.FieldDuration(s3oFieldTimeout); err != nil {
 		return
 	}
@@ -246,6 +265,12 @@ output:
 				Description("The storage class to set for each object.").
 				Default("STANDARD").
 				Advanced(),
+			service.NewStringField(s3oFieldACL).
+				Description("An optional [canned ACL](https://docs.aws.amazon.com/AmazonS3/latest/userguide/acl-overview.html#canned-acl) to apply to each object.").
+				Examples("private", "bucket-owner-full-control").
+				Version("4.18.0").
+				Default("").
+				Advanced(),
 			service.NewStringField(s3oFieldKMSKeyID).
 				Description("An optional server side encryption key.").
 				Default("").
@@ -413,6 +438,10 @@ func (a *amazonS3Writer) WriteBatch(wctx context.Context, msg service.MessageBat
 			uploadInput.Tagging = aws.String(strings.Join(tags, "&"))
 		}
 
+		if a.conf.ACL != "" {
+			uploadInput.ACL = &a.conf.ACL
+		}
+
 		if a.conf.KMSKeyID != "" {
 			uploadInput.ServerSideEncryption = aws.String("aws:kms")
 			uploadInput.SSEKMSKeyId = &a.conf.KMSKeyID
@@ -0,0 +1,31 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3OutputACLConfig(t *testing.T) {
+	pConf, err := s3oOutputSpec().ParseYAML(`
+bucket: foo
+acl: bucket-owner-full-control
+region: us-east-1
+`, nil)
+	require.NoError(t, err)
+
+	conf, err := s3oConfigFromParsed(pConf)
+	require.NoError(t, err)
+	assert.Equal(t, "bucket-owner-full-control", conf.ACL)
+
+	pConf, err = s3oOutputSpec().ParseYAML(`
+bucket: foo
+acl: nope
+region: us-east-1
+`, nil)
+	require.NoError(t, err)
+
+	_, err = s3oConfigFromParsed(pConf)
+	require.EqualError(t, err, "unrecognised canned acl: nope")
+}
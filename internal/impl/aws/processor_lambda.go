@@ -75,6 +75,11 @@ pipeline:
 		Field(service.NewBoolField("parallel").
 			Description("Whether messages of a batch should be dispatched in parallel.").
 			Default(false)).
+		Field(service.NewIntField("max_parallel").
+			Description("When `parallel` is enabled this field caps the number of invocations of a batch that can be dispatched at the same time. Set to `0` in order to invoke all messages of a batch in parallel.").
+			Default(0).
+			Version("4.18.0").
+			Advanced()).
 		Field(service.NewStringField("function").
 			Description("The function to invoke.")).
 		Field(service.NewStringField("rate_limit").
@@ -108,6 +113,11 @@ pipeline:
 				return nil, err
 			}
 
+			maxParallel, err := conf.FieldInt("max_parallel")
+			if err != nil {
+				return nil, err
+			}
+
 			function, err := conf.FieldString("function")
 			if err != nil {
 				return nil, err
@@ -128,7 +138,7 @@ pipeline:
 				return nil, err
 			}
 
-			return newLambdaProc(lambda.New(sess), parallel, function, numRetries, rateLimit, timeout, mgr)
+			return newLambdaProc(lambda.New(sess), parallel, maxParallel, function, numRetries, rateLimit, timeout, mgr)
 		})
 	if err != nil {
 		panic(err)
@@ -138,8 +148,9 @@ pipeline:
 //------------------------------------------------------------------------------
 
 type lambdaProc struct {
-	client   *lambdaClient
-	parallel bool
+	client      *lambdaClient
+	parallel    bool
+	maxParallel int
 
 	functionName string
 	log          *service.Logger
@@ -148,6 +159,7 @@ type lambdaProc struct {
 func newLambdaProc(
 	lambda lambdaiface.LambdaAPI,
 	parallel bool,
+	maxParallel int,
 	function string,
 	numRetries int,
 	rateLimit string,
@@ -158,6 +170,10 @@ func newLambdaProc(
 		functionName: function,
 		log:          mgr.Logger(),
 		parallel:     parallel,
+		maxParallel:  maxParallel,
+	}
+	if maxParallel < 0 {
+		return nil, errors.New("max_parallel must not be negative")
 	}
 	var err error
 	if l.client, err = newLambdaClient(lambda, function, numRetries, rateLimit, timeout, mgr); err != nil {
@@ -180,13 +196,24 @@ func (l *lambdaProc) ProcessBatch(ctx context.Context, batch service.MessageBatc
 		wg := sync.WaitGroup{}
 		wg.Add(len(batch))
 
+		var sem chan struct{}
+		if l.maxParallel > 0 {
+			sem = make(chan struct{}, l.maxParallel)
+		}
+
 		for i := 0; i < len(batch); i++ {
+			if sem != nil {
+				sem <- struct{}{}
+			}
 			go func(index int) {
 				err := l.client.InvokeV2(batch[index])
 				if err != nil {
 					l.log.Errorf("Lambda parallel request to '%v' failed: %v\n", l.functionName, err)
 					batch[index].SetError(err)
 				}
+				if sem != nil {
+					<-sem
+				}
 				wg.Done()
 			}(i)
 		}
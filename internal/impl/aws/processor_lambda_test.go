@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -32,7 +33,7 @@ func TestLambdaErrors(t *testing.T) {
 		},
 	}
 
-	p, err := newLambdaProc(mock, false, "foofn", 3, "", time.Second, service.MockResources())
+	p, err := newLambdaProc(mock, false, 0, "foofn", 3, "", time.Second, service.MockResources())
 	require.NoError(t, err)
 
 	bCtx := context.Background()
@@ -52,7 +53,7 @@ func TestLambdaErrors(t *testing.T) {
 	assert.EqualError(t, outBatches[0][1].GetError(), "meow bar")
 	assert.EqualError(t, outBatches[0][2].GetError(), "meow baz")
 
-	p, err = newLambdaProc(mock, true, "foofn", 3, "", time.Second, service.MockResources())
+	p, err = newLambdaProc(mock, true, 0, "foofn", 3, "", time.Second, service.MockResources())
 	require.NoError(t, err)
 
 	outBatches, err = p.ProcessBatch(bCtx, inBatch)
@@ -76,7 +77,7 @@ func TestLambdaMutations(t *testing.T) {
 		},
 	}
 
-	p, err := newLambdaProc(mock, false, "foofn", 3, "", time.Second, service.MockResources())
+	p, err := newLambdaProc(mock, false, 0, "foofn", 3, "", time.Second, service.MockResources())
 	require.NoError(t, err)
 
 	bCtx := context.Background()
@@ -107,7 +108,7 @@ func TestLambdaMutations(t *testing.T) {
 	b, _ = inBatch[2].AsBytes()
 	assert.Equal(t, "baz", string(b))
 
-	p, err = newLambdaProc(mock, true, "foofn", 3, "", time.Second, service.MockResources())
+	p, err = newLambdaProc(mock, true, 0, "foofn", 3, "", time.Second, service.MockResources())
 	require.NoError(t, err)
 
 	outBatches, err = p.ProcessBatch(bCtx, inBatch.Copy())
@@ -131,3 +132,47 @@ func TestLambdaMutations(t *testing.T) {
 	b, _ = inBatch[2].AsBytes()
 	assert.Equal(t, "baz", string(b))
 }
+
+func TestLambdaMaxParallel(t *testing.T) {
+	var inFlight, maxInFlight int32
+	mock := &mockLambda{
+		fn: func(ii *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			<-time.After(time.Millisecond * 10)
+			atomic.AddInt32(&inFlight, -1)
+			return &lambda.InvokeOutput{
+				Payload: []byte("meow " + string(ii.Payload)),
+			}, nil
+		},
+	}
+
+	p, err := newLambdaProc(mock, true, 2, "foofn", 3, "", time.Second, service.MockResources())
+	require.NoError(t, err)
+
+	var inBatch service.MessageBatch
+	for i := 0; i < 10; i++ {
+		inBatch = append(inBatch, service.NewMessage([]byte("foo")))
+	}
+
+	outBatches, err := p.ProcessBatch(context.Background(), inBatch)
+	require.NoError(t, err)
+
+	require.Len(t, outBatches, 1)
+	require.Len(t, outBatches[0], 10)
+	for _, m := range outBatches[0] {
+		b, err := m.AsBytes()
+		require.NoError(t, err)
+		assert.Equal(t, "meow foo", string(b))
+	}
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+
+	_, err = newLambdaProc(mock, true, -1, "foofn", 3, "", time.Second, service.MockResources())
+	require.Error(t, err)
+}
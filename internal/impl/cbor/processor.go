@@ -0,0 +1,165 @@
+package cbor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func processorConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Parsing").
+		Summary("Converts messages to or from the [CBOR](https://cbor.io/) format.").
+		Description("CBOR maps are converted into JSON objects, and therefore only maps with string keys are supported by the `to_json` operator. The `from_json` operator sorts map keys canonically, and therefore the same document always results in the same CBOR encoding.").
+		Field(service.NewStringAnnotatedEnumField("operator", map[string]string{
+			"to_json":   "Convert CBOR messages to JSON format",
+			"from_json": "Convert JSON messages to CBOR format",
+		}).Description("The operation to perform on messages.")).
+		Version("4.18.0")
+}
+
+func init() {
+	err := service.RegisterProcessor(
+		"cbor", processorConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Processor, error) {
+			return newProcessorFromConfig(conf)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+var cborDecMode = func() cbor.DecMode {
+	dm, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]any{}),
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return dm
+}()
+
+var cborEncMode = func() cbor.EncMode {
+	em, err := cbor.EncOptions{
+		Sort: cbor.SortCanonical,
+	}.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return em
+}()
+
+// jsonNumbersToNative walks a structured value and replaces json.Number
+// values with their native numeric types, as otherwise they would be encoded
+// as text strings.
+func jsonNumbersToNative(v any) (any, error) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			var err error
+			if t[k], err = jsonNumbersToNative(e); err != nil {
+				return nil, err
+			}
+		}
+	case []any:
+		for i, e := range t {
+			var err error
+			if t[i], err = jsonNumbersToNative(e); err != nil {
+				return nil, err
+			}
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s as a number", t)
+		}
+		return f, nil
+	}
+	return v, nil
+}
+
+type cborOperator func(m *service.Message) (*service.Message, error)
+
+func strToCBOROperator(opStr string) (cborOperator, error) {
+	switch opStr {
+	case "to_json":
+		return func(m *service.Message) (*service.Message, error) {
+			mBytes, err := m.AsBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			var jObj any
+			if err := cborDecMode.Unmarshal(mBytes, &jObj); err != nil {
+				return nil, fmt.Errorf("failed to convert CBOR document to JSON: %v", err)
+			}
+
+			m.SetStructuredMut(jObj)
+			return m, nil
+		}, nil
+	case "from_json":
+		return func(m *service.Message) (*service.Message, error) {
+			jObj, err := m.AsStructuredMut()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse message as JSON: %v", err)
+			}
+			if jObj, err = jsonNumbersToNative(jObj); err != nil {
+				return nil, fmt.Errorf("failed to convert JSON to CBOR: %v", err)
+			}
+
+			b, err := cborEncMode.Marshal(jObj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert JSON to CBOR: %v", err)
+			}
+
+			m.SetBytes(b)
+			return m, nil
+		}, nil
+	}
+	return nil, fmt.Errorf("operator not recognised: %v", opStr)
+}
+
+//------------------------------------------------------------------------------
+
+type processor struct {
+	operator cborOperator
+}
+
+func newProcessorFromConfig(conf *service.ParsedConfig) (*processor, error) {
+	operatorStr, err := conf.FieldString("operator")
+	if err != nil {
+		return nil, err
+	}
+	return newProcessor(operatorStr)
+}
+
+func newProcessor(operatorStr string) (*processor, error) {
+	operator, err := strToCBOROperator(operatorStr)
+	if err != nil {
+		return nil, err
+	}
+	return &processor{
+		operator: operator,
+	}, nil
+}
+
+func (p *processor) Process(ctx context.Context, msg *service.Message) (service.MessageBatch, error) {
+	resMsg, err := p.operator(msg)
+	if err != nil {
+		return nil, err
+	}
+	return service.MessageBatch{resMsg}, nil
+}
+
+func (p *processor) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,126 @@
+package cbor
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestCBORToJSON(t *testing.T) {
+	type testCase struct {
+		name           string
+		hexInput       string
+		expectedOutput any
+	}
+
+	tests := []testCase{
+		{
+			// {"a": 1, "b": [true, null, "foo"], "c": 1.5}
+			name:     "basic",
+			hexInput: "a3616101616283f5f663666f6f6163f93e00",
+			expectedOutput: map[string]any{
+				"a": uint64(1),
+				"b": []any{true, nil, "foo"},
+				"c": 1.5,
+			},
+		},
+		{
+			name:           "negative number",
+			hexInput:       "3863",
+			expectedOutput: int64(-100),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			input, err := hex.DecodeString(test.hexInput)
+			require.NoError(t, err)
+
+			proc, err := newProcessor("to_json")
+			require.NoError(t, err)
+
+			msgs, err := proc.Process(context.Background(), service.NewMessage(input))
+			require.NoError(t, err)
+			require.Len(t, msgs, 1)
+
+			act, err := msgs[0].AsStructured()
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOutput, act)
+		})
+	}
+}
+
+func TestCBORToJSONBadInput(t *testing.T) {
+	proc, err := newProcessor("to_json")
+	require.NoError(t, err)
+
+	_, err = proc.Process(context.Background(), service.NewMessage([]byte{0xa1}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to convert CBOR document to JSON")
+}
+
+func TestCBORFromJSON(t *testing.T) {
+	type testCase struct {
+		name      string
+		input     string
+		hexOutput string
+	}
+
+	tests := []testCase{
+		{
+			name:      "basic",
+			input:     `{"a":1,"b":[true,null,"foo"]}`,
+			hexOutput: "a2616101616283f5f663666f6f",
+		},
+		{
+			name:      "float",
+			input:     `1.5`,
+			hexOutput: "fb3ff8000000000000",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			proc, err := newProcessor("from_json")
+			require.NoError(t, err)
+
+			msgs, err := proc.Process(context.Background(), service.NewMessage([]byte(test.input)))
+			require.NoError(t, err)
+			require.Len(t, msgs, 1)
+
+			act, err := msgs[0].AsBytes()
+			require.NoError(t, err)
+			assert.Equal(t, test.hexOutput, hex.EncodeToString(act))
+		})
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	fromProc, err := newProcessor("from_json")
+	require.NoError(t, err)
+
+	toProc, err := newProcessor("to_json")
+	require.NoError(t, err)
+
+	msgs, err := fromProc.Process(context.Background(), service.NewMessage([]byte(`{"foo":{"bar":[1,2.5,"baz"]},"qux":false}`)))
+	require.NoError(t, err)
+
+	msgs, err = toProc.Process(context.Background(), msgs[0])
+	require.NoError(t, err)
+
+	act, err := msgs[0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `{"foo":{"bar":[1,2.5,"baz"]},"qux":false}`, string(act))
+}
+
+func TestCBORBadOperator(t *testing.T) {
+	_, err := newProcessor("nope")
+	require.EqualError(t, err, "operator not recognised: nope")
+}
@@ -94,6 +94,9 @@ func newReader(conf *service.ParsedConfig, mgr *service.Resources) (*reader, err
 	if r.cache, err = conf.FieldString("cache"); err != nil {
 		return nil, err
 	}
+	if !mgr.HasCache(r.cache) {
+		return nil, fmt.Errorf("cache resource '%v' was not found", r.cache)
+	}
 	if r.cacheKey, err = conf.FieldString("cache_key"); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,25 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestDiscordInputCacheNotFound(t *testing.T) {
+	conf, err := inputConfig().ParseYAML(`
+channel_id: foo
+bot_token: bar
+cache: baz
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newReader(conf, service.MockResources())
+	require.EqualError(t, err, "cache resource 'baz' was not found")
+
+	_, err = newReader(conf, service.MockResources(service.MockResourcesOptAddCache("baz")))
+	assert.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package elasticsearch
 import (
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
@@ -51,6 +52,10 @@ Both the `+"`id` and `index`"+` fields can be dynamically set using function
 interpolations described [here](/docs/configuration/interpolation#bloblang-queries). When
 sending batched messages these interpolations are performed per message part.
 
+### Elastic Cloud
+
+When connecting to a deployment hosted on Elastic Cloud the `+"`cloud_id`"+` field can be used instead of `+"`urls`"+`, and requests can be authenticated with an API key by setting the `+"`api_key`"+` field to the base64 encoded credentials of the key. Sniffing is not supported by Elastic Cloud deployments and is therefore disabled when a `+"`cloud_id`"+` is set, regardless of the `+"`sniff`"+` field.
+
 ### AWS
 
 It's possible to enable AWS connectivity with this output using the `+"`aws`"+`
@@ -71,6 +76,8 @@ false for connections to succeed.`),
 			docs.FieldInt("max_in_flight", "The maximum number of parallel message batches to have in flight at any given time."),
 		).WithChildren(retries.FieldSpecs()...).WithChildren(
 			interop.Unwrap(httpclient.BasicAuthField()),
+			docs.FieldString("api_key", "An optional base64 encoded API key to authenticate requests with, which is sent within an `Authorization` header.").Secret().Advanced().AtVersion("4.18.0"),
+			docs.FieldString("cloud_id", "An optional Elastic Cloud ID, which is resolved into the URL of the deployment and appended to the list of `urls`. When set the `sniff` field is ignored and sniffing is disabled.").Advanced().AtVersion("4.18.0"),
 			policy.FieldSpec(),
 			docs.FieldObject("aws", "Enables and customises connectivity to Amazon Elastic Service.").WithChildren(
 				docs.FieldSpecs{
@@ -162,6 +169,17 @@ func NewElasticsearchV2(conf output.ElasticsearchConfig, mgr bundle.NewManagemen
 			}
 		}
 	}
+	if conf.CloudID != "" {
+		cloudURL, err := urlFromCloudID(conf.CloudID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cloud_id: %w", err)
+		}
+		e.urls = append(e.urls, cloudURL)
+
+		// Elastic Cloud deployments sit behind a proxy and sniffing for nodes
+		// returns addresses that are unreachable from outside.
+		e.sniff = false
+	}
 
 	if tout := conf.Timeout; len(tout) > 0 {
 		var err error
@@ -183,6 +201,27 @@ func NewElasticsearchV2(conf output.ElasticsearchConfig, mgr bundle.NewManagemen
 	return &e, nil
 }
 
+// urlFromCloudID decodes an Elastic Cloud ID, which takes the form
+// <label>:<base64(host$es_id$kibana_id)>, into the URL of the Elasticsearch
+// deployment.
+func urlFromCloudID(cloudID string) (string, error) {
+	idx := strings.LastIndex(cloudID, ":")
+	if idx == -1 {
+		return "", errors.New("expected a label followed by a colon")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(cloudID[idx+1:])
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(strings.TrimSuffix(string(data), "$"), "$")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", errors.New("expected a host and deployment ID")
+	}
+	return fmt.Sprintf("https://%s.%s", parts[1], parts[0]), nil
+}
+
 //------------------------------------------------------------------------------
 
 // Connect attempts to establish a connection to a Elasticsearch broker.
@@ -203,6 +242,12 @@ func (e *Elasticsearch) Connect(ctx context.Context) error {
 		))
 	}
 
+	if e.conf.APIKey != "" {
+		opts = append(opts, elastic.SetHeaders(http.Header{
+			"Authorization": []string{"ApiKey " + e.conf.APIKey},
+		}))
+	}
+
 	if e.conf.TLS.Enabled {
 		opts = append(opts, elastic.SetHttpClient(&http.Client{
 			Transport: &http.Transport{
@@ -0,0 +1,116 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/component/output"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+	"github.com/benthosdev/benthos/v4/internal/message"
+)
+
+func TestURLFromCloudID(t *testing.T) {
+	encode := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name        string
+		cloudID     string
+		expected    string
+		errContains string
+	}{
+		{
+			name:     "basic",
+			cloudID:  "my-deployment:" + encode("us-east-1.aws.found.io$abc123$def456"),
+			expected: "https://abc123.us-east-1.aws.found.io",
+		},
+		{
+			name:     "with port",
+			cloudID:  "my-deployment:" + encode("us-east-1.aws.found.io:443$abc123$def456"),
+			expected: "https://abc123.us-east-1.aws.found.io:443",
+		},
+		{
+			name:        "no label",
+			cloudID:     encode("us-east-1.aws.found.io$abc123"),
+			errContains: "expected a label",
+		},
+		{
+			name:        "no deployment",
+			cloudID:     "foo:" + encode("us-east-1.aws.found.io"),
+			errContains: "expected a host and deployment ID",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			u, err := urlFromCloudID(test.cloudID)
+			if test.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, u)
+		})
+	}
+}
+
+func TestElasticsearchCloudIDDisablesSniff(t *testing.T) {
+	conf := output.NewElasticsearchConfig()
+	conf.URLs = nil
+	conf.Sniff = true
+	conf.CloudID = "foo:" + base64.StdEncoding.EncodeToString([]byte("example.com$abc$def"))
+
+	e, err := NewElasticsearchV2(conf, mock.NewManager())
+	require.NoError(t, err)
+
+	assert.False(t, e.sniff)
+	assert.Equal(t, []string{"https://abc.example.com"}, e.urls)
+}
+
+func TestElasticsearchAPIKey(t *testing.T) {
+	var authHeaders []string
+	var authMut sync.Mutex
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authMut.Lock()
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		authMut.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[{"index":{"_index":"foo","_id":"1","status":201}}]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	conf := output.NewElasticsearchConfig()
+	conf.URLs = []string{ts.URL}
+	conf.Index = "foo"
+	conf.ID = "1"
+	conf.Sniff = false
+	conf.Healthcheck = false
+	conf.APIKey = "Zm9vOmJhcg=="
+
+	e, err := NewElasticsearchV2(conf, mock.NewManager())
+	require.NoError(t, err)
+
+	require.NoError(t, e.Connect(context.Background()))
+	require.NoError(t, e.WriteBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte(`{"hello":"world"}`),
+	})))
+
+	authMut.Lock()
+	defer authMut.Unlock()
+	require.NotEmpty(t, authHeaders)
+	for _, h := range authHeaders {
+		assert.Equal(t, "ApiKey Zm9vOmJhcg==", h)
+	}
+}
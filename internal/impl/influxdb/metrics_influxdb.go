@@ -60,8 +60,9 @@ type influxDBMetrics struct {
 	pingInterval time.Duration
 	timeout      time.Duration
 
-	ctx    context.Context
-	cancel func()
+	ctx      context.Context
+	cancel   func()
+	loopDone chan struct{}
 
 	registry        metrics.Registry
 	runtimeRegistry metrics.Registry
@@ -77,6 +78,7 @@ func newInfluxDB(config imetrics.Config, nm bundle.NewManagement) (imetrics.Type
 		runtimeRegistry: metrics.NewRegistry(),
 		mgr:             nm,
 		log:             nm.Logger(),
+		loopDone:        make(chan struct{}),
 	}
 
 	i.ctx, i.cancel = context.WithCancel(context.Background())
@@ -170,6 +172,8 @@ func (i *influxDBMetrics) makeClient() error {
 }
 
 func (i *influxDBMetrics) loop() {
+	defer close(i.loopDone)
+
 	ticker := time.NewTicker(i.interval)
 	pingTicker := time.NewTicker(i.pingInterval)
 	defer ticker.Stop()
@@ -350,6 +354,11 @@ func (i *influxDBMetrics) HandlerFunc() http.HandlerFunc {
 }
 
 func (i *influxDBMetrics) Close() error {
+	// Stop periodic publishing before the final publish so that the client is
+	// no longer used once closed.
+	i.cancel()
+	<-i.loopDone
+
 	if err := i.publishRegistry(); err != nil {
 		i.log.Errorf("failed to send metrics data: %s", err)
 	}
@@ -1,8 +1,13 @@
 package influxdb
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component/metrics"
@@ -188,3 +193,34 @@ func TestInflux_makeClientInvalid(t *testing.T) {
 		t.Errorf("did not expect client created")
 	}
 }
+
+func TestInfluxCloseStopsPublishing(t *testing.T) {
+	var writes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/write" {
+			atomic.AddInt32(&writes, 1)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	config := metrics.NewConfig()
+	config.InfluxDB.URL = server.URL
+	config.InfluxDB.DB = "db0"
+	config.InfluxDB.Interval = "5ms"
+
+	flux, err := newInfluxDB(config, mock.NewManager())
+	require.NoError(t, err)
+
+	flux.GetCounter("foo").Incr(1)
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&writes) > 0
+	}, time.Second*5, time.Millisecond*5)
+
+	require.NoError(t, flux.Close())
+	closedWrites := atomic.LoadInt32(&writes)
+
+	<-time.After(time.Millisecond * 50)
+	assert.Equal(t, closedWrites, atomic.LoadInt32(&writes))
+}
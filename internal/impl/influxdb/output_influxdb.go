@@ -0,0 +1,391 @@
+package influxdb
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	client "github.com/influxdata/influxdb1-client/v2"
+
+	"github.com/benthosdev/benthos/v4/internal/bloblang/query"
+	"github.com/benthosdev/benthos/v4/public/bloblang"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	ioFieldURL              = "url"
+	ioFieldDB               = "db"
+	ioFieldRetentionPolicy  = "retention_policy"
+	ioFieldWriteConsistency = "write_consistency"
+	ioFieldPrecision        = "precision"
+	ioFieldUsername         = "username"
+	ioFieldPassword         = "password"
+	ioFieldTLS              = "tls"
+	ioFieldTimeout          = "timeout"
+	ioFieldMeasurement      = "measurement"
+	ioFieldTags             = "tags"
+	ioFieldFieldsMapping    = "fields_mapping"
+	ioFieldTimestampMapping = "timestamp_mapping"
+	ioFieldMaxInFlight      = "max_in_flight"
+	ioFieldBatching         = "batching"
+)
+
+func influxDBOutputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Services").
+		Version("4.18.0").
+		Summary("Writes messages as points to InfluxDB using the line protocol.").
+		Description(`Each message is converted into a point where the measurement and tags are derived from interpolated strings, and the fields are derived from a [Bloblang mapping](/docs/guides/bloblang/about) that must result in an object. Points of a batch are written in a single request, and therefore a `+"[`batching` policy](/docs/configuration/batching)"+` should be used in order to write points efficiently. Messages of a batch that fail to be converted into points are rejected individually, and the remaining points are still written.
+
+Points are written using the `+"`/write`"+` endpoint of InfluxDB 1.x. InfluxDB 2.x and InfluxDB Cloud support this endpoint for compatibility, in which case the field `+"`db`"+` should be set to the target bucket, the field `+"`password`"+` should be set to an API token of the target organisation, and the field `+"`retention_policy`"+` can be used in order to select a specific DBRP mapping.`).
+		Fields(
+			service.NewURLField(ioFieldURL).
+				Description("A URL of the format `[https|http|udp]://host:port` to the InfluxDB host.").
+				Example("http://localhost:8086"),
+			service.NewStringField(ioFieldDB).
+				Description("The name of the database (or bucket) to write points to."),
+			service.NewStringField(ioFieldRetentionPolicy).
+				Description("An optional retention policy to write points to.").
+				Advanced().
+				Default(""),
+			service.NewStringEnumField(ioFieldWriteConsistency, "", "any", "one", "quorum", "all").
+				Description("The write consistency to request when writing points to a clustered InfluxDB.").
+				Advanced().
+				Default(""),
+			service.NewStringEnumField(ioFieldPrecision, "ns", "us", "ms", "s").
+				Description("The timestamp precision of written points.").
+				Advanced().
+				Default("ns"),
+			service.NewStringField(ioFieldUsername).
+				Description("A username (when applicable).").
+				Advanced().
+				Default(""),
+			service.NewStringField(ioFieldPassword).
+				Description("A password (when applicable), or an API token when writing to InfluxDB 2.x.").
+				Advanced().
+				Secret().
+				Default(""),
+			service.NewTLSToggledField(ioFieldTLS),
+			service.NewDurationField(ioFieldTimeout).
+				Description("The maximum period to wait for a write request to complete.").
+				Advanced().
+				Default("5s"),
+			service.NewInterpolatedStringField(ioFieldMeasurement).
+				Description("The measurement of each point.").
+				Examples("cpu", `${! json("name") }`),
+			service.NewInterpolatedStringMapField(ioFieldTags).
+				Description("A map of tags to add to each point. Tags that resolve to an empty string are omitted.").
+				Example(map[string]any{
+					"host":   `${! json("host") }`,
+					"region": `${! meta("region").or("") }`,
+				}).
+				Default(map[string]any{}),
+			service.NewBloblangField(ioFieldFieldsMapping).
+				Description("A [Bloblang mapping](/docs/guides/bloblang/about) that should result in an object of fields for each point. Field values must be numbers, strings or booleans.").
+				Examples(
+					`root = this.without("name", "host")`,
+					`root.usage = this.cpu.usage_percent`,
+				),
+			service.NewBloblangField(ioFieldTimestampMapping).
+				Description("An optional [Bloblang mapping](/docs/guides/bloblang/about) that provides the timestamp of each point, which must either be a numerical unix time in seconds (with up to nanosecond precision via decimals), or a string in ISO 8601 format. By default points are timestamped at the time they are written.").
+				Examples(`root = this.timestamp`).
+				Optional(),
+			service.NewIntField(ioFieldMaxInFlight).
+				Description("The maximum number of batches to have in flight at a given time. Increase this to improve throughput.").
+				Default(64),
+			service.NewBatchPolicyField(ioFieldBatching),
+		).
+		Example(
+			"Writing CPU metrics",
+			"In this example we consume JSON documents describing the CPU usage of hosts and write them as points to the measurement `cpu`, tagged by the host.",
+			`
+output:
+  influxdb:
+    url: http://localhost:8086
+    db: telemetry
+    measurement: cpu
+    tags:
+      host: ${! json("host") }
+    fields_mapping: |
+      root.usage_user = this.usage.user
+      root.usage_system = this.usage.system
+    timestamp_mapping: root = this.timestamp
+    batching:
+      count: 1000
+      period: 1s
+`,
+		)
+}
+
+func init() {
+	err := service.RegisterBatchOutput("influxdb", influxDBOutputSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.BatchOutput, batchPolicy service.BatchPolicy, maxInFlight int, err error) {
+			if batchPolicy, err = conf.FieldBatchPolicy(ioFieldBatching); err != nil {
+				return
+			}
+			if maxInFlight, err = conf.FieldInt(ioFieldMaxInFlight); err != nil {
+				return
+			}
+			out, err = newInfluxDBOutputFromParsed(conf, mgr)
+			return
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type influxDBOutput struct {
+	url       *url.URL
+	username  string
+	password  string
+	tlsConf   *tls.Config
+	timeout   time.Duration
+	pointConf client.BatchPointsConfig
+
+	measurement *service.InterpolatedString
+	tags        map[string]*service.InterpolatedString
+	fields      *bloblang.Executor
+	timestamp   *bloblang.Executor
+
+	log *service.Logger
+
+	clientMut sync.RWMutex
+	client    client.Client
+}
+
+func newInfluxDBOutputFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*influxDBOutput, error) {
+	i := &influxDBOutput{
+		log: mgr.Logger(),
+	}
+
+	var err error
+	if i.url, err = conf.FieldURL(ioFieldURL); err != nil {
+		return nil, err
+	}
+	switch i.url.Scheme {
+	case "http", "https", "udp":
+	default:
+		return nil, fmt.Errorf("protocol needs to be http, https or udp and is %s", i.url.Scheme)
+	}
+
+	if i.pointConf.Database, err = conf.FieldString(ioFieldDB); err != nil {
+		return nil, err
+	}
+	if i.pointConf.RetentionPolicy, err = conf.FieldString(ioFieldRetentionPolicy); err != nil {
+		return nil, err
+	}
+	if i.pointConf.WriteConsistency, err = conf.FieldString(ioFieldWriteConsistency); err != nil {
+		return nil, err
+	}
+	if i.pointConf.Precision, err = conf.FieldString(ioFieldPrecision); err != nil {
+		return nil, err
+	}
+	if i.username, err = conf.FieldString(ioFieldUsername); err != nil {
+		return nil, err
+	}
+	if i.password, err = conf.FieldString(ioFieldPassword); err != nil {
+		return nil, err
+	}
+
+	tlsConf, tlsEnabled, err := conf.FieldTLSToggled(ioFieldTLS)
+	if err != nil {
+		return nil, err
+	}
+	if tlsEnabled {
+		i.tlsConf = tlsConf
+	}
+
+	if i.timeout, err = conf.FieldDuration(ioFieldTimeout); err != nil {
+		return nil, err
+	}
+	if i.measurement, err = conf.FieldInterpolatedString(ioFieldMeasurement); err != nil {
+		return nil, err
+	}
+	if i.tags, err = conf.FieldInterpolatedStringMap(ioFieldTags); err != nil {
+		return nil, err
+	}
+	if i.fields, err = conf.FieldBloblang(ioFieldFieldsMapping); err != nil {
+		return nil, err
+	}
+	if conf.Contains(ioFieldTimestampMapping) {
+		if i.timestamp, err = conf.FieldBloblang(ioFieldTimestampMapping); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+func (i *influxDBOutput) Connect(ctx context.Context) error {
+	i.clientMut.Lock()
+	defer i.clientMut.Unlock()
+
+	if i.client != nil {
+		return nil
+	}
+
+	var c client.Client
+	var err error
+	if i.url.Scheme == "udp" {
+		c, err = client.NewUDPClient(client.UDPConfig{
+			Addr: i.url.Host,
+		})
+	} else {
+		c, err = client.NewHTTPClient(client.HTTPConfig{
+			Addr:      i.url.String(),
+			Username:  i.username,
+			Password:  i.password,
+			Timeout:   i.timeout,
+			TLSConfig: i.tlsConf,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	i.client = c
+	i.log.Infof("Writing points to InfluxDB database: %v", i.pointConf.Database)
+	return nil
+}
+
+func influxFieldValue(v any) (any, error) {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	case float64, float32, int64, int32, int, uint64, uint32, bool, string:
+		return t, nil
+	}
+	return nil, fmt.Errorf("unsupported field value type: %T", v)
+}
+
+func (i *influxDBOutput) point(batch service.MessageBatch, index int) (*client.Point, error) {
+	msg := batch[index]
+
+	measurement, err := batch.TryInterpolatedString(index, i.measurement)
+	if err != nil {
+		return nil, fmt.Errorf("measurement interpolation error: %w", err)
+	}
+
+	tags := map[string]string{}
+	for k, v := range i.tags {
+		tag, err := batch.TryInterpolatedString(index, v)
+		if err != nil {
+			return nil, fmt.Errorf("tag %v interpolation error: %w", k, err)
+		}
+		if tag != "" {
+			tags[k] = tag
+		}
+	}
+
+	fieldsMsg, err := batch.BloblangQuery(index, i.fields)
+	if err != nil {
+		return nil, fmt.Errorf("fields mapping failed: %w", err)
+	}
+	if fieldsMsg == nil {
+		return nil, errors.New("fields mapping resulted in a deleted message")
+	}
+	fieldsAny, err := fieldsMsg.AsStructured()
+	if err != nil {
+		return nil, fmt.Errorf("fields mapping failed: %w", err)
+	}
+	fieldsObj, ok := fieldsAny.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("fields mapping must result in an object, got %T", fieldsAny)
+	}
+
+	fields := make(map[string]any, len(fieldsObj))
+	for k, v := range fieldsObj {
+		if fields[k], err = influxFieldValue(v); err != nil {
+			return nil, fmt.Errorf("field %v: %w", k, err)
+		}
+	}
+
+	var ts time.Time
+	if i.timestamp != nil {
+		tsMsg, err := msg.BloblangQuery(i.timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("timestamp mapping failed: %w", err)
+		}
+		if tsMsg == nil {
+			return nil, errors.New("timestamp mapping resulted in a deleted message")
+		}
+		tsValue, err := tsMsg.AsStructured()
+		if err != nil {
+			if tsBytes, _ := tsMsg.AsBytes(); len(tsBytes) > 0 {
+				tsValue = string(tsBytes)
+				err = nil
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("timestamp mapping failed: %w", err)
+		}
+		if ts, err = query.IGetTimestamp(tsValue); err != nil {
+			return nil, fmt.Errorf("timestamp mapping failed: %w", err)
+		}
+	} else {
+		ts = time.Now()
+	}
+
+	return client.NewPoint(measurement, tags, fields, ts)
+}
+
+func (i *influxDBOutput) WriteBatch(ctx context.Context, batch service.MessageBatch) error {
+	i.clientMut.RLock()
+	c := i.client
+	i.clientMut.RUnlock()
+	if c == nil {
+		return service.ErrNotConnected
+	}
+
+	points, err := client.NewBatchPoints(i.pointConf)
+	if err != nil {
+		return err
+	}
+
+	// Messages that fail to convert are rejected individually so that the
+	// rest of the batch can still be written.
+	var batchErr *service.BatchError
+	for index := range batch {
+		p, err := i.point(batch, index)
+		if err != nil {
+			if batchErr == nil {
+				batchErr = service.NewBatchError(batch, err)
+			}
+			batchErr.Failed(index, err)
+			continue
+		}
+		points.AddPoint(p)
+	}
+
+	if len(points.Points()) > 0 {
+		if err := c.Write(points); err != nil {
+			return err
+		}
+	}
+	if batchErr != nil {
+		return batchErr
+	}
+	return nil
+}
+
+func (i *influxDBOutput) Close(ctx context.Context) error {
+	i.clientMut.Lock()
+	defer i.clientMut.Unlock()
+
+	if i.client == nil {
+		return nil
+	}
+	err := i.client.Close()
+	i.client = nil
+	return err
+}
@@ -0,0 +1,138 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestInfluxDBOutput(t *testing.T) {
+	reqs := make(chan *http.Request, 1)
+	bodies := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		reqs <- r
+		bodies <- string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	conf, err := influxDBOutputSpec().ParseYAML(fmt.Sprintf(`
+url: %v
+db: telemetry
+retention_policy: weekly
+precision: s
+measurement: ${! json("name") }
+tags:
+  host: ${! json("host") }
+  region: ${! meta("region").or("") }
+fields_mapping: root = this.without("name", "host", "ts")
+timestamp_mapping: root = this.ts
+`, ts.URL), nil)
+	require.NoError(t, err)
+
+	out, err := newInfluxDBOutputFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, out.Connect(context.Background()))
+
+	msgA := service.NewMessage([]byte(`{"name":"cpu","host":"a","ts":1600000000,"usage":0.5,"cores":4}`))
+	msgA.MetaSet("region", "eu")
+	msgB := service.NewMessage([]byte(`{"name":"mem","host":"b","ts":1600000010,"free":"lots","ok":true}`))
+
+	require.NoError(t, out.WriteBatch(context.Background(), service.MessageBatch{msgA, msgB}))
+	require.NoError(t, out.Close(context.Background()))
+
+	req := <-reqs
+	assert.Equal(t, "/write", req.URL.Path)
+	assert.Equal(t, "telemetry", req.URL.Query().Get("db"))
+	assert.Equal(t, "weekly", req.URL.Query().Get("rp"))
+	assert.Equal(t, "s", req.URL.Query().Get("precision"))
+
+	lines := strings.Split(strings.TrimSpace(<-bodies), "\n")
+	sort.Strings(lines)
+	assert.Equal(t, []string{
+		`cpu,host=a,region=eu cores=4i,usage=0.5 1600000000`,
+		`mem,host=b free="lots",ok=true 1600000010`,
+	}, lines)
+}
+
+func TestInfluxDBOutputBadFields(t *testing.T) {
+	conf, err := influxDBOutputSpec().ParseYAML(`
+url: http://localhost:8086
+db: telemetry
+measurement: cpu
+fields_mapping: root = this
+`, nil)
+	require.NoError(t, err)
+
+	out, err := newInfluxDBOutputFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+
+	for _, input := range []string{
+		`{"nested":{"a":1}}`,
+		`["not","an","object"]`,
+	} {
+		_, err := out.point(service.MessageBatch{service.NewMessage([]byte(input))}, 0)
+		require.Error(t, err, input)
+	}
+}
+
+func TestInfluxDBOutputPartialBatch(t *testing.T) {
+	bodies := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies <- string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	conf, err := influxDBOutputSpec().ParseYAML(fmt.Sprintf(`
+url: %v
+db: telemetry
+precision: s
+measurement: cpu
+fields_mapping: root = this.without("ts")
+timestamp_mapping: root = this.ts
+`, ts.URL), nil)
+	require.NoError(t, err)
+
+	out, err := newInfluxDBOutputFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, out.Connect(context.Background()))
+	defer out.Close(context.Background())
+
+	err = out.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`{"ts":1600000000,"usage":0.5}`)),
+		service.NewMessage([]byte(`{"ts":1600000005,"nested":{"a":1}}`)),
+		service.NewMessage([]byte(`{"ts":1600000010,"usage":0.25}`)),
+	})
+	require.Error(t, err)
+
+	var bErr *service.BatchError
+	require.ErrorAs(t, err, &bErr)
+	assert.Equal(t, 1, bErr.IndexedErrors())
+
+	var failed []int
+	bErr.WalkMessages(func(i int, _ *service.Message, err error) bool {
+		if err != nil {
+			failed = append(failed, i)
+		}
+		return true
+	})
+	assert.Equal(t, []int{1}, failed)
+
+	assert.Equal(t, []string{
+		`cpu usage=0.5 1600000000`,
+		`cpu usage=0.25 1600000010`,
+	}, strings.Split(strings.TrimSpace(<-bodies), "\n"))
+}
@@ -3,9 +3,11 @@ package io
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/benthosdev/benthos/v4/internal/filepath/ifs"
@@ -16,7 +18,9 @@ func fileCacheConfig() *service.ConfigSpec {
 	spec := service.NewConfigSpec().
 		Stable().
 		Summary(`Stores each item in a directory as a file, where an item ID is the path relative to the configured directory.`).
-		Description(`This type currently offers no form of item expiry or garbage collection, and is intended to be used for development and debugging purposes only.`).
+		Description(`Keys containing path separators are stored within subdirectories, which are created as required, and keys that resolve to a path outside of the directory are rejected. Since the ` + "`add`" + ` operation creates files exclusively this cache can be used as a durable store for deduplication across restarts.
+
+This type currently offers no form of item expiry or garbage collection, and therefore items must be cleaned up externally when they are no longer needed.`).
 		Field(service.NewStringField("directory").
 			Description("The directory within which to store items."))
 
@@ -57,8 +61,26 @@ type fileCache struct {
 	dir string
 }
 
+// keyPath returns the path of the file for a key, or an error if the key
+// resolves to a path outside of the cache directory.
+func (f *fileCache) keyPath(key string) (string, error) {
+	path := filepath.Join(f.dir, key)
+	rel, err := filepath.Rel(f.dir, path)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("key '%v' resolves outside of the cache directory", key)
+	}
+	return path, nil
+}
+
 func (f *fileCache) Get(_ context.Context, key string) ([]byte, error) {
-	b, err := ifs.ReadFile(f.mgr.FS(), filepath.Join(f.dir, key))
+	path, err := f.keyPath(key)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ifs.ReadFile(f.mgr.FS(), path)
 	if errors.Is(err, fs.ErrNotExist) {
 		return nil, service.ErrKeyNotFound
 	}
@@ -66,11 +88,25 @@ func (f *fileCache) Get(_ context.Context, key string) ([]byte, error) {
 }
 
 func (f *fileCache) Set(_ context.Context, key string, value []byte, _ *time.Duration) error {
-	return ifs.WriteFile(f.mgr.FS(), filepath.Join(f.dir, key), value, 0o644)
+	path, err := f.keyPath(key)
+	if err != nil {
+		return err
+	}
+	if err := f.mgr.FS().MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return ifs.WriteFile(f.mgr.FS(), path, value, 0o644)
 }
 
 func (f *fileCache) Add(_ context.Context, key string, value []byte, _ *time.Duration) error {
-	file, err := f.mgr.FS().OpenFile(filepath.Join(f.dir, key), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+	path, err := f.keyPath(key)
+	if err != nil {
+		return err
+	}
+	if err := f.mgr.FS().MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := f.mgr.FS().OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
 	if err != nil {
 		if errors.Is(err, fs.ErrExist) {
 			return service.ErrKeyAlreadyExists
@@ -85,7 +121,14 @@ func (f *fileCache) Add(_ context.Context, key string, value []byte, _ *time.Dur
 }
 
 func (f *fileCache) Delete(_ context.Context, key string) error {
-	return f.mgr.FS().Remove(filepath.Join(f.dir, key))
+	path, err := f.keyPath(key)
+	if err != nil {
+		return err
+	}
+	if err := f.mgr.FS().Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
 }
 
 func (f *fileCache) Close(context.Context) error {
@@ -3,6 +3,7 @@ package io
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,4 +48,50 @@ func TestFileCache(t *testing.T) {
 
 	_, err = c.Get(tCtx, "foo")
 	assert.Equal(t, service.ErrKeyNotFound, err)
+
+	require.NoError(t, c.Delete(tCtx, "foo"))
+}
+
+func TestFileCacheNestedKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	tCtx := context.Background()
+	c := newFileCache(dir, service.MockResources())
+
+	require.NoError(t, c.Set(tCtx, "foo/bar", []byte("1"), nil))
+	require.NoError(t, c.Add(tCtx, "baz/buz/qux", []byte("2"), nil))
+
+	act, err := c.Get(tCtx, "foo/bar")
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(act))
+
+	act, err = c.Get(tCtx, "baz/buz/qux")
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(act))
+
+	assert.Equal(t, service.ErrKeyAlreadyExists, c.Add(tCtx, "baz/buz/qux", []byte("3"), nil))
+}
+
+func TestFileCacheKeysOutsideDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	tCtx := context.Background()
+	c := newFileCache(filepath.Join(dir, "cache"), service.MockResources())
+
+	for _, key := range []string{"../foo", "foo/../../bar", "..", "", "foo/.."} {
+		_, err := c.Get(tCtx, key)
+		assert.Error(t, err, key)
+		assert.Error(t, c.Set(tCtx, key, []byte("1"), nil), key)
+		assert.Error(t, c.Add(tCtx, key, []byte("1"), nil), key)
+		assert.Error(t, c.Delete(tCtx, key), key)
+	}
+
+	_, err := os.Stat(filepath.Join(dir, "foo"))
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, c.Set(tCtx, "foo/../bar", []byte("1"), nil))
+
+	act, err := c.Get(tCtx, "bar")
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(act))
 }
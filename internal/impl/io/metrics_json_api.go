@@ -16,9 +16,11 @@ func init() {
 		docs.ComponentSpec{
 			Name:    "json_api",
 			Type:    docs.TypeMetrics,
-			Summary: `Serves metrics as JSON object with the service wide HTTP service at the endpoints ` + "`/stats` and `/metrics`" + `.`,
+			Summary: `Serves metrics as JSON object with the service wide HTTP service at the endpoints ` + "`/stats`, `/metrics` and `/metrics/json`" + `.`,
 			Description: `
-This metrics type is useful for debugging as it provides a human readable format that you can parse with tools such as ` + "`jq`" + ``,
+This metrics type is useful for debugging as it provides a human readable format that you can parse with tools such as ` + "`jq`" + `, and allows you to inspect the current state of a running instance without a metrics backend.
+
+Counters and gauges are served as their current value, and timings are served as an object containing the 50th, 90th and 99th percentiles in nanoseconds. Metrics with labels are keyed by their name followed by their labels in the format ` + "`name{label=\"value\"}`" + `.`,
 			Config: docs.FieldObject("", "").HasDefault(struct{}{}),
 		})
 }
@@ -72,6 +74,10 @@ func (h *jsonAPIMetrics) HandlerFunc() http.HandlerFunc {
 	}
 }
 
+func (h *jsonAPIMetrics) JSONHandlerFunc() http.HandlerFunc {
+	return h.HandlerFunc()
+}
+
 func (h *jsonAPIMetrics) GetCounter(path string) metrics.StatCounter {
 	return h.local.GetCounter(path)
 }
@@ -2,7 +2,9 @@ package io
 
 import (
 	"context"
+	"fmt"
 	"path"
+	"strings"
 	"sync"
 
 	"gopkg.in/yaml.v3"
@@ -13,6 +15,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/component/output/processors"
 	"github.com/benthosdev/benthos/v4/internal/docs"
 	"github.com/benthosdev/benthos/v4/internal/impl/pure"
+	"github.com/benthosdev/benthos/v4/public/bloblang"
 )
 
 func init() {
@@ -35,7 +38,7 @@ Returns the configuration of an output.
 
 ### POST ` + "`/outputs/{id}`" + `
 
-Creates or updates an output with a configuration provided in the request body (in YAML or JSON format).
+Creates or updates an output with a configuration provided in the request body (in YAML or JSON format). The configuration is linted before the output is created, and if any linting errors are found the request is rejected with a 400 status code and the errors detailed in the response body.
 
 ### DELETE ` + "`/outputs/{id}`" + `
 
@@ -108,8 +111,15 @@ func newDynamicOutput(conf output.Config, mgr bundle.NewManagement) (output.Stre
 	}
 
 	dynAPI.OnUpdate(func(ctx context.Context, id string, c []byte) error {
+		var node yaml.Node
+		if err := yaml.Unmarshal(c, &node); err != nil {
+			return api.NewBadRequestError(err)
+		}
+		if lints := lintDynamicOutputConfig(mgr, &node); len(lints) > 0 {
+			return api.NewBadRequestError(fmt.Errorf("output config contains linting errors: %v", strings.Join(lints, ", ")))
+		}
 		newConf := output.NewConfig()
-		if err := yaml.Unmarshal(c, &newConf); err != nil {
+		if err := node.Decode(&newConf); err != nil {
 			return err
 		}
 		oMgr := mgr.IntoPath("dynamic", "outputs", id)
@@ -158,3 +168,12 @@ func newDynamicOutput(conf output.Config, mgr bundle.NewManagement) (output.Stre
 
 	return fanOut, nil
 }
+
+func lintDynamicOutputConfig(mgr bundle.NewManagement, node *yaml.Node) (lints []string) {
+	lConf := docs.NewLintConfig()
+	lConf.BloblangEnv = bloblang.XWrapEnvironment(mgr.BloblEnvironment()).Deactivated()
+	for _, l := range docs.LintYAML(docs.NewLintContext(lConf), docs.TypeOutput, node) {
+		lints = append(lints, l.Error())
+	}
+	return
+}
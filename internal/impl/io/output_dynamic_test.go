@@ -78,3 +78,45 @@ drop: {}
 	o.TriggerCloseNow()
 	require.NoError(t, o.WaitForClose(ctx))
 }
+
+func TestDynamicOutputAPILintErrors(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	gMux := mux.NewRouter()
+
+	mgr := bmock.NewManager()
+	mgr.OnRegisterEndpoint = func(path string, h http.HandlerFunc) {
+		gMux.HandleFunc(path, h)
+	}
+
+	conf := output.NewConfig()
+	conf.Type = "dynamic"
+
+	o, err := mgr.NewOutput(conf)
+	require.NoError(t, err)
+
+	tChan := make(chan message.Transaction)
+	require.NoError(t, o.Consume(tChan))
+
+	req := httptest.NewRequest("POST", "/outputs/foo", bytes.NewBuffer([]byte(`
+file:
+  path: /tmp/foo.txt
+  nope: true
+`)))
+	res := httptest.NewRecorder()
+	gMux.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Code)
+	assert.Contains(t, res.Body.String(), "field nope not recognised")
+
+	req = httptest.NewRequest("GET", "/outputs", nil)
+	res = httptest.NewRecorder()
+	gMux.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Code)
+	assert.Equal(t, `{}`, res.Body.String())
+
+	close(tChan)
+	require.NoError(t, o.WaitForClose(ctx))
+}
@@ -8,21 +8,31 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/benthosdev/benthos/v4/internal/batch/policy/batchconfig"
 	"github.com/benthosdev/benthos/v4/internal/bloblang/field"
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/codec"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
+	"github.com/benthosdev/benthos/v4/internal/component/output/batcher"
+	"github.com/benthosdev/benthos/v4/internal/filepath/ifs"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
 	"github.com/benthosdev/benthos/v4/public/service"
 )
 
 const (
-	fileOutputFieldPath  = "path"
-	fileOutputFieldCodec = "codec"
+	fileOutputFieldPath             = "path"
+	fileOutputFieldCodec            = "codec"
+	fileOutputFieldRotation         = "rotation"
+	fileOutputFieldRotationMaxSize  = "max_size"
+	fileOutputFieldRotationPeriod   = "period"
+	fileOutputFieldRotationMaxFiles = "max_files"
+	fileOutputFieldBatching         = "batching"
 )
 
 func fileOutputSpec() *service.ConfigSpec {
@@ -30,7 +40,17 @@ func fileOutputSpec() *service.ConfigSpec {
 		Stable().
 		Categories("Local").
 		Summary(`Writes messages to files on disk based on a chosen codec.`).
-		Description(`Messages can be written to different files by using [interpolation functions](/docs/configuration/interpolation#bloblang-queries) in the path field. However, only one file is ever open at a given time, and therefore when the path changes the previously open file is closed.`).
+		Description(`Messages can be written to different files by using [interpolation functions](/docs/configuration/interpolation#bloblang-queries) in the path field. However, only one file is ever open at a given time, and therefore when the path changes the previously open file is closed.
+
+### Rotation
+
+Files can be rotated once they reach a certain size or once they have been open for a certain period by configuring the `+"`rotation`"+` fields. When a file is rotated it is renamed with the suffix `+"`.1`"+`, and any previously rotated files have their suffix incremented, following the same convention as logrotate. The field `+"`max_files`"+` can be used in order to limit the number of rotated files that are kept, where the oldest files are deleted.
+
+Rotation only applies to codecs that write multiple messages to the same file and therefore is not supported with the `+"`all-bytes`"+` codec. It is also possible to rotate files by date by interpolating timestamps within the path, e.g. `+"`/var/log/benthos/${! now().ts_format(\"2006-01-02\") }.log`"+`, which can be combined with the rotation fields.
+
+### Batching
+
+By default messages are written to files as they arrive. A `+"`batching`"+` policy can be configured in order to accumulate messages before they are written, which allows batch processors such as `+"[`archive`](/docs/components/processors/archive)"+` or `+"[`compress`](/docs/components/processors/compress)"+` to be applied to groups of messages before they reach the file.`).
 		Fields(
 			service.NewInterpolatedStringField(fileOutputFieldPath).
 				Description("The file to write to, if the file does not yet exist it will be created.").
@@ -41,12 +61,41 @@ func fileOutputSpec() *service.ConfigSpec {
 				).
 				Version("3.33.0"),
 			service.NewInternalField(codec.WriterDocs).Version("3.33.0").Default("lines"),
+			service.NewObjectField(fileOutputFieldRotation,
+				service.NewIntField(fileOutputFieldRotationMaxSize).
+					Description("The size in bytes at which point a file is rotated, if zero then files are not rotated by size.").
+					Examples(104857600).
+					Default(0),
+				service.NewDurationField(fileOutputFieldRotationPeriod).
+					Description("An optional period after which an open file is rotated, regardless of its size.").
+					Examples("1h", "24h").
+					Optional(),
+				service.NewIntField(fileOutputFieldRotationMaxFiles).
+					Description("The maximum number of rotated files to keep, if zero then rotated files are never deleted.").
+					Default(0),
+			).
+				Description("Configure the rotation of files.").
+				Advanced().
+				Version("4.18.0"),
+			service.NewBatchPolicyField(fileOutputFieldBatching).
+				Version("4.18.0"),
 		)
 }
 
 type fileOutputConfig struct {
-	Path  string
-	Codec string
+	Path     string
+	Codec    string
+	Rotation fileRotationConfig
+}
+
+type fileRotationConfig struct {
+	MaxSize  int64
+	Period   time.Duration
+	MaxFiles int
+}
+
+func (r fileRotationConfig) enabled() bool {
+	return r.MaxSize > 0 || r.Period > 0
 }
 
 func fileOutputConfigFromParsed(pConf *service.ParsedConfig) (conf fileOutputConfig, err error) {
@@ -56,6 +105,21 @@ func fileOutputConfigFromParsed(pConf *service.ParsedConfig) (conf fileOutputCon
 	if conf.Codec, err = pConf.FieldString(fileOutputFieldCodec); err != nil {
 		return
 	}
+
+	rConf := pConf.Namespace(fileOutputFieldRotation)
+	var maxSize int
+	if maxSize, err = rConf.FieldInt(fileOutputFieldRotationMaxSize); err != nil {
+		return
+	}
+	conf.Rotation.MaxSize = int64(maxSize)
+	if rConf.Contains(fileOutputFieldRotationPeriod) {
+		if conf.Rotation.Period, err = rConf.FieldDuration(fileOutputFieldRotationPeriod); err != nil {
+			return
+		}
+	}
+	if conf.Rotation.MaxFiles, err = rConf.FieldInt(fileOutputFieldRotationMaxFiles); err != nil {
+		return
+	}
 	return
 }
 
@@ -81,7 +145,16 @@ func init() {
 
 			mgr := interop.UnwrapManagement(res)
 			var f *fileWriter
-			if f, err = newFileWriter(conf.Path, conf.Codec, mgr); err != nil {
+			if f, err = newFileWriter(conf.Path, conf.Codec, conf.Rotation, mgr); err != nil {
+				return
+			}
+
+			var batchPolAny any
+			if batchPolAny, err = pConf.FieldAny(fileOutputFieldBatching); err != nil {
+				return
+			}
+			var batchConf batchconfig.Config
+			if batchConf, err = batchconfig.FromAny(batchPolAny); err != nil {
 				return
 			}
 
@@ -89,6 +162,9 @@ func init() {
 			if w, err = output.NewAsyncWriter("file", 1, f, mgr); err != nil {
 				return
 			}
+			if w, err = batcher.NewFromConfig(batchConf, w, mgr); err != nil {
+				return
+			}
 
 			out = interop.NewUnwrapInternalOutput(w)
 			return
@@ -107,30 +183,96 @@ type fileWriter struct {
 	path      *field.Expression
 	codec     codec.WriterConstructor
 	codecConf codec.WriterConfig
+	rotation  fileRotationConfig
 
-	handleMut  sync.Mutex
-	handlePath string
-	handle     codec.Writer
+	handleMut    sync.Mutex
+	handlePath   string
+	handle       codec.Writer
+	handleSize   int64
+	handleOpened time.Time
 }
 
-func newFileWriter(pathStr, codecStr string, mgr bundle.NewManagement) (*fileWriter, error) {
+func newFileWriter(pathStr, codecStr string, rotation fileRotationConfig, mgr bundle.NewManagement) (*fileWriter, error) {
 	codec, codecConf, err := codec.GetWriter(codecStr)
 	if err != nil {
 		return nil, err
 	}
+	if rotation.enabled() && codecConf.CloseAfter {
+		return nil, fmt.Errorf("file rotation is not supported with the codec %v", codecStr)
+	}
 	path, err := mgr.BloblEnvironment().NewField(pathStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse path expression: %w", err)
 	}
+	if codecConf.Document && path.NumDynamicExpressions() > 0 {
+		// Switching between paths would reopen and truncate files that have
+		// already been written to.
+		return nil, fmt.Errorf("the codec %v does not support an interpolated path", codecStr)
+	}
 	return &fileWriter{
 		codec:     codec,
 		codecConf: codecConf,
+		rotation:  rotation,
 		path:      path,
 		log:       mgr.Logger(),
 		nm:        mgr,
 	}, nil
 }
 
+// sizeCountingWriter tracks the number of bytes written to the currently open
+// file in order to determine when it should be rotated.
+type sizeCountingWriter struct {
+	io.WriteCloser
+	size *int64
+}
+
+func (s sizeCountingWriter) Write(p []byte) (int, error) {
+	n, err := s.WriteCloser.Write(p)
+	*s.size += int64(n)
+	return n, err
+}
+
+// shouldRotate returns true if the currently open file has exceeded any of the
+// configured rotation limits.
+func (w *fileWriter) shouldRotate() bool {
+	if w.rotation.MaxSize > 0 && w.handleSize >= w.rotation.MaxSize {
+		return true
+	}
+	if w.rotation.Period > 0 && time.Since(w.handleOpened) >= w.rotation.Period {
+		return true
+	}
+	return false
+}
+
+func rotatedPath(path string, i int) string {
+	return path + "." + strconv.Itoa(i)
+}
+
+// rotate moves the file at a given path to the suffix `.1`, incrementing the
+// suffix of existing rotated files and removing those that exceed the maximum
+// number of files to keep.
+func (w *fileWriter) rotate(path string) error {
+	store := w.nm.FS()
+
+	last := w.rotation.MaxFiles
+	if last <= 0 {
+		for last = 1; ; last++ {
+			if _, err := store.Stat(rotatedPath(path, last)); err != nil {
+				break
+			}
+		}
+	} else if err := store.Remove(rotatedPath(path, last)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	for i := last - 1; i > 0; i-- {
+		if err := ifs.Rename(store, rotatedPath(path, i), rotatedPath(path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return ifs.Rename(store, path, rotatedPath(path, 1))
+}
+
 //------------------------------------------------------------------------------
 
 func (w *fileWriter) Connect(ctx context.Context) error {
@@ -149,12 +291,21 @@ func (w *fileWriter) WriteBatch(ctx context.Context, msg message.Batch) error {
 		defer w.handleMut.Unlock()
 
 		if w.handle != nil && path == w.handlePath {
-			return w.handle.Write(ctx, p)
+			if !w.rotation.enabled() || !w.shouldRotate() {
+				return w.handle.Write(ctx, p)
+			}
 		}
 		if w.handle != nil {
-			if err := w.handle.Close(ctx); err != nil {
+			err := w.handle.Close(ctx)
+			w.handle = nil
+			if err != nil {
 				return err
 			}
+			if path == w.handlePath {
+				if err := w.rotate(path); err != nil {
+					return fmt.Errorf("failed to rotate file: %w", err)
+				}
+			}
 		}
 
 		flag := os.O_CREATE | os.O_RDWR
@@ -181,6 +332,15 @@ func (w *fileWriter) WriteBatch(ctx context.Context, msg message.Batch) error {
 		}
 
 		w.handlePath = path
+		w.handleOpened = time.Now()
+		w.handleSize = 0
+		if w.rotation.enabled() {
+			if info, err := file.Stat(); err == nil {
+				w.handleSize = info.Size()
+			}
+			fileWriter = sizeCountingWriter{WriteCloser: fileWriter, size: &w.handleSize}
+		}
+
 		handle, err := w.codec(fileWriter)
 		if err != nil {
 			return err
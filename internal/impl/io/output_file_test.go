@@ -0,0 +1,132 @@
+package io
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+	"github.com/benthosdev/benthos/v4/internal/message"
+	"github.com/benthosdev/benthos/v4/public/service"
+
+	_ "github.com/benthosdev/benthos/v4/internal/impl/pure"
+)
+
+func TestFileOutputRotationBySize(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo.log")
+
+	w, err := newFileWriter(path, "lines", fileRotationConfig{
+		MaxSize:  8,
+		MaxFiles: 2,
+	}, mock.NewManager())
+	require.NoError(t, err)
+
+	for _, s := range []string{"aaaa", "bbbb", "cccc", "dddd", "eeee", "ffff", "gggg"} {
+		require.NoError(t, w.WriteBatch(ctx, message.QuickBatch([][]byte{[]byte(s)})))
+	}
+	require.NoError(t, w.Close(ctx))
+
+	for file, exp := range map[string]string{
+		"foo.log":   "gggg\n",
+		"foo.log.1": "eeee\nffff\n",
+		"foo.log.2": "cccc\ndddd\n",
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err, file)
+		assert.Equal(t, exp, string(b), file)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "foo.log.3"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileOutputRotationUnlimited(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo.log")
+
+	w, err := newFileWriter(path, "lines", fileRotationConfig{
+		MaxSize: 1,
+	}, mock.NewManager())
+	require.NoError(t, err)
+
+	for _, s := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, w.WriteBatch(ctx, message.QuickBatch([][]byte{[]byte(s)})))
+	}
+	require.NoError(t, w.Close(ctx))
+
+	for file, exp := range map[string]string{
+		"foo.log":   "d\n",
+		"foo.log.1": "c\n",
+		"foo.log.2": "b\n",
+		"foo.log.3": "a\n",
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err, file)
+		assert.Equal(t, exp, string(b), file)
+	}
+}
+
+func TestFileOutputRotationBadCodec(t *testing.T) {
+	_, err := newFileWriter("/tmp/foo", "all-bytes", fileRotationConfig{
+		MaxSize: 10,
+	}, mock.NewManager())
+	require.Error(t, err)
+}
+
+func TestFileOutputDocumentCodecInterpolatedPath(t *testing.T) {
+	for _, c := range []string{"json-array", "tar"} {
+		_, err := newFileWriter(`/tmp/${! meta("foo") }.out`, c, fileRotationConfig{}, mock.NewManager())
+		require.Error(t, err, c)
+
+		_, err = newFileWriter("/tmp/foo.out", c, fileRotationConfig{}, mock.NewManager())
+		require.NoError(t, err, c)
+	}
+
+	_, err := newFileWriter(`/tmp/${! meta("foo") }.out`, "lines", fileRotationConfig{}, mock.NewManager())
+	require.NoError(t, err)
+}
+
+func TestFileOutputBatching(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo.jsonl")
+
+	builder := service.NewEnvironment().NewStreamBuilder()
+	require.NoError(t, builder.SetYAML(fmt.Sprintf(`
+input:
+  generate:
+    count: 4
+    interval: ""
+    mapping: 'root.id = count("file_output_batching_test")'
+output:
+  file:
+    path: %v
+    codec: lines
+    batching:
+      count: 2
+      processors:
+        - archive:
+            format: json_array
+logger:
+  level: none
+`, path)))
+
+	strm, err := builder.Build()
+	require.NoError(t, err)
+
+	tCtx, done := context.WithTimeout(context.Background(), time.Minute)
+	defer done()
+	require.NoError(t, strm.Run(tCtx))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "[{\"id\":1},{\"id\":2}]\n[{\"id\":3},{\"id\":4}]\n", string(b))
+}
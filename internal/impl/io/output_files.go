@@ -0,0 +1,175 @@
+package io
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	filesOutputFieldPath        = "path"
+	filesOutputFieldPermissions = "permissions"
+	filesOutputFieldAtomic      = "atomic"
+	filesOutputFieldMaxInFlight = "max_in_flight"
+)
+
+func filesOutputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Local").
+		Version("4.18.0").
+		Summary(`Writes each message to its own file on disk at a path determined by an interpolated string.`).
+		Description(`Each message is written in full to a file at the resulting path of the `+"`path`"+` field, and if the file already exists its contents are replaced. Missing directories within the path are created automatically.
+
+When `+"`atomic`"+` is set to `+"`true`"+` each message is first written to a temporary file within the same directory as the target path, which is then renamed to the target path once the write has completed. This ensures that other processes observing the directory never see partially written files.`).
+		Fields(
+			service.NewInterpolatedStringField(filesOutputFieldPath).
+				Description("The path of the file to write each message to.").
+				Examples(
+					`/tmp/${! json("document.id") }.json`,
+					`/tmp/${! @kafka_topic }/${! @kafka_partition }-${! @kafka_offset }.txt`,
+				),
+			service.NewStringField(filesOutputFieldPermissions).
+				Description("The file permissions to apply to newly created files, expressed in octal notation.").
+				Examples("0600", "0644").
+				Default("0644").
+				Advanced(),
+			service.NewBoolField(filesOutputFieldAtomic).
+				Description("Whether to write each message to a temporary file before renaming it to the target path.").
+				Default(true),
+			service.NewIntField(filesOutputFieldMaxInFlight).
+				Description("The maximum number of messages to have in flight at a given time. Increase this to improve throughput.").
+				Default(64),
+		).
+		Example(
+			"Materialising documents",
+			"In this example we write each JSON document consumed to a file named after its ID, organised into directories by type.",
+			`
+output:
+  files:
+    path: /var/lib/documents/${! json("type") }/${! json("id") }.json
+    permissions: "0600"
+`,
+		)
+}
+
+func init() {
+	err := service.RegisterOutput("files", filesOutputSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.Output, maxInFlight int, err error) {
+			if maxInFlight, err = conf.FieldInt(filesOutputFieldMaxInFlight); err != nil {
+				return
+			}
+			out, err = newFilesOutputFromParsed(conf, mgr)
+			return
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type filesOutput struct {
+	path   *service.InterpolatedString
+	perm   fs.FileMode
+	atomic bool
+
+	fs *service.FS
+}
+
+func newFilesOutputFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*filesOutput, error) {
+	f := &filesOutput{fs: mgr.FS()}
+
+	var err error
+	if f.path, err = conf.FieldInterpolatedString(filesOutputFieldPath); err != nil {
+		return nil, err
+	}
+
+	var permStr string
+	if permStr, err = conf.FieldString(filesOutputFieldPermissions); err != nil {
+		return nil, err
+	}
+	perm, err := strconv.ParseUint(permStr, 8, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse permissions: %w", err)
+	}
+	f.perm = fs.FileMode(perm)
+
+	if f.atomic, err = conf.FieldBool(filesOutputFieldAtomic); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *filesOutput) Connect(ctx context.Context) error {
+	return nil
+}
+
+func (f *filesOutput) writeFile(path string, data []byte) error {
+	file, err := f.fs.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, f.perm)
+	if err != nil {
+		return err
+	}
+
+	w, ok := file.(io.Writer)
+	if !ok {
+		_ = file.Close()
+		return errors.New("failed to open file for writing")
+	}
+
+	_, err = w.Write(data)
+	if cErr := file.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+func (f *filesOutput) Write(ctx context.Context, msg *service.Message) error {
+	path, err := f.path.TryString(msg)
+	if err != nil {
+		return fmt.Errorf("path interpolation error: %w", err)
+	}
+	path = filepath.Clean(path)
+
+	data, err := msg.AsBytes()
+	if err != nil {
+		return err
+	}
+
+	if err := f.fs.MkdirAll(filepath.Dir(path), fs.FileMode(0o777)); err != nil {
+		return err
+	}
+
+	if !f.atomic {
+		return f.writeFile(path, data)
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	tmpPath := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+"."+id.String()+".tmp")
+	if err := f.writeFile(tmpPath, data); err != nil {
+		_ = f.fs.Remove(tmpPath)
+		return err
+	}
+	if err := f.fs.Rename(tmpPath, path); err != nil {
+		_ = f.fs.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+func (f *filesOutput) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,75 @@
+package io
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestFilesOutput(t *testing.T) {
+	for _, atomic := range []bool{true, false} {
+		atomic := atomic
+		t.Run(fmt.Sprintf("atomic %v", atomic), func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+
+			conf, err := filesOutputSpec().ParseYAML(fmt.Sprintf(`
+path: '%v/${! json("type") }/${! json("id") }.json'
+permissions: "0600"
+atomic: %v
+`, dir, atomic), nil)
+			require.NoError(t, err)
+
+			out, err := newFilesOutputFromParsed(conf, service.MockResources())
+			require.NoError(t, err)
+			require.NoError(t, out.Connect(ctx))
+
+			for _, s := range []string{
+				`{"type":"foo","id":"a","v":1}`,
+				`{"type":"foo","id":"b","v":2}`,
+				`{"type":"bar","id":"a","v":3}`,
+				`{"type":"foo","id":"a","v":4}`,
+			} {
+				require.NoError(t, out.Write(ctx, service.NewMessage([]byte(s))))
+			}
+			require.NoError(t, out.Close(ctx))
+
+			for file, exp := range map[string]string{
+				"foo/a.json": `{"type":"foo","id":"a","v":4}`,
+				"foo/b.json": `{"type":"foo","id":"b","v":2}`,
+				"bar/a.json": `{"type":"bar","id":"a","v":3}`,
+			} {
+				path := filepath.Join(dir, file)
+				b, err := os.ReadFile(path)
+				require.NoError(t, err, file)
+				assert.Equal(t, exp, string(b), file)
+
+				info, err := os.Stat(path)
+				require.NoError(t, err, file)
+				assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), file)
+			}
+
+			entries, err := os.ReadDir(filepath.Join(dir, "foo"))
+			require.NoError(t, err)
+			assert.Len(t, entries, 2)
+		})
+	}
+}
+
+func TestFilesOutputBadPermissions(t *testing.T) {
+	conf, err := filesOutputSpec().ParseYAML(`
+path: /tmp/foo
+permissions: nope
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newFilesOutputFromParsed(conf, service.MockResources())
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/codec"
@@ -23,12 +24,27 @@ func init() {
 	}), docs.ComponentSpec{
 		Name:    "socket",
 		Summary: `Connects to a (tcp/udp/unix) server and sends a continuous stream of data, dividing messages according to the specified codec.`,
+		Description: `
+If the connection is lost, or a write fails, the connection is closed and reestablished with an exponential backoff, and the failed messages are reattempted. A ` + "`write_timeout`" + ` can be specified in order to abandon writes to a server that has stopped reading data, which would otherwise block indefinitely.
+
+This output can be used in order to feed legacy collectors, for example metrics can be written to a Graphite server with the plaintext protocol:
+
+` + "```yaml" + `
+output:
+  socket:
+    network: tcp
+    address: graphite:2003
+    codec: lines
+  processors:
+    - mapping: 'root = "%s %v %v".format(this.path, this.value, this.timestamp)'
+` + "```" + ``,
 		Config: docs.FieldComponent().WithChildren(
 			docs.FieldString("network", "The network type to connect as.").HasOptions(
 				"unix", "tcp", "udp",
 			),
 			docs.FieldString("address", "The address (or path) to connect to.", "/tmp/benthos.sock", "localhost:9000"),
 			codec.WriterDocs,
+			docs.FieldString("write_timeout", "An optional maximum period to wait for each message to be written before the connection is closed and reestablished.", "5s").Advanced().AtVersion("4.18.0"),
 		).ChildDefaultAndTypesFromStruct(output.NewSocketConfig()),
 		Categories: []string{
 			"Network",
@@ -48,13 +64,15 @@ func newSocketOutput(conf output.Config, mgr bundle.NewManagement, log log.Modul
 }
 
 type socketWriter struct {
-	network   string
-	address   string
-	codec     codec.WriterConstructor
-	codecConf codec.WriterConfig
+	network      string
+	address      string
+	codec        codec.WriterConstructor
+	codecConf    codec.WriterConfig
+	writeTimeout time.Duration
 
 	log log.Modular
 
+	conn      net.Conn
 	writer    codec.Writer
 	writerMut sync.Mutex
 }
@@ -76,6 +94,11 @@ func newSocketWriter(conf output.SocketConfig, mgr bundle.NewManagement, log log
 		codecConf: codecConf,
 		log:       log,
 	}
+	if conf.WriteTimeout != "" {
+		if t.writeTimeout, err = time.ParseDuration(conf.WriteTimeout); err != nil {
+			return nil, fmt.Errorf("failed to parse write_timeout: %w", err)
+		}
+	}
 	return &t, nil
 }
 
@@ -86,7 +109,8 @@ func (s *socketWriter) Connect(ctx context.Context) error {
 		return nil
 	}
 
-	conn, err := net.Dial(s.network, s.address)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
 	if err != nil {
 		return err
 	}
@@ -96,6 +120,7 @@ func (s *socketWriter) Connect(ctx context.Context) error {
 		conn.Close()
 		return err
 	}
+	s.conn = conn
 
 	s.log.Infof("Sending messages over %v socket to: %s\n", s.network, s.address)
 	return nil
@@ -103,7 +128,7 @@ func (s *socketWriter) Connect(ctx context.Context) error {
 
 func (s *socketWriter) WriteBatch(ctx context.Context, msg message.Batch) error {
 	s.writerMut.Lock()
-	w := s.writer
+	w, conn := s.writer, s.conn
 	s.writerMut.Unlock()
 
 	if w == nil {
@@ -111,11 +136,17 @@ func (s *socketWriter) WriteBatch(ctx context.Context, msg message.Batch) error
 	}
 
 	return msg.Iter(func(i int, part *message.Part) error {
+		if s.writeTimeout > 0 {
+			_ = conn.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+		}
 		serr := w.Write(ctx, part)
 		if serr != nil || s.codecConf.CloseAfter {
 			s.writerMut.Lock()
-			s.writer.Close(ctx)
-			s.writer = nil
+			if s.writer == w {
+				s.writer.Close(ctx)
+				s.writer = nil
+				s.conn = nil
+			}
 			s.writerMut.Unlock()
 		}
 		return serr
@@ -130,6 +161,7 @@ func (s *socketWriter) Close(ctx context.Context) error {
 	if s.writer != nil {
 		err = s.writer.Close(context.Background())
 		s.writer = nil
+		s.conn = nil
 	}
 	return err
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
@@ -486,3 +487,56 @@ func TestSocketCustomDelimeter(t *testing.T) {
 
 	conn.Close()
 }
+
+func TestSocketWriteTimeout(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	ln, err := net.Listen("unix", filepath.Join(t.TempDir(), "benthos.sock"))
+	require.NoError(t, err)
+	defer ln.Close()
+
+	conf := output.NewSocketConfig()
+	conf.Network = ln.Addr().Network()
+	conf.Address = ln.Addr().String()
+	conf.WriteTimeout = "100ms"
+
+	wtr, err := newSocketWriter(conf, mock.NewManager(), log.Noop())
+	require.NoError(t, err)
+	defer func() {
+		_ = wtr.Close(ctx)
+	}()
+
+	go func() {
+		if cerr := wtr.Connect(ctx); cerr != nil {
+			t.Error(cerr)
+		}
+	}()
+
+	// Accept the connection but never read from it, writes must eventually
+	// block once the socket buffers are full.
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	payload := bytes.Repeat([]byte("a"), 1024*1024)
+	for i := 0; i < 64; i++ {
+		if err = wtr.WriteBatch(ctx, message.QuickBatch([][]byte{payload})); err != nil {
+			break
+		}
+	}
+	require.Error(t, err)
+
+	err = wtr.WriteBatch(ctx, message.QuickBatch([][]byte{[]byte("foo")}))
+	require.Equal(t, component.ErrNotConnected, err)
+}
+
+func TestSocketBadWriteTimeout(t *testing.T) {
+	conf := output.NewSocketConfig()
+	conf.Network = "tcp"
+	conf.Address = "localhost:1234"
+	conf.WriteTimeout = "nope"
+
+	_, err := newSocketWriter(conf, mock.NewManager(), log.Noop())
+	require.Error(t, err)
+}
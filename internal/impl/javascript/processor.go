@@ -51,6 +51,10 @@ In order to optimise code execution JS runtimes are created on demand (in order
 
 Although technically possible, it is recommended that you do not rely on the global state for maintaining state across invocations as the pooling nature of the runtimes will prevent deterministic behaviour. We aim to support deterministic strategies for mutating global state in the future.
 
+## Error Handling
+
+When a program throws an uncaught exception for a message that message is flagged as having failed, with the error message being the exception, and the remaining messages of the batch continue to be processed. Failed messages can be handled using the [standard error handling patterns](/docs/configuration/error_handling).
+
 ## Functions
 `+description.String()+`
 `).
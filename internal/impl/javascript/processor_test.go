@@ -254,6 +254,50 @@ code: |
 	require.NoError(t, proc.Close(bCtx))
 }
 
+func TestProcessorUncaughtError(t *testing.T) {
+	conf, err := javascriptProcessorConfig().ParseYAML(`
+code: |
+  (() => {
+    let thing = benthos.v0_msg_as_structured();
+    thing.seen = true;
+    benthos.v0_msg_set_structured(thing);
+  })();
+`, nil)
+	require.NoError(t, err)
+
+	proc, err := newJavascriptProcessorFromConfig(conf, service.MockResources())
+	require.NoError(t, err)
+
+	bCtx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	resBatches, err := proc.ProcessBatch(bCtx, service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"first"}`)),
+		service.NewMessage([]byte(`not a structured message`)),
+		service.NewMessage([]byte(`{"id":"third"}`)),
+	})
+	require.NoError(t, err)
+	require.Len(t, resBatches, 1)
+	require.Len(t, resBatches[0], 3)
+
+	resBytes, err := resBatches[0][0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"first","seen":true}`, string(resBytes))
+	assert.NoError(t, resBatches[0][0].GetError())
+
+	resBytes, err = resBatches[0][1].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `not a structured message`, string(resBytes))
+	assert.Error(t, resBatches[0][1].GetError())
+
+	resBytes, err = resBatches[0][2].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"third","seen":true}`, string(resBytes))
+	assert.NoError(t, resBatches[0][2].GetError())
+
+	require.NoError(t, proc.Close(bCtx))
+}
+
 func TestProcessorBasicFromFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	require.NoError(t, os.WriteFile(path.Join(tmpDir, "foo.js"), []byte(`
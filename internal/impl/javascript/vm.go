@@ -130,10 +130,11 @@ func (r *vmRunner) Run(ctx context.Context, batch service.MessageBatch) (service
 		r.targetIndex = i
 		r.targetMessage = batch[i]
 
-		_, err := r.vm.RunProgram(r.p)
-		if err != nil {
-			// TODO: Make this more granular, error could be message specific
-			return nil, err
+		if _, err := r.vm.RunProgram(r.p); err != nil {
+			r.logger.Debugf("Program failed: %v", err)
+			batch[i].SetError(err)
+			newBatch = append(newBatch, batch[i])
+			continue
 		}
 		if newMsg := r.targetMessage; newMsg != nil {
 			newBatch = append(newBatch, newMsg)
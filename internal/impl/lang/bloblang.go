@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/snowflake"
@@ -93,7 +94,7 @@ func init() {
 
 	snowflakeidSpec := bloblang.NewPluginSpec().
 		Category(query.FunctionCategoryGeneral).
-		Description("Generate a new snowflake ID each time it is invoked and prints a string representation. I.e.: 1559229974454472704").
+		Description("Generate a new snowflake ID each time it is invoked and prints a string representation. I.e.: 1559229974454472704. All invocations with the same `node_id` within a Benthos process share a sequence, and therefore produce unique IDs even when invoked from parallel processing threads. When running multiple Benthos processes ensure that each uses a distinct `node_id`.").
 		Param(bloblang.NewInt64Param("node_id").Description("It is possible to specify the node_id.").Default(int64(1))).
 		Example("", `root.id = snowflake_id()`).
 		Example("It is possible to specify the node_id.", `root.id = snowflake_id(2)`)
@@ -105,7 +106,7 @@ func init() {
 			if err != nil {
 				return nil, err
 			}
-			node, err := snowflake.NewNode(nodeID)
+			node, err := getSnowflakeNode(nodeID)
 			if err != nil {
 				return nil, err
 			}
@@ -122,6 +123,29 @@ func init() {
 	}
 }
 
+var (
+	snowflakeNodes    = map[int64]*snowflake.Node{}
+	snowflakeNodesMut sync.Mutex
+)
+
+// getSnowflakeNode returns a node shared by all snowflake_id invocations of a
+// given node ID, as separate nodes of the same ID would generate duplicate IDs
+// within the same millisecond.
+func getSnowflakeNode(nodeID int64) (*snowflake.Node, error) {
+	snowflakeNodesMut.Lock()
+	defer snowflakeNodesMut.Unlock()
+
+	if node, exists := snowflakeNodes[nodeID]; exists {
+		return node, nil
+	}
+	node, err := snowflake.NewNode(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	snowflakeNodes[nodeID] = node
+	return node, nil
+}
+
 // GetFakeValue returns fake data generated by the faker function corresponding to the input string.
 func GetFakeValue(function string) (any, error) {
 	switch strings.ToLower(function) {
@@ -97,3 +97,24 @@ func TestULID_BadRandom(t *testing.T) {
 	require.ErrorContains(t, err, "invalid randomness source: not-very-random")
 	require.Nil(t, ex, "did not expect an executable mapping")
 }
+
+func TestSnowflakeIDUniqueAcrossMappings(t *testing.T) {
+	exA, err := bloblang.Parse(`root = snowflake_id()`)
+	require.NoError(t, err)
+
+	exB, err := bloblang.Parse(`root = snowflake_id()`)
+	require.NoError(t, err)
+
+	seen := map[string]struct{}{}
+	for i := 0; i < 1000; i++ {
+		for _, ex := range []*bloblang.Executor{exA, exB} {
+			res, err := ex.Query(nil)
+			require.NoError(t, err)
+
+			id := res.(string)
+			_, exists := seen[id]
+			require.False(t, exists, "duplicate snowflake id: %v", id)
+			seen[id] = struct{}{}
+		}
+	}
+}
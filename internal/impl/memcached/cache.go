@@ -110,12 +110,31 @@ func newMemcachedCache(
 	}, nil
 }
 
+// memcachedMaxRelativeTTL is the longest expiration that memcached interprets
+// as a number of seconds from now, longer expirations must be given as a unix
+// timestamp.
+const memcachedMaxRelativeTTL = time.Hour * 24 * 30
+
+func memcachedExpiration(ttl time.Duration) int32 {
+	if ttl <= 0 {
+		return 0
+	}
+	if ttl > memcachedMaxRelativeTTL {
+		return int32(time.Now().Add(ttl).Unix())
+	}
+	if ttl < time.Second {
+		// An expiration of zero means the item never expires.
+		return 1
+	}
+	return int32(ttl / time.Second)
+}
+
 func (m *memcachedCache) getItemFor(key string, value []byte, ttl *time.Duration) *memcache.Item {
 	var expiration int32
 	if ttl != nil {
-		expiration = int32(ttl.Milliseconds() / 1000)
+		expiration = memcachedExpiration(*ttl)
 	} else {
-		expiration = int32(m.defaultTTL.Milliseconds() / 1000)
+		expiration = memcachedExpiration(m.defaultTTL)
 	}
 	return &memcache.Item{
 		Key:        m.prefix + key,
@@ -217,7 +236,7 @@ func (m *memcachedCache) Delete(ctx context.Context, key string) error {
 
 	for {
 		err := m.mc.Delete(m.prefix + key)
-		if errors.Is(err, memcache.ErrCacheMiss) {
+		if err == nil || errors.Is(err, memcache.ErrCacheMiss) {
 			return nil
 		}
 
@@ -234,5 +253,5 @@ func (m *memcachedCache) Delete(ctx context.Context, key string) error {
 }
 
 func (m *memcachedCache) Close(ctx context.Context) error {
-	return nil
+	return m.mc.Close()
 }
@@ -0,0 +1,18 @@
+package memcached
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemcachedExpiration(t *testing.T) {
+	assert.Equal(t, int32(0), memcachedExpiration(0))
+	assert.Equal(t, int32(1), memcachedExpiration(time.Millisecond*100))
+	assert.Equal(t, int32(300), memcachedExpiration(time.Minute*5))
+	assert.Equal(t, int32(2592000), memcachedExpiration(time.Hour*24*30))
+
+	exp := time.Now().Add(time.Hour * 24 * 60).Unix()
+	assert.InDelta(t, exp, int64(memcachedExpiration(time.Hour*24*60)), 1)
+}
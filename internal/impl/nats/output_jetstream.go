@@ -3,6 +3,7 @@ package nats
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"strings"
 	"sync"
 
@@ -36,6 +37,12 @@ func natsJetStreamOutputConfig() *service.ConfigSpec {
 				"Content-Type": "application/json",
 				"Timestamp":    `${!meta("Timestamp")}`,
 			}).Version("4.1.0")).
+		Field(service.NewInterpolatedStringField("msg_id").
+			Description("An optional message ID to set as the `Nats-Msg-Id` header of each message, which JetStream uses in order to detect and discard duplicate messages published within the duplicate window of the stream. Each message is published with acknowledgement from the server, and therefore a message is only considered delivered once it has been persisted.").
+			Example(`${! json("id") }`).
+			Example(`${! meta("kafka_topic") }-${! meta("kafka_partition") }-${! meta("kafka_offset") }`).
+			Optional().
+			Version("4.18.0")).
 		Field(service.NewIntField("max_in_flight").
 			Description("The maximum number of messages to have in flight at a given time. Increase this to improve throughput.").
 			Default(1024)).
@@ -67,6 +74,7 @@ type jetStreamOutput struct {
 	subjectStrRaw string
 	subjectStr    *service.InterpolatedString
 	headers       map[string]*service.InterpolatedString
+	msgID         *service.InterpolatedString
 	authConf      auth.Config
 	tlsConf       *tls.Config
 
@@ -106,6 +114,12 @@ func newJetStreamWriterFromConfig(conf *service.ParsedConfig, mgr *service.Resou
 		return nil, err
 	}
 
+	if conf.Contains("msg_id") {
+		if j.msgID, err = conf.FieldInterpolatedString("msg_id"); err != nil {
+			return nil, err
+		}
+	}
+
 	tlsConf, tlsEnabled, err := conf.FieldTLSToggled("tls")
 	if err != nil {
 		return nil, err
@@ -183,7 +197,12 @@ func (j *jetStreamOutput) Write(ctx context.Context, msg *service.Message) error
 		return service.ErrNotConnected
 	}
 
-	jsmsg := nats.NewMsg(j.subjectStr.String(msg))
+	subject, err := j.subjectStr.TryString(msg)
+	if err != nil {
+		return fmt.Errorf("subject interpolation error: %w", err)
+	}
+
+	jsmsg := nats.NewMsg(subject)
 	msgBytes, err := msg.AsBytes()
 	if err != nil {
 		return err
@@ -193,7 +212,18 @@ func (j *jetStreamOutput) Write(ctx context.Context, msg *service.Message) error
 		jsmsg.Header.Add(k, v.String(msg))
 	}
 
-	_, err = jCtx.PublishMsg(jsmsg)
+	opts := []nats.PubOpt{nats.Context(ctx)}
+	if j.msgID != nil {
+		msgID, err := j.msgID.TryString(msg)
+		if err != nil {
+			return fmt.Errorf("msg_id interpolation error: %w", err)
+		}
+		if msgID != "" {
+			opts = append(opts, nats.MsgId(msgID))
+		}
+	}
+
+	_, err = jCtx.PublishMsg(jsmsg, opts...)
 	return err
 }
 
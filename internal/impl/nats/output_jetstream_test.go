@@ -20,6 +20,7 @@ subject: testsubject
 headers:
   Content-Type: application/json
   Timestamp: ${!meta("Timestamp")}
+msg_id: ${! json("id") }
 auth:
   nkey_file: test auth n key file
   user_credentials_file: test auth user creds file
@@ -33,12 +34,13 @@ auth:
 		e, err := newJetStreamWriterFromConfig(conf, service.MockResources())
 		require.NoError(t, err)
 
-		msg := service.NewMessage((nil))
+		msg := service.NewMessage([]byte(`{"id":"foo"}`))
 		msg.MetaSet("Timestamp", "1651485106")
 		assert.Equal(t, "url1,url2", e.urls)
 		assert.Equal(t, "testsubject", e.subjectStr.String(msg))
 		assert.Equal(t, "application/json", e.headers["Content-Type"].String(msg))
 		assert.Equal(t, "1651485106", e.headers["Timestamp"].String(msg))
+		assert.Equal(t, "foo", e.msgID.String(msg))
 		assert.Equal(t, "test auth n key file", e.authConf.NKeyFile)
 		assert.Equal(t, "test auth user creds file", e.authConf.UserCredentialsFile)
 		assert.Equal(t, "test auth inline user JWT", e.authConf.UserJWT)
@@ -0,0 +1,549 @@
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/benthosdev/benthos/v4/internal/cli"
+	"github.com/benthosdev/benthos/v4/internal/tracing"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	omFieldHTTP             = "http"
+	omFieldGRPC             = "grpc"
+	omFieldTags             = "tags"
+	omFieldFlushPeriod      = "flush_period"
+	omFieldHistogramBuckets = "histogram_buckets"
+)
+
+func otlpMetricsSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.18.0").
+		Summary("Send metrics to an [Open Telemetry collector](https://opentelemetry.io/docs/collector/) using the OTLP protocol.").
+		Description(`
+Metrics are aggregated in memory and exported to each configured collector once every `+"`flush_period`"+`. Counters are exported as cumulative monotonic sums, gauges as gauges, and timing metrics as cumulative histograms of durations in seconds. Since a metric name can only have one type, series that share a name with a metric of a different type, which can happen when metrics are renamed with a mapping, are logged and skipped.
+
+This exporter can be combined with the `+"[`open_telemetry_collector` tracer](/docs/components/tracers/open_telemetry_collector)"+` in order to ship both the metrics and the tracing spans of a pipeline to the same collector.`).
+		Fields(
+			service.NewObjectListField(omFieldHTTP,
+				service.NewURLField("url").
+					Description("The URL of a collector to send metrics to.").
+					Default("localhost:4318"),
+			).Description("A list of http collectors.").Default([]any{}),
+			service.NewObjectListField(omFieldGRPC,
+				service.NewURLField("url").
+					Description("The URL of a collector to send metrics to.").
+					Default("localhost:4317"),
+			).Description("A list of grpc collectors.").Default([]any{}),
+			service.NewStringMapField(omFieldTags).
+				Description("A map of tags to add to the resource of all exported metrics.").
+				Default(map[string]string{}).
+				Advanced(),
+			service.NewDurationField(omFieldFlushPeriod).
+				Description("The period of time between each export of metrics.").
+				Default("10s").
+				Advanced(),
+			service.NewFloatListField(omFieldHistogramBuckets).
+				Description("The explicit bucket boundaries, in seconds, of histograms exported for timing metrics.").
+				Default([]any{0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1.0, 5.0, 10.0}).
+				Advanced(),
+		).
+		Example(
+			"Metrics and Tracing",
+			"Here we send both the metrics and the tracing spans of a pipeline to a local collector over gRPC:",
+			`
+metrics:
+  open_telemetry_collector:
+    grpc:
+      - url: localhost:4317
+
+tracer:
+  open_telemetry_collector:
+    grpc:
+      - url: localhost:4317
+`,
+		)
+}
+
+func init() {
+	err := service.RegisterMetricsExporter(
+		"open_telemetry_collector", otlpMetricsSpec(),
+		func(conf *service.ParsedConfig, log *service.Logger) (service.MetricsExporter, error) {
+			return newOtlpMetricsFromConfig(conf, log)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newOtlpMetricsFromConfig(conf *service.ParsedConfig, log *service.Logger) (*otlpMetrics, error) {
+	c, err := newOtlpConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	flushPeriod, err := conf.FieldDuration(omFieldFlushPeriod)
+	if err != nil {
+		return nil, err
+	}
+	if flushPeriod <= 0 {
+		return nil, fmt.Errorf("flush_period must be greater than zero, got %v", flushPeriod)
+	}
+	buckets, err := conf.FieldFloatList(omFieldHistogramBuckets)
+	if err != nil {
+		return nil, err
+	}
+	if !sort.Float64sAreSorted(buckets) {
+		return nil, errors.New("histogram_buckets must be in ascending order")
+	}
+	return newOtlpMetrics(c, flushPeriod, buckets, log)
+}
+
+//------------------------------------------------------------------------------
+
+type otlpMetricsClient interface {
+	export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error
+	close() error
+}
+
+type otlpMetricsHTTPClient struct {
+	url    string
+	client *http.Client
+}
+
+func (h *otlpMetricsHTTPClient) export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	hReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	hReq.Header.Set("Content-Type", "application/x-protobuf")
+
+	res, err := h.client.Do(hReq)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("collector %v returned unexpected status code: %v", h.url, res.StatusCode)
+	}
+	return nil
+}
+
+func (h *otlpMetricsHTTPClient) close() error {
+	h.client.CloseIdleConnections()
+	return nil
+}
+
+type otlpMetricsGRPCClient struct {
+	conn   *grpc.ClientConn
+	client colmetricspb.MetricsServiceClient
+}
+
+func (g *otlpMetricsGRPCClient) export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	_, err := g.client.Export(ctx, req)
+	return err
+}
+
+func (g *otlpMetricsGRPCClient) close() error {
+	return g.conn.Close()
+}
+
+// Collector URLs of http clients follow the convention of the tracer and are
+// typically a host and port, in which case the scheme and the default path of
+// the metrics endpoint are added.
+func otlpMetricsHTTPURL(u string) string {
+	if !strings.Contains(u, "://") {
+		u = "http://" + u
+	}
+	if i := strings.Index(u, "://"); !strings.Contains(u[i+3:], "/") {
+		u += "/v1/metrics"
+	}
+	return u
+}
+
+//------------------------------------------------------------------------------
+
+type otlpHistogram struct {
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+type otlpSeries struct {
+	name       string
+	attributes []*commonpb.KeyValue
+
+	value int64
+	hist  *otlpHistogram
+}
+
+type otlpMetrics struct {
+	log         *service.Logger
+	resource    *resourcepb.Resource
+	clients     []otlpMetricsClient
+	flushPeriod time.Duration
+	buckets     []float64
+	startTime   time.Time
+
+	mut      sync.Mutex
+	counters map[string]*otlpSeries
+	gauges   map[string]*otlpSeries
+	timers   map[string]*otlpSeries
+
+	// Names of metrics that have been skipped due to sharing a name with a
+	// metric of a different type, so that each conflict is only logged once.
+	conflicts map[string]struct{}
+
+	ctx      context.Context
+	cancel   func()
+	loopDone chan struct{}
+}
+
+func newOtlpMetrics(config *otlp, flushPeriod time.Duration, buckets []float64, log *service.Logger) (*otlpMetrics, error) {
+	o := &otlpMetrics{
+		log:         log,
+		flushPeriod: flushPeriod,
+		buckets:     buckets,
+		startTime:   time.Now(),
+		counters:    map[string]*otlpSeries{},
+		gauges:      map[string]*otlpSeries{},
+		timers:      map[string]*otlpSeries{},
+		conflicts:   map[string]struct{}{},
+		loopDone:    make(chan struct{}),
+	}
+
+	tags := tracing.ServiceTags(config.tags, cli.Version)
+	o.resource = &resourcepb.Resource{Attributes: otlpAttributes(tags)}
+
+	for _, c := range config.http {
+		o.clients = append(o.clients, &otlpMetricsHTTPClient{
+			url:    otlpMetricsHTTPURL(c.url),
+			client: &http.Client{Timeout: time.Second * 30},
+		})
+	}
+	for _, c := range config.grpc {
+		conn, err := grpc.Dial(c.url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			_ = o.closeClients()
+			return nil, err
+		}
+		o.clients = append(o.clients, &otlpMetricsGRPCClient{
+			conn:   conn,
+			client: colmetricspb.NewMetricsServiceClient(conn),
+		})
+	}
+
+	o.ctx, o.cancel = context.WithCancel(context.Background())
+	go o.loop()
+	return o, nil
+}
+
+func otlpAttributes(tags map[string]string) []*commonpb.KeyValue {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]*commonpb.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, &commonpb.KeyValue{
+			Key: k,
+			Value: &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_StringValue{StringValue: tags[k]},
+			},
+		})
+	}
+	return attrs
+}
+
+func (o *otlpMetrics) series(m map[string]*otlpSeries, name string, labelKeys, labelValues []string) *otlpSeries {
+	id := name + fmt.Sprintf("%q", labelValues)
+
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	if s, exists := m[id]; exists {
+		return s
+	}
+
+	var attrs []*commonpb.KeyValue
+	for i, k := range labelKeys {
+		if i >= len(labelValues) {
+			break
+		}
+		attrs = append(attrs, &commonpb.KeyValue{
+			Key: k,
+			Value: &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_StringValue{StringValue: labelValues[i]},
+			},
+		})
+	}
+	s := &otlpSeries{name: name, attributes: attrs}
+	m[id] = s
+	return s
+}
+
+//------------------------------------------------------------------------------
+
+type otlpCounter struct {
+	root *otlpMetrics
+	s    *otlpSeries
+}
+
+func (c *otlpCounter) Incr(count int64) {
+	c.root.mut.Lock()
+	c.s.value += count
+	c.root.mut.Unlock()
+}
+
+type otlpGauge struct {
+	root *otlpMetrics
+	s    *otlpSeries
+}
+
+func (g *otlpGauge) Set(value int64) {
+	g.root.mut.Lock()
+	g.s.value = value
+	g.root.mut.Unlock()
+}
+
+type otlpTimer struct {
+	root *otlpMetrics
+	s    *otlpSeries
+}
+
+func (t *otlpTimer) Timing(delta int64) {
+	seconds := float64(delta) / float64(time.Second)
+	index := sort.SearchFloat64s(t.root.buckets, seconds)
+
+	t.root.mut.Lock()
+	t.s.hist.count++
+	t.s.hist.sum += seconds
+	t.s.hist.buckets[index]++
+	t.root.mut.Unlock()
+}
+
+func (o *otlpMetrics) NewCounterCtor(name string, labelKeys ...string) service.MetricsExporterCounterCtor {
+	return func(labelValues ...string) service.MetricsExporterCounter {
+		return &otlpCounter{root: o, s: o.series(o.counters, name, labelKeys, labelValues)}
+	}
+}
+
+func (o *otlpMetrics) NewTimerCtor(name string, labelKeys ...string) service.MetricsExporterTimerCtor {
+	return func(labelValues ...string) service.MetricsExporterTimer {
+		s := o.series(o.timers, name, labelKeys, labelValues)
+		o.mut.Lock()
+		if s.hist == nil {
+			s.hist = &otlpHistogram{buckets: make([]uint64, len(o.buckets)+1)}
+		}
+		o.mut.Unlock()
+		return &otlpTimer{root: o, s: s}
+	}
+}
+
+func (o *otlpMetrics) NewGaugeCtor(name string, labelKeys ...string) service.MetricsExporterGaugeCtor {
+	return func(labelValues ...string) service.MetricsExporterGauge {
+		return &otlpGauge{root: o, s: o.series(o.gauges, name, labelKeys, labelValues)}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func sortedSeries(m map[string]*otlpSeries) []*otlpSeries {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	series := make([]*otlpSeries, 0, len(ids))
+	for _, id := range ids {
+		series = append(series, m[id])
+	}
+	return series
+}
+
+// conflict logs that series of a metric type are being skipped as their name is
+// already used by a metric of a different type, which is only logged once for
+// each name.
+func (o *otlpMetrics) conflict(name, typeStr string) {
+	if _, exists := o.conflicts[name]; exists {
+		return
+	}
+	o.conflicts[name] = struct{}{}
+	o.log.Errorf("Skipping %v metric '%v' as its name is already used by a metric of a different type", typeStr, name)
+}
+
+// request builds an export request from a snapshot of all metrics, where data
+// points that share a metric name are grouped into the same metric. Series
+// that share a name with a metric of a different type are skipped.
+func (o *otlpMetrics) request() *colmetricspb.ExportMetricsServiceRequest {
+	start, now := uint64(o.startTime.UnixNano()), uint64(time.Now().UnixNano())
+
+	var metrics []*metricspb.Metric
+	byName := map[string]*metricspb.Metric{}
+
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	for _, s := range sortedSeries(o.counters) {
+		m, exists := byName[s.name]
+		if !exists {
+			m = &metricspb.Metric{
+				Name: s.name,
+				Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+					AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+					IsMonotonic:            true,
+				}},
+			}
+			byName[s.name] = m
+			metrics = append(metrics, m)
+		}
+		sum := m.GetSum()
+		sum.DataPoints = append(sum.DataPoints, &metricspb.NumberDataPoint{
+			Attributes:        s.attributes,
+			StartTimeUnixNano: start,
+			TimeUnixNano:      now,
+			Value:             &metricspb.NumberDataPoint_AsInt{AsInt: s.value},
+		})
+	}
+
+	for _, s := range sortedSeries(o.gauges) {
+		m, exists := byName[s.name]
+		if !exists {
+			m = &metricspb.Metric{
+				Name: s.name,
+				Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{}},
+			}
+			byName[s.name] = m
+			metrics = append(metrics, m)
+		}
+		gauge := m.GetGauge()
+		if gauge == nil {
+			o.conflict(s.name, "gauge")
+			continue
+		}
+		gauge.DataPoints = append(gauge.DataPoints, &metricspb.NumberDataPoint{
+			Attributes:   s.attributes,
+			TimeUnixNano: now,
+			Value:        &metricspb.NumberDataPoint_AsInt{AsInt: s.value},
+		})
+	}
+
+	for _, s := range sortedSeries(o.timers) {
+		m, exists := byName[s.name]
+		if !exists {
+			m = &metricspb.Metric{
+				Name: s.name,
+				Unit: "s",
+				Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+					AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				}},
+			}
+			byName[s.name] = m
+			metrics = append(metrics, m)
+		}
+		hist := m.GetHistogram()
+		if hist == nil {
+			o.conflict(s.name, "timer")
+			continue
+		}
+		sum := s.hist.sum
+		hist.DataPoints = append(hist.DataPoints, &metricspb.HistogramDataPoint{
+			Attributes:        s.attributes,
+			StartTimeUnixNano: start,
+			TimeUnixNano:      now,
+			Count:             s.hist.count,
+			Sum:               &sum,
+			BucketCounts:      append([]uint64(nil), s.hist.buckets...),
+			ExplicitBounds:    o.buckets,
+		})
+	}
+
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: o.resource,
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope: &commonpb.InstrumentationScope{
+					Name:    "benthos",
+					Version: cli.Version,
+				},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+func (o *otlpMetrics) flush(ctx context.Context) {
+	req := o.request()
+	if len(req.ResourceMetrics[0].ScopeMetrics[0].Metrics) == 0 {
+		return
+	}
+	for _, c := range o.clients {
+		if err := c.export(ctx, req); err != nil {
+			o.log.Errorf("Failed to export metrics: %v", err)
+		}
+	}
+}
+
+func (o *otlpMetrics) loop() {
+	defer close(o.loopDone)
+
+	ticker := time.NewTicker(o.flushPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+		case <-ticker.C:
+			o.flush(o.ctx)
+		}
+	}
+}
+
+func (o *otlpMetrics) closeClients() error {
+	var err error
+	for _, c := range o.clients {
+		if cErr := c.close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+	return err
+}
+
+func (o *otlpMetrics) Close(ctx context.Context) error {
+	o.cancel()
+	select {
+	case <-o.loopDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// Export any metrics recorded since the last flush.
+	o.flush(ctx)
+	return o.closeClients()
+}
@@ -0,0 +1,152 @@
+package otlp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestOtlpMetricsHTTPURL(t *testing.T) {
+	for in, exp := range map[string]string{
+		"localhost:4318":                    "http://localhost:4318/v1/metrics",
+		"http://localhost:4318":             "http://localhost:4318/v1/metrics",
+		"https://example.com/otlp/v1/stats": "https://example.com/otlp/v1/stats",
+	} {
+		assert.Equal(t, exp, otlpMetricsHTTPURL(in), in)
+	}
+}
+
+func TestOtlpMetricsHTTPExport(t *testing.T) {
+	reqChan := make(chan *colmetricspb.ExportMetricsServiceRequest, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var req colmetricspb.ExportMetricsServiceRequest
+		require.NoError(t, proto.Unmarshal(body, &req))
+		reqChan <- &req
+	}))
+	defer ts.Close()
+
+	pConf, err := otlpMetricsSpec().ParseYAML(`
+http:
+  - url: `+strings.TrimPrefix(ts.URL, "http://")+`
+tags:
+  service.name: foo
+flush_period: 1h
+histogram_buckets: [ 0.1, 1 ]
+`, nil)
+	require.NoError(t, err)
+
+	o, err := newOtlpMetricsFromConfig(pConf, service.MockResources().Logger())
+	require.NoError(t, err)
+
+	o.NewCounterCtor("counter_one", "label")("a").Incr(2)
+	o.NewCounterCtor("counter_one", "label")("a").Incr(3)
+	o.NewCounterCtor("counter_one", "label")("b").Incr(1)
+	o.NewGaugeCtor("gauge_one")().Set(7)
+	timer := o.NewTimerCtor("timer_one")()
+	timer.Timing(int64(time.Millisecond * 50))
+	timer.Timing(int64(time.Second * 2))
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+	require.NoError(t, o.Close(ctx))
+
+	var req *colmetricspb.ExportMetricsServiceRequest
+	select {
+	case req = <-reqChan:
+	default:
+		t.Fatal("expected an export request on close")
+	}
+
+	require.Len(t, req.ResourceMetrics, 1)
+	resAttrs := map[string]string{}
+	for _, kv := range req.ResourceMetrics[0].Resource.Attributes {
+		resAttrs[kv.Key] = kv.Value.GetStringValue()
+	}
+	assert.Equal(t, map[string]string{"service.name": "foo"}, resAttrs)
+
+	metrics := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 3)
+
+	assert.Equal(t, "counter_one", metrics[0].Name)
+	sum := metrics[0].GetSum()
+	require.NotNil(t, sum)
+	assert.True(t, sum.IsMonotonic)
+	require.Len(t, sum.DataPoints, 2)
+	assert.Equal(t, "a", sum.DataPoints[0].Attributes[0].Value.GetStringValue())
+	assert.Equal(t, int64(5), sum.DataPoints[0].GetAsInt())
+	assert.Equal(t, "b", sum.DataPoints[1].Attributes[0].Value.GetStringValue())
+	assert.Equal(t, int64(1), sum.DataPoints[1].GetAsInt())
+
+	assert.Equal(t, "gauge_one", metrics[1].Name)
+	gauge := metrics[1].GetGauge()
+	require.NotNil(t, gauge)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, int64(7), gauge.DataPoints[0].GetAsInt())
+
+	assert.Equal(t, "timer_one", metrics[2].Name)
+	hist := metrics[2].GetHistogram()
+	require.NotNil(t, hist)
+	require.Len(t, hist.DataPoints, 1)
+	assert.Equal(t, uint64(2), hist.DataPoints[0].Count)
+	assert.InDelta(t, 2.05, hist.DataPoints[0].GetSum(), 0.0001)
+	assert.Equal(t, []float64{0.1, 1}, hist.DataPoints[0].ExplicitBounds)
+	assert.Equal(t, []uint64{1, 0, 1}, hist.DataPoints[0].BucketCounts)
+}
+
+func TestOtlpMetricsNameTypeConflict(t *testing.T) {
+	pConf, err := otlpMetricsSpec().ParseYAML(`
+flush_period: 1h
+`, nil)
+	require.NoError(t, err)
+
+	o, err := newOtlpMetricsFromConfig(pConf, service.MockResources().Logger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = o.Close(context.Background())
+	})
+
+	o.NewCounterCtor("foo")().Incr(2)
+	o.NewGaugeCtor("foo")().Set(7)
+	o.NewTimerCtor("foo")().Timing(int64(time.Millisecond))
+	o.NewGaugeCtor("bar")().Set(3)
+
+	req := o.request()
+	metrics := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 2)
+
+	assert.Equal(t, "foo", metrics[0].Name)
+	sum := metrics[0].GetSum()
+	require.NotNil(t, sum)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(2), sum.DataPoints[0].GetAsInt())
+
+	assert.Equal(t, "bar", metrics[1].Name)
+	require.NotNil(t, metrics[1].GetGauge())
+}
+
+func TestOtlpMetricsConfigErrors(t *testing.T) {
+	pConf, err := otlpMetricsSpec().ParseYAML(`
+histogram_buckets: [ 1, 0.1 ]
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newOtlpMetricsFromConfig(pConf, service.MockResources().Logger())
+	require.EqualError(t, err, "histogram_buckets must be in ascending order")
+}
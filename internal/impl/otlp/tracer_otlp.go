@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/benthosdev/benthos/v4/internal/cli"
+	"github.com/benthosdev/benthos/v4/internal/tracing"
 	"github.com/benthosdev/benthos/v4/public/service"
 )
 
@@ -118,20 +119,10 @@ func newOtlp(config *otlp) (trace.TracerProvider, error) {
 	}
 	var attrs []attribute.KeyValue
 
-	for k, v := range config.tags {
+	for k, v := range tracing.ServiceTags(config.tags, cli.Version) {
 		attrs = append(attrs, attribute.String(k, v))
 	}
 
-	if _, ok := config.tags[string(semconv.ServiceNameKey)]; !ok {
-		attrs = append(attrs, semconv.ServiceNameKey.String("benthos"))
-
-		// Only set the default service version tag if the user doesn't provide
-		// a custom service name tag.
-		if _, ok := config.tags[string(semconv.ServiceVersionKey)]; !ok {
-			attrs = append(attrs, semconv.ServiceVersionKey.String(cli.Version))
-		}
-	}
-
 	opts = append(opts, tracesdk.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)))
 
 	return tracesdk.NewTracerProvider(opts...), nil
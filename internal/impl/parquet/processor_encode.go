@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/segmentio/parquet-go"
 	"github.com/segmentio/parquet-go/compress"
+	"github.com/segmentio/parquet-go/format"
 
 	"github.com/benthosdev/benthos/v4/internal/bloblang/query"
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -75,8 +77,8 @@ func init() {
 func parquetSchemaConfig() *service.ConfigField {
 	return service.NewObjectListField("schema",
 		service.NewStringField("name").Description("The name of the column."),
-		service.NewStringEnumField("type", "BOOLEAN", "INT32", "INT64", "FLOAT", "DOUBLE", "BYTE_ARRAY", "UTF8").
-			Description("The type of the column, only applicable for leaf columns with no child fields. Some logical types can be specified here such as UTF8.").Optional(),
+		service.NewStringEnumField("type", "BOOLEAN", "INT32", "INT64", "FLOAT", "DOUBLE", "BYTE_ARRAY", "UTF8", "TIMESTAMP_MILLIS", "TIMESTAMP_MICROS").
+			Description("The type of the column, only applicable for leaf columns with no child fields. Some logical types can be specified here such as UTF8. Timestamp columns accept either a number representing a unix timestamp in the unit of the column, or a string or timestamp value which is converted.").Optional(),
 		service.NewBoolField("repeated").Description("Whether the field is repeated.").Default(false),
 		service.NewBoolField("optional").Description("Whether the field is optional.").Default(false),
 		service.NewAnyListField("fields").Description("A list of child fields.").Optional().Example([]any{
@@ -137,6 +139,10 @@ func parquetGroupFromConfig(columnConfs []*service.ParsedConfig, encodingFn enco
 				n = parquet.Leaf(parquet.ByteArrayType)
 			case "UTF8":
 				n = parquet.String()
+			case "TIMESTAMP_MILLIS":
+				n = parquet.Timestamp(parquet.Millisecond)
+			case "TIMESTAMP_MICROS":
+				n = parquet.Timestamp(parquet.Microsecond)
 			default:
 				return nil, fmt.Errorf("field %v type of '%v' not recognised", name, typeStr)
 			}
@@ -271,6 +277,25 @@ func (s *parquetEncodeProcessor) Close(ctx context.Context) error {
 
 //------------------------------------------------------------------------------
 
+// timestampToUnit converts a value into an integer timestamp of the provided
+// unit. Numbers are assumed to already be in the correct unit.
+func timestampToUnit(data any, unit format.TimeUnit) (int64, error) {
+	switch data.(type) {
+	case string, time.Time:
+	default:
+		return query.IGetInt(data)
+	}
+
+	ts, err := query.IGetTimestamp(data)
+	if err != nil {
+		return 0, err
+	}
+	if unit.Micros != nil {
+		return ts.UnixMicro(), nil
+	}
+	return ts.UnixMilli(), nil
+}
+
 type inserterConfig struct {
 	colIndex      int
 	firstRepOf    *int
@@ -352,6 +377,14 @@ func (c *inserterConfig) toPQValueNotRepeated(f parquet.Field, data any, defLeve
 			}
 			leafValue = parquet.ValueOf(int32(iv))
 		case parquet.Int64:
+			if lt := f.Type().LogicalType(); lt != nil && lt.Timestamp != nil {
+				iv, err := timestampToUnit(data, lt.Timestamp.Unit)
+				if err != nil {
+					return nil, err
+				}
+				leafValue = parquet.ValueOf(iv)
+				break
+			}
 			iv, err := query.IGetInt(data)
 			if err != nil {
 				return nil, err
@@ -183,6 +183,56 @@ func testParquetEncodeDecodeRoundTrip(t *testing.T, encodeProc *parquetEncodePro
 	}
 }
 
+func TestParquetEncodeTimestamps(t *testing.T) {
+	tctx := context.Background()
+
+	encodeConf, err := parquetEncodeProcessorConfig().ParseYAML(`
+schema:
+  - { name: millis, type: TIMESTAMP_MILLIS }
+  - { name: micros, type: TIMESTAMP_MICROS }
+  - { name: raw, type: TIMESTAMP_MILLIS, optional: true }
+`, nil)
+	require.NoError(t, err)
+
+	encodeProc, err := newParquetEncodeProcessorFromConfig(encodeConf, nil)
+	require.NoError(t, err)
+
+	decodeConf, err := parquetDecodeProcessorConfig().ParseYAML(``, nil)
+	require.NoError(t, err)
+
+	decodeProc, err := newParquetDecodeProcessorFromConfig(decodeConf, nil)
+	require.NoError(t, err)
+
+	encodedBatches, err := encodeProc.ProcessBatch(tctx, service.MessageBatch{
+		service.NewMessage([]byte(`{"millis":"2022-01-02T03:04:05.678Z","micros":"2022-01-02T03:04:05.678901Z","raw":1641092645678}`)),
+		service.NewMessage([]byte(`{"millis":"2022-01-02T03:04:05Z","micros":"2022-01-02T03:04:05Z"}`)),
+	})
+	require.NoError(t, err)
+	require.Len(t, encodedBatches, 1)
+	require.Len(t, encodedBatches[0], 1)
+
+	encodedBytes, err := encodedBatches[0][0].AsBytes()
+	require.NoError(t, err)
+
+	decodedBatch, err := decodeProc.Process(tctx, service.NewMessage(encodedBytes))
+	require.NoError(t, err)
+	require.Len(t, decodedBatch, 2)
+
+	var actual []string
+	for _, m := range decodedBatch {
+		mBytes, err := m.AsBytes()
+		require.NoError(t, err)
+		actual = append(actual, string(mBytes))
+	}
+	assert.JSONEq(t, `{"millis":1641092645678,"micros":1641092645678901,"raw":1641092645678}`, actual[0])
+	assert.JSONEq(t, `{"millis":1641092645000,"micros":1641092645000000,"raw":null}`, actual[1])
+
+	_, err = encodeProc.ProcessBatch(tctx, service.MessageBatch{
+		service.NewMessage([]byte(`{"millis":"not a timestamp","micros":0}`)),
+	})
+	require.Error(t, err)
+}
+
 func TestParquetEncodeEmptyBatch(t *testing.T) {
 	tctx := context.Background()
 
@@ -1,8 +1,12 @@
 package prometheus
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,6 +15,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/model"
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
@@ -26,6 +31,8 @@ func init() {
 		Status: docs.StatusStable,
 		Summary: `
 Host endpoints (` + "`/metrics` and `/stats`" + `) for Prometheus scraping.`,
+		Description: `
+A JSON snapshot of the current metrics is also served at the endpoint ` + "`/metrics/json`" + `, where timings are summarised as p50, p90 and p99 percentiles in nanoseconds. When ` + "`use_histogram_timing`" + ` is enabled these percentiles are estimated from the histogram buckets.`,
 		Footnotes: `
 ## Push Gateway
 
@@ -39,6 +46,22 @@ include the "/metrics/jobs/..." path in the push URL.
 
 If the Push Gateway requires HTTP Basic Authentication it can be configured with
 ` + "`push_basic_auth`.",
+		Examples: []docs.AnnotatedExample{
+			{
+				Title: "Histogram Timings",
+				Summary: `
+By default timing metrics are exported as summaries, which cannot be aggregated across instances. Here we export timings as histograms with buckets suited to a pipeline with latencies in the region of tens to hundreds of milliseconds, and also push metrics to a Push Gateway every thirty seconds:`,
+				Config: `
+metrics:
+  prometheus:
+    use_histogram_timing: true
+    histogram_buckets: [ 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5 ]
+    push_url: http://localhost:9091
+    push_interval: 30s
+    push_job_name: benthos_ingest
+`,
+			},
+		},
 		Config: docs.FieldComponent().WithChildren(
 			docs.FieldBool("use_histogram_timing", "Whether to export timing metrics as a histogram, if `false` a summary is used instead. When exporting histogram timings the delta values are converted from nanoseconds into seconds in order to better fit within bucket definitions. For more information on histograms and summaries refer to: https://prometheus.io/docs/practices/histograms/.").HasDefault(false).Advanced().AtVersion("3.63.0"),
 			docs.FieldFloat("histogram_buckets", "Timing metrics histogram buckets (in seconds). If left empty defaults to DefBuckets (https://pkg.go.dev/github.com/prometheus/client_golang/prometheus#pkg-variables)").Array().HasDefault([]any{}).Advanced().AtVersion("3.63.0"),
@@ -207,18 +230,10 @@ func newPrometheus(config metrics.Config, nm bundle.NewManagement) (metrics.Type
 			if err != nil {
 				return nil, fmt.Errorf("failed to parse push interval: %v", err)
 			}
-			go func() {
-				for {
-					select {
-					case <-p.closedChan:
-						return
-					case <-time.After(interval):
-						if err = p.pusher.Push(); err != nil {
-							p.log.Errorf("Failed to push metrics: %v\n", err)
-						}
-					}
-				}
-			}()
+			if interval <= 0 {
+				return nil, fmt.Errorf("push interval must be greater than zero, got %v", promConf.PushInterval)
+			}
+			go p.pushLoop(interval)
 		}
 	}
 
@@ -229,6 +244,22 @@ func newPrometheus(config metrics.Config, nm bundle.NewManagement) (metrics.Type
 	return p, nil
 }
 
+func (p *prometheusMetrics) pushLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.closedChan:
+			return
+		case <-ticker.C:
+			if err := p.pusher.Push(); err != nil {
+				p.log.Errorf("Failed to push metrics: %v\n", err)
+			}
+		}
+	}
+}
+
 //------------------------------------------------------------------------------
 
 func (p *prometheusMetrics) HandlerFunc() http.HandlerFunc {
@@ -237,6 +268,119 @@ func (p *prometheusMetrics) HandlerFunc() http.HandlerFunc {
 	}
 }
 
+// JSONHandlerFunc returns a handler that serves a JSON snapshot of the gathered
+// registry in the same shape as the json_api metrics type. Counters and gauges
+// are mapped to their values and timers to their p50, p90 and p99 percentiles
+// in nanoseconds, with histogram percentiles estimated from the buckets.
+func (p *prometheusMetrics) JSONHandlerFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		families, err := p.reg.Gather()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		p.mut.Lock()
+		histTimers := make(map[string]struct{}, len(p.timersHist))
+		for k := range p.timersHist {
+			histTimers[k] = struct{}{}
+		}
+		p.mut.Unlock()
+
+		values := map[string]any{}
+		for _, fam := range families {
+			for _, m := range fam.GetMetric() {
+				key := jsonSnapshotKey(fam.GetName(), m.GetLabel())
+				switch fam.GetType() {
+				case dto.MetricType_COUNTER:
+					values[key] = m.GetCounter().GetValue()
+				case dto.MetricType_GAUGE:
+					values[key] = m.GetGauge().GetValue()
+				case dto.MetricType_UNTYPED:
+					values[key] = m.GetUntyped().GetValue()
+				case dto.MetricType_SUMMARY:
+					ps := map[string]float64{}
+					for _, q := range m.GetSummary().GetQuantile() {
+						ps["p"+strconv.FormatFloat(q.GetQuantile()*100, 'f', -1, 64)] = q.GetValue()
+					}
+					values[key] = ps
+				case dto.MetricType_HISTOGRAM:
+					scale := 1.0
+					if _, isTimer := histTimers[fam.GetName()]; isTimer {
+						scale = 1_000_000_000
+					}
+					h := m.GetHistogram()
+					values[key] = map[string]float64{
+						"p50": histogramQuantile(0.5, h) * scale,
+						"p90": histogramQuantile(0.9, h) * scale,
+						"p99": histogramQuantile(0.99, h) * scale,
+					}
+				}
+			}
+		}
+
+		jBytes, err := json.Marshal(values)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jBytes)
+	}
+}
+
+// jsonSnapshotKey formats a metric name and its labels the same way labelled
+// metrics are keyed by the json_api metrics type.
+func jsonSnapshotKey(name string, labels []*dto.LabelPair) string {
+	if len(labels) == 0 {
+		return name
+	}
+
+	sorted := make([]*dto.LabelPair, len(labels))
+	copy(sorted, labels)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetName() < sorted[j].GetName()
+	})
+
+	b := &strings.Builder{}
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, l := range sorted {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.GetName())
+		b.WriteByte('=')
+		b.WriteString(strconv.QuoteToASCII(l.GetValue()))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// histogramQuantile estimates a quantile from the cumulative buckets of a
+// histogram by linear interpolation within the bucket containing the rank.
+func histogramQuantile(q float64, h *dto.Histogram) float64 {
+	count := float64(h.GetSampleCount())
+	if count == 0 {
+		return 0
+	}
+
+	rank := q * count
+	var lowerBound, lowerCount float64
+	for _, b := range h.GetBucket() {
+		upperBound, upperCount := b.GetUpperBound(), float64(b.GetCumulativeCount())
+		if upperCount >= rank {
+			if upperCount == lowerCount {
+				return upperBound
+			}
+			return lowerBound + (upperBound-lowerBound)*(rank-lowerCount)/(upperCount-lowerCount)
+		}
+		lowerBound, lowerCount = upperBound, upperCount
+	}
+	return lowerBound
+}
+
 func (p *prometheusMetrics) GetCounter(path string) metrics.StatCounter {
 	return p.GetCounterVec(path).With()
 }
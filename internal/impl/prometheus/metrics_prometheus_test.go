@@ -1,6 +1,7 @@
 package prometheus
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -89,6 +90,18 @@ func TestPrometheusWithPushGatewayAndPushInterval(t *testing.T) {
 	}
 }
 
+func TestPrometheusWithBadPushInterval(t *testing.T) {
+	for _, interval := range []string{"0s", "-1s"} {
+		config := metrics.NewConfig()
+		config.Prometheus.PushURL = "http://localhost:9091"
+		config.Prometheus.PushInterval = interval
+
+		_, err := newPrometheus(config, mock.NewManager())
+		require.Error(t, err, interval)
+		assert.Contains(t, err.Error(), "push interval must be greater than zero", interval)
+	}
+}
+
 func getTestProm(t *testing.T) (metrics.Type, http.HandlerFunc) {
 	t.Helper()
 
@@ -167,6 +180,39 @@ func TestPrometheusHistMetrics(t *testing.T) {
 	assert.Contains(t, body, "\ntimertwo_sum{label3=\"value4\",label4=\"value5\"} 1.4e-08")
 }
 
+func TestPrometheusJSONSnapshot(t *testing.T) {
+	for _, useHist := range []bool{false, true} {
+		conf := metrics.NewConfig()
+		conf.Prometheus.UseHistogramTiming = useHist
+		conf.Prometheus.HistogramBuckets = []float64{0.0005, 0.001, 0.002}
+
+		nm, err := newPrometheus(conf, mock.NewManager())
+		require.NoError(t, err)
+
+		applyTestMetrics(nm)
+		nm.GetTimerVec("timertwo", "label3", "label4").With("value4", "value5").Timing(int64(time.Millisecond))
+
+		handler := metrics.JSONHandlerFunc(nm)
+		require.NotNil(t, handler)
+
+		var values map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getPage(t, handler)), &values))
+
+		assert.Equal(t, 21.0, values["counterone"])
+		assert.Equal(t, 12.0, values["gaugeone"])
+		assert.Equal(t, 10.0, values[`countertwo{label1="value1"}`])
+		assert.Equal(t, 11.0, values[`countertwo{label1="value2"}`])
+		assert.Equal(t, 12.0, values[`gaugetwo{label2="value3"}`])
+
+		tmr, ok := values[`timertwo{label3="value4",label4="value5"}`].(map[string]any)
+		require.True(t, ok, "hist: %v", useHist)
+		for _, p := range []string{"p50", "p90", "p99"} {
+			require.Contains(t, tmr, p)
+			assert.InDelta(t, float64(time.Millisecond), tmr[p], float64(time.Millisecond/2), "hist: %v", useHist)
+		}
+	}
+}
+
 func TestPrometheusWithFileOutputPath(t *testing.T) {
 	config := metrics.NewConfig()
 	config.Prometheus.FileOutputPath = os.TempDir() + "/benthos_metrics.prom"
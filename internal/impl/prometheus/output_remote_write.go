@@ -0,0 +1,412 @@
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/benthosdev/benthos/v4/internal/bloblang/query"
+	"github.com/benthosdev/benthos/v4/internal/httpclient"
+	"github.com/benthosdev/benthos/v4/public/bloblang"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	rwoFieldURL              = "url"
+	rwoFieldMetricName       = "metric_name"
+	rwoFieldLabels           = "labels"
+	rwoFieldValueMapping     = "value_mapping"
+	rwoFieldTimestampMapping = "timestamp_mapping"
+	rwoFieldHeaders          = "headers"
+	rwoFieldTimeout          = "timeout"
+	rwoFieldTLS              = "tls"
+	rwoFieldMaxInFlight      = "max_in_flight"
+	rwoFieldBatching         = "batching"
+)
+
+func remoteWriteOutputSpec() *service.ConfigSpec {
+	spec := service.NewConfigSpec().
+		Beta().
+		Categories("Services").
+		Version("4.18.0").
+		Summary("Pushes messages as samples to a receiver of the Prometheus remote write protocol, such as Cortex, Mimir, Thanos or Prometheus itself.").
+		Description(`Each message is converted into a sample of a time series, where the metric name and labels are derived from interpolated strings and the value is derived from a [Bloblang mapping](/docs/guides/bloblang/about). Samples of a batch are sent within a single request, and therefore a `+"[`batching` policy](/docs/configuration/batching)"+` should be used in order to send samples efficiently.
+
+Samples that share the same metric name and labels within a batch are grouped into the same time series and sorted by their timestamp. Remote write receivers commonly reject samples that are older than the latest sample of a time series, and therefore messages of the same time series should be written in order.
+
+Requests that are rejected with a 4xx status code, other than 429, would fail again if retried and therefore the samples are logged and dropped. Requests that are rejected with any other status code are retried.`).
+		Fields(
+			service.NewURLField(rwoFieldURL).
+				Description("The URL of the remote write endpoint.").
+				Examples("http://localhost:9090/api/v1/write", "http://mimir:8080/api/v1/push"),
+			service.NewInterpolatedStringField(rwoFieldMetricName).
+				Description("The metric name of each sample.").
+				Examples("http_requests_total", `${! json("name") }`),
+			service.NewInterpolatedStringMapField(rwoFieldLabels).
+				Description("A map of labels to add to each sample. Labels that resolve to an empty string are omitted.").
+				Example(map[string]any{
+					"host": `${! json("host") }`,
+					"job":  "benthos",
+				}).
+				Default(map[string]any{}),
+			service.NewBloblangField(rwoFieldValueMapping).
+				Description("A [Bloblang mapping](/docs/guides/bloblang/about) that provides the value of each sample, which must be a number.").
+				Examples(`root = this.value`, `root = this.latency_ms / 1000`),
+			service.NewBloblangField(rwoFieldTimestampMapping).
+				Description("An optional [Bloblang mapping](/docs/guides/bloblang/about) that provides the timestamp of each sample, which must either be a numerical unix time in seconds (with up to nanosecond precision via decimals), or a string in ISO 8601 format. By default samples are timestamped at the time they are sent.").
+				Examples(`root = this.timestamp`).
+				Optional(),
+			service.NewStringMapField(rwoFieldHeaders).
+				Description("A map of headers to add to each request, such as a tenant identifier.").
+				Example(map[string]any{
+					"X-Scope-OrgID": "tenant-1",
+				}).
+				Advanced().
+				Default(map[string]any{}),
+			service.NewDurationField(rwoFieldTimeout).
+				Description("The maximum period to wait for a request to complete.").
+				Advanced().
+				Default("5s"),
+			service.NewTLSToggledField(rwoFieldTLS),
+			service.NewIntField(rwoFieldMaxInFlight).
+				Description("The maximum number of batches to have in flight at a given time. Increase this to improve throughput.").
+				Default(64),
+		)
+
+	for _, f := range httpclient.AuthFieldSpecs() {
+		spec = spec.Field(f)
+	}
+
+	return spec.
+		Field(service.NewBatchPolicyField(rwoFieldBatching)).
+		Example(
+			"Pushing request latencies",
+			"In this example we consume JSON documents describing HTTP requests and push their latencies to Mimir as samples of the metric `http_request_duration_seconds`, labelled by their route and status code.",
+			`
+output:
+  prometheus_remote_write:
+    url: http://mimir:8080/api/v1/push
+    metric_name: http_request_duration_seconds
+    labels:
+      route: ${! json("route") }
+      code: ${! json("status") }
+    value_mapping: root = this.latency_ms / 1000
+    timestamp_mapping: root = this.timestamp
+    headers:
+      X-Scope-OrgID: tenant-1
+    batching:
+      count: 500
+      period: 1s
+`,
+		)
+}
+
+func init() {
+	err := service.RegisterBatchOutput("prometheus_remote_write", remoteWriteOutputSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.BatchOutput, batchPolicy service.BatchPolicy, maxInFlight int, err error) {
+			if batchPolicy, err = conf.FieldBatchPolicy(rwoFieldBatching); err != nil {
+				return
+			}
+			if maxInFlight, err = conf.FieldInt(rwoFieldMaxInFlight); err != nil {
+				return
+			}
+			out, err = newRemoteWriteOutputFromParsed(conf, mgr)
+			return
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type remoteWriteOutput struct {
+	url       string
+	headers   map[string]string
+	client    *http.Client
+	reqSigner httpclient.RequestSigner
+
+	metricName *service.InterpolatedString
+	labels     map[string]*service.InterpolatedString
+	value      *bloblang.Executor
+	timestamp  *bloblang.Executor
+
+	mgr *service.Resources
+}
+
+func newRemoteWriteOutputFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*remoteWriteOutput, error) {
+	r := &remoteWriteOutput{
+		mgr: mgr,
+	}
+
+	u, err := conf.FieldURL(rwoFieldURL)
+	if err != nil {
+		return nil, err
+	}
+	r.url = u.String()
+
+	if r.metricName, err = conf.FieldInterpolatedString(rwoFieldMetricName); err != nil {
+		return nil, err
+	}
+	if r.labels, err = conf.FieldInterpolatedStringMap(rwoFieldLabels); err != nil {
+		return nil, err
+	}
+	if r.value, err = conf.FieldBloblang(rwoFieldValueMapping); err != nil {
+		return nil, err
+	}
+	if conf.Contains(rwoFieldTimestampMapping) {
+		if r.timestamp, err = conf.FieldBloblang(rwoFieldTimestampMapping); err != nil {
+			return nil, err
+		}
+	}
+	if r.headers, err = conf.FieldStringMap(rwoFieldHeaders); err != nil {
+		return nil, err
+	}
+
+	timeout, err := conf.FieldDuration(rwoFieldTimeout)
+	if err != nil {
+		return nil, err
+	}
+	r.client = &http.Client{Timeout: timeout}
+
+	tlsConf, tlsEnabled, err := conf.FieldTLSToggled(rwoFieldTLS)
+	if err != nil {
+		return nil, err
+	}
+	if tlsEnabled {
+		if c, ok := http.DefaultTransport.(*http.Transport); ok {
+			cloned := c.Clone()
+			cloned.TLSClientConfig = tlsConf
+			r.client.Transport = cloned
+		} else {
+			r.client.Transport = &http.Transport{
+				TLSClientConfig: tlsConf,
+			}
+		}
+	}
+
+	if r.reqSigner, err = httpclient.AuthSignerFromParsed(conf); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *remoteWriteOutput) Connect(ctx context.Context) error {
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type rwLabel struct {
+	name, value string
+}
+
+type rwSample struct {
+	value     float64
+	timestamp int64
+}
+
+type rwTimeSeries struct {
+	labels  []rwLabel
+	samples []rwSample
+}
+
+func (r *remoteWriteOutput) sample(batch service.MessageBatch, index int) (labels []rwLabel, sample rwSample, err error) {
+	name, err := batch.TryInterpolatedString(index, r.metricName)
+	if err != nil {
+		err = fmt.Errorf("metric name interpolation error: %w", err)
+		return
+	}
+	labels = append(labels, rwLabel{name: "__name__", value: name})
+
+	for k, v := range r.labels {
+		var l string
+		if l, err = batch.TryInterpolatedString(index, v); err != nil {
+			err = fmt.Errorf("label %v interpolation error: %w", k, err)
+			return
+		}
+		if l != "" {
+			labels = append(labels, rwLabel{name: k, value: l})
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].name < labels[j].name
+	})
+
+	valueAny, err := mappingResult(batch, index, r.value)
+	if err != nil {
+		err = fmt.Errorf("value mapping failed: %w", err)
+		return
+	}
+	if sample.value, err = query.IGetNumber(valueAny); err != nil {
+		err = fmt.Errorf("value mapping failed: %w", err)
+		return
+	}
+
+	ts := time.Now()
+	if r.timestamp != nil {
+		var tsAny any
+		if tsAny, err = mappingResult(batch, index, r.timestamp); err != nil {
+			err = fmt.Errorf("timestamp mapping failed: %w", err)
+			return
+		}
+		if ts, err = query.IGetTimestamp(tsAny); err != nil {
+			err = fmt.Errorf("timestamp mapping failed: %w", err)
+			return
+		}
+	}
+	sample.timestamp = ts.UnixMilli()
+	return
+}
+
+func mappingResult(batch service.MessageBatch, index int, exec *bloblang.Executor) (any, error) {
+	msg, err := batch.BloblangQuery(index, exec)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return nil, errors.New("mapping resulted in a deleted message")
+	}
+	v, err := msg.AsStructured()
+	if err != nil {
+		if b, _ := msg.AsBytes(); len(b) > 0 {
+			return string(b), nil
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func seriesKey(labels []rwLabel) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.name)
+		b.WriteByte(0)
+		b.WriteString(l.value)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+//------------------------------------------------------------------------------
+
+// The remote write protocol expects a snappy compressed protobuf WriteRequest,
+// which we encode by hand as the schema is small and stable.
+
+func appendTag(b []byte, field int, wireType byte) []byte {
+	return appendUvarint(b, uint64(field)<<3|uint64(wireType))
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendBytesField(b []byte, field int, v []byte) []byte {
+	b = appendTag(b, field, 2)
+	b = appendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func encodeWriteRequest(series []*rwTimeSeries) []byte {
+	var req []byte
+	for _, s := range series {
+		var ts []byte
+		for _, l := range s.labels {
+			var lb []byte
+			lb = appendBytesField(lb, 1, []byte(l.name))
+			lb = appendBytesField(lb, 2, []byte(l.value))
+			ts = appendBytesField(ts, 1, lb)
+		}
+		for _, smp := range s.samples {
+			var sb []byte
+			var vb [8]byte
+			binary.LittleEndian.PutUint64(vb[:], math.Float64bits(smp.value))
+			sb = appendTag(sb, 1, 1)
+			sb = append(sb, vb[:]...)
+			sb = appendTag(sb, 2, 0)
+			sb = appendUvarint(sb, uint64(smp.timestamp))
+			ts = appendBytesField(ts, 2, sb)
+		}
+		req = appendBytesField(req, 1, ts)
+	}
+	return req
+}
+
+//------------------------------------------------------------------------------
+
+func (r *remoteWriteOutput) WriteBatch(ctx context.Context, batch service.MessageBatch) error {
+	var series []*rwTimeSeries
+	seriesByKey := map[string]*rwTimeSeries{}
+	for i := range batch {
+		labels, sample, err := r.sample(batch, i)
+		if err != nil {
+			return err
+		}
+		key := seriesKey(labels)
+		s, exists := seriesByKey[key]
+		if !exists {
+			s = &rwTimeSeries{labels: labels}
+			seriesByKey[key] = s
+			series = append(series, s)
+		}
+		s.samples = append(s.samples, sample)
+	}
+	for _, s := range series {
+		sort.SliceStable(s.samples, func(i, j int) bool {
+			return s.samples[i].timestamp < s.samples[j].timestamp
+		})
+	}
+
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	for k, v := range r.headers {
+		req.Header.Set(k, v)
+	}
+	if err := r.reqSigner(r.mgr.FS(), req); err != nil {
+		return err
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		err := fmt.Errorf("remote write request failed with status %v: %s", res.StatusCode, bytes.TrimSpace(resBody))
+
+		// Client errors such as out of order samples would fail again if
+		// retried, and are therefore dropped.
+		if res.StatusCode >= 400 && res.StatusCode < 500 && res.StatusCode != http.StatusTooManyRequests {
+			r.mgr.Logger().Errorf("Dropping batch of %v samples: %v", len(batch), err)
+			return nil
+		}
+		return err
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	return nil
+}
+
+func (r *remoteWriteOutput) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,169 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+// decodeFields is a minimal protobuf decoder returning the raw values of each
+// field of a message in order.
+func decodeFields(t *testing.T, b []byte) (fields []int, values []any) {
+	t.Helper()
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		require.Greater(t, n, 0)
+		b = b[n:]
+
+		fields = append(fields, int(tag>>3))
+		switch tag & 7 {
+		case 0:
+			v, n := binary.Uvarint(b)
+			require.Greater(t, n, 0)
+			b = b[n:]
+			values = append(values, int64(v))
+		case 1:
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+			b = b[8:]
+		case 2:
+			l, n := binary.Uvarint(b)
+			require.Greater(t, n, 0)
+			b = b[n:]
+			values = append(values, b[:l])
+			b = b[l:]
+		default:
+			t.Fatalf("unexpected wire type: %v", tag&7)
+		}
+	}
+	return
+}
+
+func decodeWriteRequest(t *testing.T, b []byte) (res []string) {
+	t.Helper()
+	_, series := decodeFields(t, b)
+	for _, s := range series {
+		fields, values := decodeFields(t, s.([]byte))
+		var str string
+		for i, f := range fields {
+			_, vs := decodeFields(t, values[i].([]byte))
+			switch f {
+			case 1:
+				str += fmt.Sprintf("%s=%s ", vs[0], vs[1])
+			case 2:
+				str += fmt.Sprintf("%v@%v ", vs[0], vs[1])
+			}
+		}
+		res = append(res, str)
+	}
+	return
+}
+
+func TestRemoteWriteOutput(t *testing.T) {
+	reqs := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		reqs <- r
+		bodies <- b
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	conf, err := remoteWriteOutputSpec().ParseYAML(fmt.Sprintf(`
+url: %v/api/v1/write
+metric_name: ${! json("name") }
+labels:
+  host: ${! json("host") }
+  zone: ${! json("zone").or("") }
+value_mapping: root = this.value
+timestamp_mapping: root = this.ts
+headers:
+  X-Scope-OrgID: foo
+`, ts.URL), nil)
+	require.NoError(t, err)
+
+	out, err := newRemoteWriteOutputFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, out.Connect(context.Background()))
+
+	var batch service.MessageBatch
+	for _, s := range []string{
+		`{"name":"cpu","host":"a","zone":"eu","value":0.5,"ts":20}`,
+		`{"name":"mem","host":"b","value":100,"ts":10}`,
+		`{"name":"cpu","host":"a","zone":"eu","value":0.25,"ts":10}`,
+	} {
+		batch = append(batch, service.NewMessage([]byte(s)))
+	}
+	require.NoError(t, out.WriteBatch(context.Background(), batch))
+	require.NoError(t, out.Close(context.Background()))
+
+	req := <-reqs
+	assert.Equal(t, "/api/v1/write", req.URL.Path)
+	assert.Equal(t, "snappy", req.Header.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+	assert.Equal(t, "foo", req.Header.Get("X-Scope-OrgID"))
+
+	body, err := snappy.Decode(nil, <-bodies)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"__name__=cpu host=a zone=eu 0.25@10000 0.5@20000 ",
+		"__name__=mem host=b 100@10000 ",
+	}, decodeWriteRequest(t, body))
+}
+
+func TestRemoteWriteOutputErrors(t *testing.T) {
+	var status int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", int(atomic.LoadInt64(&status)))
+	}))
+	defer ts.Close()
+
+	conf, err := remoteWriteOutputSpec().ParseYAML(fmt.Sprintf(`
+url: %v
+metric_name: foo
+value_mapping: root = this.value
+`, ts.URL), nil)
+	require.NoError(t, err)
+
+	out, err := newRemoteWriteOutputFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+
+	err = out.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`{"value":"nope"}`)),
+	})
+	require.Error(t, err)
+
+	for _, test := range []struct {
+		status int
+		err    string
+	}{
+		{status: http.StatusBadRequest},
+		{status: http.StatusNotFound},
+		{status: http.StatusTooManyRequests, err: "remote write request failed with status 429: nope"},
+		{status: http.StatusInternalServerError, err: "remote write request failed with status 500: nope"},
+		{status: http.StatusServiceUnavailable, err: "remote write request failed with status 503: nope"},
+	} {
+		atomic.StoreInt64(&status, int64(test.status))
+		err = out.WriteBatch(context.Background(), service.MessageBatch{
+			service.NewMessage([]byte(`{"value":1}`)),
+		})
+		if test.err == "" {
+			assert.NoError(t, err, test.status)
+		} else {
+			assert.EqualError(t, err, test.err, test.status)
+		}
+	}
+}
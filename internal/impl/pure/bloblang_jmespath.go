@@ -0,0 +1,58 @@
+package pure
+
+import (
+	"fmt"
+
+	jmespath "github.com/jmespath/go-jmespath"
+
+	"github.com/benthosdev/benthos/v4/internal/bloblang/query"
+	"github.com/benthosdev/benthos/v4/internal/message"
+	"github.com/benthosdev/benthos/v4/public/bloblang"
+)
+
+func init() {
+	if err := bloblang.RegisterMethodV2("jmespath",
+		bloblang.NewPluginSpec().
+			Beta().
+			Version("4.18.0").
+			Category(query.MethodCategoryObjectAndArray).
+			Description("Executes a [JMESPath query](http://jmespath.org/) on a value and returns the result. Queries that compare values result in a boolean, which makes this method suitable for routing predicates such as the `check` field of a [`switch` output](/docs/components/outputs/switch) or [processor](/docs/components/processors/switch).").
+			Example("", `root.cities = this.jmespath("locations[?state == 'WA'].name | sort(@)")`,
+				[2]string{
+					`{"locations":[{"name":"Seattle","state":"WA"},{"name":"New York","state":"NY"},{"name":"Bellevue","state":"WA"}]}`,
+					`{"cities":["Bellevue","Seattle"]}`,
+				},
+			).
+			Example("Queries that result in a boolean can be used as conditions.", "root.expensive = this.jmespath(\"length(items[?price > `100`]) > `0`\")",
+				[2]string{
+					`{"items":[{"price":20},{"price":150}]}`,
+					`{"expensive":true}`,
+				},
+				[2]string{
+					`{"items":[{"price":20}]}`,
+					`{"expensive":false}`,
+				},
+			).
+			Param(bloblang.NewStringParam("query").Description("The JMESPath query to execute.")),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			queryStr, err := args.GetString("query")
+			if err != nil {
+				return nil, err
+			}
+			jQuery, err := jmespath.Compile(queryStr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile JMESPath query: %w", err)
+			}
+			return func(v any) (any, error) {
+				// Numbers are replaced in place and therefore we operate on a
+				// copy in order to leave the original value untouched.
+				v = message.CopyJSON(v)
+				if nv, replace := clearNumbers(v); replace {
+					v = nv
+				}
+				return safeSearch(v, jQuery)
+			}, nil
+		}); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,36 @@
+package pure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/bloblang"
+)
+
+func TestJMESPathMethod(t *testing.T) {
+	exec, err := bloblang.Parse("root = this.jmespath(\"items[?price > `100`].id\")")
+	require.NoError(t, err)
+
+	input := map[string]any{
+		"items": []any{
+			map[string]any{"id": "foo", "price": json.Number("20")},
+			map[string]any{"id": "bar", "price": json.Number("150.5")},
+		},
+	}
+
+	res, err := exec.Query(input)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"bar"}, res)
+
+	// The input value must not be modified by the query.
+	assert.Equal(t, json.Number("20"), input["items"].([]any)[0].(map[string]any)["price"])
+}
+
+func TestJMESPathMethodBadQuery(t *testing.T) {
+	_, err := bloblang.Parse(`root = this.jmespath("items[?")`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to compile JMESPath query")
+}
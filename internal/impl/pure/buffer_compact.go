@@ -0,0 +1,282 @@
+package pure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benthosdev/benthos/v4/internal/batch"
+	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	cbFieldKey     = "key"
+	cbFieldPeriod  = "period"
+	cbFieldMaxKeys = "max_keys"
+)
+
+func compactBufferConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.18.0").
+		Categories("Utility").
+		Summary("Holds messages for a period of time and keeps only the latest message for each key, compacting bursts of updates to the same entity into a single message.").
+		Description(`
+Each message written to this buffer is assigned a key by resolving the `+"`key`"+` field. When a message arrives with a key that is already held by the buffer the older message is discarded and replaced with the new one. Once the `+"`period`"+` has passed since the last flush all held messages are flushed as a single batch, in the order in which their keys were first seen within that period.
+
+This is useful when consuming streams of updates where only the most recent state of an entity is of interest, as it reduces the volume of writes to sinks that are slow or expensive to call.
+
+## Memory Usage
+
+Messages are held in memory until they are flushed, and therefore memory usage grows with the number of distinct keys seen within a period. The field `+"`max_keys`"+` can be used in order to flush early once a number of distinct keys are held, at which point writes to the buffer are blocked until the held messages have been flushed and read, which bounds the memory usage of the buffer.
+
+## Delivery Guarantees
+
+This buffer honours the transaction model within Benthos in order to ensure that messages are not acknowledged until they are either intentionally dropped or successfully delivered to outputs. A message that is replaced by a later message with the same key is considered intentionally dropped and is acknowledged immediately.
+
+During graceful termination all held messages are flushed regardless of the period. If the buffer is instead closed while messages are still held they are rejected, so that they can be consumed again by inputs that support it.`).
+		Fields(
+			service.NewInterpolatedStringField(cbFieldKey).
+				Description("An interpolated string that resolves to the key of each message, messages that share a key are compacted.").
+				Example(`${! meta("kafka_key") }`).
+				Example(`${! this.user.id }`),
+			service.NewDurationField(cbFieldPeriod).
+				Description("The period of time to hold messages for before flushing them.").
+				Example("1s").
+				Example("1m"),
+			service.NewIntField(cbFieldMaxKeys).
+				Description("An optional maximum number of distinct keys to hold, once reached all held messages are flushed early and writes are blocked until they have been read. Set to `0` in order to disable.").
+				Default(0).
+				Advanced(),
+		).
+		Example(
+			"Compacting Inventory Updates",
+			"Here we consume stock level updates from Kafka, where a single product can receive many updates in quick succession, and write only the latest level of each product to an HTTP API at most once every ten seconds:",
+			`
+input:
+  kafka:
+    addresses: [ TODO ]
+    topics: [ stock_levels ]
+    consumer_group: benthos_stock_sync
+
+buffer:
+  compact:
+    key: ${! this.product_id }
+    period: 10s
+
+output:
+  http_client:
+    url: http://example.com/inventory
+    verb: PUT
+`,
+		)
+}
+
+func init() {
+	err := service.RegisterBatchBuffer(
+		"compact", compactBufferConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchBuffer, error) {
+			return newCompactBufferFromConfig(conf)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newCompactBufferFromConfig(conf *service.ParsedConfig) (*compactBuffer, error) {
+	key, err := conf.FieldInterpolatedString(cbFieldKey)
+	if err != nil {
+		return nil, err
+	}
+	period, err := conf.FieldDuration(cbFieldPeriod)
+	if err != nil {
+		return nil, err
+	}
+	if period <= 0 {
+		return nil, fmt.Errorf("period must be greater than zero, got %v", period)
+	}
+	maxKeys, err := conf.FieldInt(cbFieldMaxKeys)
+	if err != nil {
+		return nil, err
+	}
+	return newCompactBuffer(key, period, maxKeys), nil
+}
+
+//------------------------------------------------------------------------------
+
+type compactMessage struct {
+	m     *service.Message
+	ackFn service.AckFunc
+}
+
+type compactBuffer struct {
+	key     *service.InterpolatedString
+	period  time.Duration
+	maxKeys int
+
+	pending []compactMessage
+	keys    map[string]int
+	closed  bool
+	cond    *sync.Cond
+
+	fullChan chan struct{}
+
+	endOfInputChan      chan struct{}
+	closeEndOfInputOnce sync.Once
+}
+
+func newCompactBuffer(key *service.InterpolatedString, period time.Duration, maxKeys int) *compactBuffer {
+	return &compactBuffer{
+		key:            key,
+		period:         period,
+		maxKeys:        maxKeys,
+		keys:           map[string]int{},
+		cond:           sync.NewCond(&sync.Mutex{}),
+		fullChan:       make(chan struct{}, 1),
+		endOfInputChan: make(chan struct{}),
+	}
+}
+
+func (c *compactBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageBatch, aFn service.AckFunc) error {
+	// Resolve all keys before modifying our state so that a failed batch is
+	// rejected as a whole.
+	keys := make([]string, len(msgBatch))
+	for i := range msgBatch {
+		var err error
+		if keys[i], err = msgBatch.TryInterpolatedString(i, c.key); err != nil {
+			return fmt.Errorf("key interpolation error: %w", err)
+		}
+	}
+
+	ctx, done := context.WithCancel(ctx)
+	defer done()
+
+	go func() {
+		<-ctx.Done()
+		c.cond.L.Lock()
+		c.cond.Broadcast()
+		c.cond.L.Unlock()
+	}()
+
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+
+	// Block until held messages are flushed once we've reached max_keys.
+	for !c.closed && c.maxKeys > 0 && len(c.keys) >= c.maxKeys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.cond.Wait()
+	}
+	if c.closed {
+		return component.ErrTypeClosed
+	}
+
+	aggregatedAck := batch.NewCombinedAcker(batch.AckFunc(aFn))
+	for i, msg := range msgBatch {
+		cMsg := compactMessage{
+			m:     msg.Copy(),
+			ackFn: service.AckFunc(aggregatedAck.Derive()),
+		}
+		if index, exists := c.keys[keys[i]]; exists {
+			// The older message is superseded and therefore intentionally
+			// dropped, so we acknowledge it.
+			_ = c.pending[index].ackFn(ctx, nil)
+			c.pending[index] = cMsg
+			continue
+		}
+		c.keys[keys[i]] = len(c.pending)
+		c.pending = append(c.pending, cMsg)
+	}
+
+	if c.maxKeys > 0 && len(c.keys) >= c.maxKeys {
+		select {
+		case c.fullChan <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (c *compactBuffer) flush() (service.MessageBatch, service.AckFunc) {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+
+	// Drain any signal of a full buffer as we're about to empty it.
+	select {
+	case <-c.fullChan:
+	default:
+	}
+
+	if len(c.pending) == 0 {
+		return nil, nil
+	}
+
+	flushBatch := make(service.MessageBatch, len(c.pending))
+	flushAcks := make([]service.AckFunc, len(c.pending))
+	for i, pending := range c.pending {
+		flushBatch[i] = pending.m
+		flushAcks[i] = pending.ackFn
+	}
+
+	c.pending = nil
+	c.keys = map[string]int{}
+	c.cond.Broadcast()
+
+	return flushBatch, func(ctx context.Context, err error) error {
+		for _, aFn := range flushAcks {
+			_ = aFn(ctx, err)
+		}
+		return nil
+	}
+}
+
+func (c *compactBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	timer := time.NewTimer(c.period)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			timer.Reset(c.period)
+		case <-c.fullChan:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-c.endOfInputChan:
+			if msgBatch, aFn := c.flush(); len(msgBatch) > 0 {
+				return msgBatch, aFn, nil
+			}
+			return nil, nil, service.ErrEndOfBuffer
+		}
+		if msgBatch, aFn := c.flush(); len(msgBatch) > 0 {
+			return msgBatch, aFn, nil
+		}
+	}
+}
+
+func (c *compactBuffer) EndOfInput() {
+	c.closeEndOfInputOnce.Do(func() {
+		close(c.endOfInputChan)
+	})
+}
+
+var errCompactClosed = errors.New("message rejected as buffer was closed before it was flushed")
+
+func (c *compactBuffer) Close(ctx context.Context) error {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+
+	// Nack all pending messages so that we re-consume them on the next start
+	// up.
+	for _, pending := range c.pending {
+		_ = pending.ackFn(ctx, errCompactClosed)
+	}
+	c.pending = nil
+	c.keys = map[string]int{}
+	c.closed = true
+	c.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,255 @@
+package pure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func compactBufFromConf(t *testing.T, conf string) *compactBuffer {
+	t.Helper()
+
+	parsedConf, err := compactBufferConfig().ParseYAML(conf, nil)
+	require.NoError(t, err)
+
+	buf, err := newCompactBufferFromConfig(parsedConf)
+	require.NoError(t, err)
+	return buf
+}
+
+func compactBatchContents(t *testing.T, b service.MessageBatch) []string {
+	t.Helper()
+
+	var contents []string
+	for _, m := range b {
+		mBytes, err := m.AsBytes()
+		require.NoError(t, err)
+		contents = append(contents, string(mBytes))
+	}
+	return contents
+}
+
+func TestCompactBufferConfigErrors(t *testing.T) {
+	parsedConf, err := compactBufferConfig().ParseYAML(`
+key: ${! this.id }
+period: 0s
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newCompactBufferFromConfig(parsedConf)
+	require.EqualError(t, err, "period must be greater than zero, got 0s")
+}
+
+func TestCompactBufferLatestPerKey(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	buf := compactBufFromConf(t, `
+key: ${! this.id }
+period: 50ms
+`)
+	defer buf.Close(ctx)
+
+	var acks []error
+	ackFor := func(ctx context.Context, err error) error {
+		acks = append(acks, err)
+		return nil
+	}
+
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"a","v":1}`)),
+		service.NewMessage([]byte(`{"id":"b","v":1}`)),
+	}, ackFor))
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"a","v":2}`)),
+	}, ackFor))
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"c","v":1}`)),
+		service.NewMessage([]byte(`{"id":"a","v":3}`)),
+	}, ackFor))
+
+	// The second batch was entirely superseded and is therefore acknowledged.
+	assert.Equal(t, []error{nil}, acks)
+
+	outBatch, ackFn, err := buf.ReadBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`{"id":"a","v":3}`,
+		`{"id":"b","v":1}`,
+		`{"id":"c","v":1}`,
+	}, compactBatchContents(t, outBatch))
+
+	require.NoError(t, ackFn(ctx, errors.New("nope")))
+	require.Len(t, acks, 3)
+	assert.EqualError(t, acks[1], "nope")
+	assert.EqualError(t, acks[2], "nope")
+
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"a","v":4}`)),
+	}, ackFor))
+
+	outBatch, ackFn, err = buf.ReadBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"id":"a","v":4}`}, compactBatchContents(t, outBatch))
+	require.NoError(t, ackFn(ctx, nil))
+	require.Len(t, acks, 4)
+	assert.NoError(t, acks[3])
+}
+
+func TestCompactBufferMaxKeys(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	buf := compactBufFromConf(t, `
+key: ${! content() }
+period: 1h
+max_keys: 2
+`)
+	defer buf.Close(ctx)
+
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`foo`)),
+		service.NewMessage([]byte(`foo`)),
+	}, noopAck))
+
+	readCtx, readDone := context.WithTimeout(ctx, time.Millisecond*50)
+	_, _, err := buf.ReadBatch(readCtx)
+	readDone()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`bar`)),
+	}, noopAck))
+
+	outBatch, _, err := buf.ReadBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, compactBatchContents(t, outBatch))
+}
+
+func TestCompactBufferMaxKeysBlocksWrites(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	buf := compactBufFromConf(t, `
+key: ${! content() }
+period: 1h
+max_keys: 2
+`)
+	defer buf.Close(ctx)
+
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`foo`)),
+		service.NewMessage([]byte(`bar`)),
+	}, noopAck))
+
+	// With a slow reader further writes are blocked until a flush.
+	writeErrChan := make(chan error, 1)
+	go func() {
+		writeErrChan <- buf.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(`baz`)),
+		}, noopAck)
+	}()
+
+	select {
+	case err := <-writeErrChan:
+		t.Fatalf("write was not blocked: %v", err)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	outBatch, _, err := buf.ReadBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, compactBatchContents(t, outBatch))
+
+	select {
+	case err := <-writeErrChan:
+		require.NoError(t, err)
+	case <-ctx.Done():
+		t.Fatal("write was not unblocked by a flush")
+	}
+
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`buz`)),
+	}, noopAck))
+
+	// Blocked writes honour their context.
+	writeCtx, writeDone := context.WithTimeout(ctx, time.Millisecond*50)
+	err = buf.WriteBatch(writeCtx, service.MessageBatch{
+		service.NewMessage([]byte(`qux`)),
+	}, noopAck)
+	writeDone()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// And are rejected once the buffer is closed.
+	go func() {
+		writeErrChan <- buf.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(`qux`)),
+		}, noopAck)
+	}()
+	<-time.After(time.Millisecond * 50)
+	require.NoError(t, buf.Close(ctx))
+
+	select {
+	case err := <-writeErrChan:
+		assert.Equal(t, component.ErrTypeClosed, err)
+	case <-ctx.Done():
+		t.Fatal("write was not unblocked by close")
+	}
+}
+
+func TestCompactBufferEndOfInput(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	buf := compactBufFromConf(t, `
+key: ${! content() }
+period: 1h
+`)
+	defer buf.Close(ctx)
+
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`foo`)),
+		service.NewMessage([]byte(`bar`)),
+	}, noopAck))
+	buf.EndOfInput()
+
+	outBatch, _, err := buf.ReadBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, compactBatchContents(t, outBatch))
+
+	_, _, err = buf.ReadBatch(ctx)
+	assert.Equal(t, service.ErrEndOfBuffer, err)
+}
+
+func TestCompactBufferCloseNacksPending(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	buf := compactBufFromConf(t, `
+key: ${! content() }
+period: 1h
+`)
+
+	var acks []error
+	require.NoError(t, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`foo`)),
+		service.NewMessage([]byte(`bar`)),
+	}, func(ctx context.Context, err error) error {
+		acks = append(acks, err)
+		return nil
+	}))
+
+	require.NoError(t, buf.Close(ctx))
+	require.Len(t, acks, 1)
+	assert.Equal(t, errCompactClosed, acks[0])
+
+	assert.Equal(t, component.ErrTypeClosed, buf.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte(`baz`)),
+	}, noopAck))
+}
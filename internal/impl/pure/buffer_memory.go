@@ -2,6 +2,7 @@ package pure
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -42,13 +43,25 @@ This buffer has a configurable limit, where consumption will be stopped with bac
 
 This buffer intentionally weakens the delivery guarantees of the pipeline and therefore should never be used in places where data loss is unacceptable.
 
+### Write-Ahead Log
+
+When the field ` + "`wal_directory`" + ` is set messages are written to a log on disk before they are acknowledged at the input level, and their delivery is recorded in the log once they are acknowledged at the output level. If the service is shut down uncleanly then messages that were buffered but not delivered are recovered from the log and emitted again when the service restarts, which provides at-least-once delivery guarantees across process crashes and restarts.
+
+Writes to the log are not synced to disk, and therefore messages may still be lost in the event of an operating system crash or power loss. Recovered messages are queued regardless of the ` + "`limit`" + ` of the buffer.
+
 ## Batching
 
 It is possible to batch up messages sent from this buffer using a [batch policy](/docs/configuration/batching#batch-policy).`).
 		Field(service.NewIntField("limit").
 			Description(`The maximum buffer size (in bytes) to allow before applying backpressure upstream.`).
 			Default(524288000)).
-		Field(service.NewInternalField(bs))
+		Field(service.NewInternalField(bs)).
+		Field(service.NewStringField("wal_directory").
+			Description("An optional directory in which to keep a write-ahead log of messages that are yet to be delivered, allowing them to be recovered after an unclean shutdown. The directory is created if it does not already exist, and must not be shared with other buffers.").
+			Example("./benthos_wal").
+			Version("4.18.0").
+			Optional().
+			Advanced())
 }
 
 func init() {
@@ -88,26 +101,45 @@ func newMemoryBufferFromConfig(conf *service.ParsedConfig, res *service.Resource
 		}
 	}
 
-	return newMemoryBuffer(limit, batcher), nil
+	m := newMemoryBuffer(limit, batcher)
+	if conf.Contains("wal_directory") {
+		walDir, err := conf.FieldString("wal_directory")
+		if err != nil {
+			return nil, err
+		}
+		if err := m.openWAL(walDir); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
 }
 
 //------------------------------------------------------------------------------
 
 type measuredBatch struct {
-	b    service.MessageBatch
-	size int
+	b       service.MessageBatch
+	size    int
+	created time.Time
+
+	// The identifier of the batch within the write-ahead log, if enabled.
+	walID uint64
 }
 
 type memoryBuffer struct {
 	batches []measuredBatch
 	bytes   int
 
+	// The messages and bytes of batches that are waiting to be read.
+	queuedMessages int
+	queuedBytes    int
+
 	cap        int
 	cond       *sync.Cond
 	endOfInput bool
 	closed     bool
 
 	batcher *service.Batcher
+	wal     *memoryWAL
 }
 
 func newMemoryBuffer(capacity int, batcher *service.Batcher) *memoryBuffer {
@@ -118,6 +150,37 @@ func newMemoryBuffer(capacity int, batcher *service.Batcher) *memoryBuffer {
 	}
 }
 
+// openWAL opens a write-ahead log in the provided directory and queues any
+// batches recovered from it.
+func (m *memoryBuffer) openWAL(dir string) error {
+	wal, recovered, err := openMemoryWAL(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+
+	m.wal = wal
+	for _, r := range recovered {
+		size := 0
+		for _, msg := range r.b {
+			mBytes, err := msg.AsBytes()
+			if err != nil {
+				return err
+			}
+			size += len(mBytes)
+		}
+		m.batches = append(m.batches, measuredBatch{
+			b:       r.b,
+			size:    size,
+			created: time.Now(),
+			walID:   r.id,
+		})
+		m.bytes += size
+		m.queuedMessages += len(r.b)
+		m.queuedBytes += size
+	}
+	return nil
+}
+
 //------------------------------------------------------------------------------
 
 func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
@@ -183,6 +246,8 @@ func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 				batchReady = m.batcher.Add(msg.Copy())
 			}
 			batchSources = append(batchSources, m.batches[0])
+			m.queuedMessages -= len(m.batches[0].b)
+			m.queuedBytes -= m.batches[0].size
 
 			m.batches[0] = measuredBatch{}
 			m.batches = m.batches[1:]
@@ -213,8 +278,19 @@ func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 		defer m.cond.L.Unlock()
 		if err == nil {
 			m.bytes -= outSize
+			if m.wal != nil {
+				for _, b := range batchSources {
+					if walErr := m.wal.ack(b.walID); walErr != nil {
+						return fmt.Errorf("failed to record delivery in write-ahead log: %w", walErr)
+					}
+				}
+			}
 		} else {
 			m.batches = append(batchSources, m.batches...)
+			for _, b := range batchSources {
+				m.queuedMessages += len(b.b)
+				m.queuedBytes += b.size
+			}
 		}
 		m.cond.Broadcast()
 		return nil
@@ -225,8 +301,13 @@ func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 func (m *memoryBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageBatch, aFn service.AckFunc) error {
 	// Deep copy before acknowledging in order to avoid vague ownership
 	msgBatch = msgBatch.DeepCopy()
-	if err := aFn(ctx, nil); err != nil {
-		return err
+
+	// With a write-ahead log the batch is only acknowledged once it has been
+	// logged, as only then can it be recovered.
+	if m.wal == nil {
+		if err := aFn(ctx, nil); err != nil {
+			return err
+		}
 	}
 
 	extraBytes := 0
@@ -242,6 +323,16 @@ func (m *memoryBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageB
 		return component.ErrMessageTooLarge
 	}
 
+	if err := m.store(msgBatch, extraBytes); err != nil {
+		return err
+	}
+	if m.wal != nil {
+		return aFn(ctx, nil)
+	}
+	return nil
+}
+
+func (m *memoryBuffer) store(msgBatch service.MessageBatch, extraBytes int) error {
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
 
@@ -256,16 +347,42 @@ func (m *memoryBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageB
 		}
 	}
 
+	var walID uint64
+	if m.wal != nil {
+		var err error
+		if walID, err = m.wal.write(msgBatch); err != nil {
+			return fmt.Errorf("failed to write to write-ahead log: %w", err)
+		}
+	}
+
 	m.batches = append(m.batches, measuredBatch{
-		b:    msgBatch,
-		size: extraBytes,
+		b:       msgBatch,
+		size:    extraBytes,
+		created: time.Now(),
+		walID:   walID,
 	})
 	m.bytes += extraBytes
+	m.queuedMessages += len(msgBatch)
+	m.queuedBytes += extraBytes
 
 	m.cond.Broadcast()
 	return nil
 }
 
+func (m *memoryBuffer) Backlog() service.BufferBacklog {
+	m.cond.L.Lock()
+	defer m.cond.L.Unlock()
+
+	b := service.BufferBacklog{
+		Messages: m.queuedMessages,
+		Bytes:    m.queuedBytes,
+	}
+	if len(m.batches) > 0 {
+		b.Oldest = m.batches[0].created
+	}
+	return b
+}
+
 func (m *memoryBuffer) EndOfInput() {
 	go func() {
 		m.cond.L.Lock()
@@ -284,8 +401,12 @@ func (m *memoryBuffer) EndOfInput() {
 
 func (m *memoryBuffer) Close(ctx context.Context) error {
 	m.cond.L.Lock()
+	defer m.cond.L.Unlock()
+
 	m.closed = true
 	m.cond.Broadcast()
-	m.cond.L.Unlock()
+	if m.wal != nil {
+		return m.wal.close()
+	}
 	return nil
 }
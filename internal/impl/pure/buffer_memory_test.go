@@ -476,3 +476,82 @@ batch_policy:
 	msgEqual(t, "hello", m[0])
 	require.NoError(t, ackFunc(ctx, nil))
 }
+
+func TestMemoryBatchedCheck(t *testing.T) {
+	ctx := context.Background()
+	block := memBufFromConf(t, `
+limit: 100000
+batch_policy:
+  enabled: true
+  check: content() == "end"
+  processors:
+    - archive:
+        format: lines
+`)
+	defer block.Close(ctx)
+
+	if err := block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("end")),
+		service.NewMessage([]byte("world")),
+	}, func(ctx context.Context, err error) error { return nil }); err != nil {
+		t.Error(err)
+	}
+
+	tCtx, done := context.WithTimeout(ctx, time.Second)
+	defer done()
+
+	m, ackFunc, err := block.ReadBatch(tCtx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	msgEqual(t, "end\nworld", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+}
+
+func TestMemoryBacklog(t *testing.T) {
+	ctx := context.Background()
+	block := memBufFromConf(t, `
+limit: 1000
+`)
+	defer block.Close(ctx)
+
+	assert.Equal(t, service.BufferBacklog{}, block.Backlog())
+
+	before := time.Now()
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("hello")),
+		service.NewMessage([]byte("world")),
+	}, func(ctx context.Context, err error) error { return nil }))
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("foo")),
+	}, func(ctx context.Context, err error) error { return nil }))
+
+	backlog := block.Backlog()
+	assert.Equal(t, 3, backlog.Messages)
+	assert.Equal(t, 13, backlog.Bytes)
+	assert.False(t, backlog.Oldest.Before(before))
+	oldest := backlog.Oldest
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 2)
+
+	backlog = block.Backlog()
+	assert.Equal(t, 1, backlog.Messages)
+	assert.Equal(t, 3, backlog.Bytes)
+	assert.False(t, backlog.Oldest.Before(oldest))
+
+	// Rejected batches are queued again with their original write time.
+	require.NoError(t, ackFunc(ctx, errors.New("nope")))
+
+	backlog = block.Backlog()
+	assert.Equal(t, 3, backlog.Messages)
+	assert.Equal(t, 13, backlog.Bytes)
+	assert.Equal(t, oldest, backlog.Oldest)
+
+	for i := 0; i < 2; i++ {
+		_, ackFunc, err = block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.NoError(t, ackFunc(ctx, nil))
+	}
+	assert.Equal(t, service.BufferBacklog{}, block.Backlog())
+}
@@ -0,0 +1,361 @@
+package pure
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	walRecordWrite byte = 'W'
+	walRecordAck   byte = 'A'
+
+	walSegmentExt = ".wal"
+)
+
+// The size at which the active segment of a write-ahead log is rotated.
+var walSegmentMaxSize int64 = 64 * 1024 * 1024
+
+var errWALCorrupt = errors.New("write-ahead log record is corrupt")
+
+type walSegment struct {
+	n    uint64
+	path string
+	f    *os.File
+	size int64
+
+	// The number of batches written to this segment that are yet to be
+	// acknowledged.
+	live int
+}
+
+// memoryWAL is an append-only log of batches written to a memory buffer and
+// the acknowledgements of those batches, split across segment files. A
+// segment is deleted once all batches written to it have been acknowledged,
+// and since acknowledgements are always written to the same or a later
+// segment than the batch they refer to segments are only ever deleted from
+// the front.
+type memoryWAL struct {
+	dir      string
+	segments []*walSegment
+	written  map[uint64]*walSegment
+	nextID   uint64
+}
+
+type walBatch struct {
+	id uint64
+	b  service.MessageBatch
+}
+
+// openMemoryWAL opens a write-ahead log within a directory, returning the
+// batches that were written in a prior run and never acknowledged. Recovered
+// batches are compacted into a fresh segment before the prior segments are
+// removed.
+func openMemoryWAL(dir string) (*memoryWAL, []walBatch, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, err
+	}
+
+	oldPaths, err := filepath.Glob(filepath.Join(dir, "*"+walSegmentExt))
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(oldPaths)
+
+	pending := map[uint64]service.MessageBatch{}
+	var lastSegment, nextID uint64
+	for _, p := range oldPaths {
+		var n uint64
+		if _, err := fmt.Sscanf(strings.TrimSuffix(filepath.Base(p), walSegmentExt), "%d", &n); err == nil && n > lastSegment {
+			lastSegment = n
+		}
+		if err := readWALSegment(p, pending, &nextID); err != nil {
+			return nil, nil, fmt.Errorf("failed to read write-ahead log segment %v: %w", p, err)
+		}
+	}
+
+	ids := make([]uint64, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	// Batch identifiers continue from those of the prior run so that they
+	// never collide with records of prior segments, which remain on disk until
+	// recovery has completed.
+	w := &memoryWAL{
+		dir:     dir,
+		written: map[uint64]*walSegment{},
+		nextID:  nextID,
+	}
+	if err := w.newSegment(lastSegment + 1); err != nil {
+		return nil, nil, err
+	}
+
+	recovered := make([]walBatch, 0, len(ids))
+	for _, id := range ids {
+		newID, err := w.write(pending[id])
+		if err != nil {
+			_ = w.close()
+			return nil, nil, err
+		}
+		recovered = append(recovered, walBatch{id: newID, b: pending[id]})
+	}
+	for _, seg := range w.segments {
+		if err := seg.f.Sync(); err != nil {
+			_ = w.close()
+			return nil, nil, err
+		}
+	}
+
+	for _, p := range oldPaths {
+		if err := os.Remove(p); err != nil {
+			_ = w.close()
+			return nil, nil, err
+		}
+	}
+	return w, recovered, nil
+}
+
+// readWALSegment reads the records of a segment into a map of pending batches,
+// and raises nextID above the identifiers of any records it contains.
+func readWALSegment(path string, pending map[uint64]service.MessageBatch, nextID *uint64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		var header [9]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			// A partially written record at the end of a segment is the result
+			// of an unclean shutdown mid-write and the batch was therefore
+			// never acknowledged upstream.
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+
+		id := binary.BigEndian.Uint64(header[1:])
+		if id >= *nextID {
+			*nextID = id + 1
+		}
+		switch header[0] {
+		case walRecordAck:
+			delete(pending, id)
+		case walRecordWrite:
+			var lenBytes [4]byte
+			if _, err := io.ReadFull(r, lenBytes[:]); err != nil {
+				if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+					return nil
+				}
+				return err
+			}
+			content := make([]byte, binary.BigEndian.Uint32(lenBytes[:]))
+			if _, err := io.ReadFull(r, content); err != nil {
+				if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+					return nil
+				}
+				return err
+			}
+			b, err := decodeWALBatch(content)
+			if err != nil {
+				return err
+			}
+			pending[id] = b
+		default:
+			return errWALCorrupt
+		}
+	}
+}
+
+func (w *memoryWAL) newSegment(n uint64) error {
+	path := filepath.Join(w.dir, fmt.Sprintf("%020d%v", n, walSegmentExt))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	w.segments = append(w.segments, &walSegment{n: n, path: path, f: f})
+	return nil
+}
+
+func (w *memoryWAL) active() *walSegment {
+	return w.segments[len(w.segments)-1]
+}
+
+func (w *memoryWAL) append(record []byte) error {
+	seg := w.active()
+	if seg.size >= walSegmentMaxSize {
+		if err := w.newSegment(seg.n + 1); err != nil {
+			return err
+		}
+		seg = w.active()
+	}
+	if _, err := seg.f.Write(record); err != nil {
+		// Discard any partially written record so that subsequent records
+		// remain readable.
+		_ = seg.f.Truncate(seg.size)
+		_, _ = seg.f.Seek(seg.size, io.SeekStart)
+		return err
+	}
+	seg.size += int64(len(record))
+	return nil
+}
+
+// write logs a batch and returns the identifier to acknowledge it with.
+func (w *memoryWAL) write(b service.MessageBatch) (uint64, error) {
+	content, err := encodeWALBatch(b)
+	if err != nil {
+		return 0, err
+	}
+
+	id := w.nextID
+	record := make([]byte, 13, 13+len(content))
+	record[0] = walRecordWrite
+	binary.BigEndian.PutUint64(record[1:], id)
+	binary.BigEndian.PutUint32(record[9:], uint32(len(content)))
+	record = append(record, content...)
+
+	if err := w.append(record); err != nil {
+		return 0, err
+	}
+	w.nextID++
+
+	seg := w.active()
+	seg.live++
+	w.written[id] = seg
+	return id, nil
+}
+
+// ack logs the acknowledgement of a batch and removes any segments that no
+// longer contain unacknowledged batches.
+func (w *memoryWAL) ack(id uint64) error {
+	seg, exists := w.written[id]
+	if !exists {
+		return nil
+	}
+
+	var record [9]byte
+	record[0] = walRecordAck
+	binary.BigEndian.PutUint64(record[1:], id)
+	if err := w.append(record[:]); err != nil {
+		return err
+	}
+
+	delete(w.written, id)
+	seg.live--
+
+	for len(w.segments) > 1 && w.segments[0].live == 0 {
+		if err := w.segments[0].f.Close(); err != nil {
+			return err
+		}
+		if err := os.Remove(w.segments[0].path); err != nil {
+			return err
+		}
+		w.segments[0] = nil
+		w.segments = w.segments[1:]
+	}
+	return nil
+}
+
+func (w *memoryWAL) close() error {
+	var err error
+	for _, seg := range w.segments {
+		if cErr := seg.f.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+	w.segments = nil
+	w.written = nil
+	return err
+}
+
+//------------------------------------------------------------------------------
+
+func encodeWALBatch(b service.MessageBatch) ([]byte, error) {
+	buf := appendWALUint32(nil, uint32(len(b)))
+	for _, msg := range b {
+		metaObj := map[string]any{}
+		_ = msg.MetaWalkMut(func(key string, value any) error {
+			metaObj[key] = value
+			return nil
+		})
+		metaBytes, err := msgpack.Marshal(metaObj)
+		if err != nil {
+			return nil, err
+		}
+		msgBytes, err := msg.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		buf = appendWALUint32(buf, uint32(len(metaBytes)))
+		buf = append(buf, metaBytes...)
+		buf = appendWALUint32(buf, uint32(len(msgBytes)))
+		buf = append(buf, msgBytes...)
+	}
+	return buf, nil
+}
+
+func appendWALUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func decodeWALBatch(b []byte) (service.MessageBatch, error) {
+	readChunk := func() ([]byte, error) {
+		if len(b) < 4 {
+			return nil, errWALCorrupt
+		}
+		l := binary.BigEndian.Uint32(b)
+		if uint32(len(b)-4) < l {
+			return nil, errWALCorrupt
+		}
+		chunk := b[4 : 4+l]
+		b = b[4+l:]
+		return chunk, nil
+	}
+
+	if len(b) < 4 {
+		return nil, errWALCorrupt
+	}
+	count := binary.BigEndian.Uint32(b)
+	b = b[4:]
+
+	batch := make(service.MessageBatch, 0, count)
+	for i := uint32(0); i < count; i++ {
+		metaBytes, err := readChunk()
+		if err != nil {
+			return nil, err
+		}
+		msgBytes, err := readChunk()
+		if err != nil {
+			return nil, err
+		}
+
+		msg := service.NewMessage(msgBytes)
+		metaObj := map[string]any{}
+		if err := msgpack.Unmarshal(metaBytes, &metaObj); err != nil {
+			return nil, err
+		}
+		for k, v := range metaObj {
+			msg.MetaSetMut(k, v)
+		}
+		batch = append(batch, msg)
+	}
+	return batch, nil
+}
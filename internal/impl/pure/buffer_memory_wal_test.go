@@ -0,0 +1,197 @@
+package pure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func walBufFromDir(t *testing.T, dir string) *memoryBuffer {
+	t.Helper()
+	return memBufFromConf(t, `
+limit: 1000
+wal_directory: `+dir+`
+`)
+}
+
+func walWrite(t *testing.T, m *memoryBuffer, contents ...string) {
+	t.Helper()
+
+	var batch service.MessageBatch
+	for _, c := range contents {
+		msg := service.NewMessage([]byte(c))
+		msg.MetaSetMut("foo", c)
+		batch = append(batch, msg)
+	}
+
+	acked := false
+	require.NoError(t, m.WriteBatch(context.Background(), batch, func(ctx context.Context, err error) error {
+		acked = true
+		return err
+	}))
+	assert.True(t, acked)
+}
+
+func walRead(t *testing.T, m *memoryBuffer) ([]string, service.AckFunc) {
+	t.Helper()
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	batch, ackFn, err := m.ReadBatch(ctx)
+	require.NoError(t, err)
+
+	var contents []string
+	for _, msg := range batch {
+		mBytes, err := msg.AsBytes()
+		require.NoError(t, err)
+		contents = append(contents, string(mBytes))
+
+		v, _ := msg.MetaGetMut("foo")
+		assert.Equal(t, string(mBytes), v)
+	}
+	return contents, ackFn
+}
+
+func TestMemoryWALRecovery(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	block := walBufFromDir(t, dir)
+	walWrite(t, block, "first")
+	walWrite(t, block, "second", "third")
+	walWrite(t, block, "fourth")
+
+	contents, ackFn := walRead(t, block)
+	assert.Equal(t, []string{"first"}, contents)
+	require.NoError(t, ackFn(ctx, nil))
+
+	contents, _ = walRead(t, block)
+	assert.Equal(t, []string{"second", "third"}, contents)
+
+	// Simulate an unclean shutdown by abandoning the buffer.
+	require.NoError(t, block.wal.close())
+
+	block = walBufFromDir(t, dir)
+	assert.Equal(t, 3, block.Backlog().Messages)
+
+	contents, ackFn = walRead(t, block)
+	assert.Equal(t, []string{"second", "third"}, contents)
+	require.NoError(t, ackFn(ctx, nil))
+
+	walWrite(t, block, "fifth")
+	require.NoError(t, block.Close(ctx))
+
+	block = walBufFromDir(t, dir)
+	defer block.Close(ctx)
+
+	contents, ackFn = walRead(t, block)
+	assert.Equal(t, []string{"fourth"}, contents)
+	require.NoError(t, ackFn(ctx, nil))
+
+	contents, ackFn = walRead(t, block)
+	assert.Equal(t, []string{"fifth"}, contents)
+	require.NoError(t, ackFn(ctx, nil))
+
+	assert.Equal(t, service.BufferBacklog{}, block.Backlog())
+}
+
+func TestMemoryWALPartialRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	block := walBufFromDir(t, dir)
+	walWrite(t, block, "first")
+	walWrite(t, block, "second")
+	require.NoError(t, block.Close(ctx))
+
+	// Truncate the last record as if the process died mid-write.
+	segments, err := filepath.Glob(filepath.Join(dir, "*.wal"))
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+
+	info, err := os.Stat(segments[0])
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(segments[0], info.Size()-3))
+
+	block = walBufFromDir(t, dir)
+	defer block.Close(ctx)
+
+	assert.Equal(t, 1, block.Backlog().Messages)
+	contents, _ := walRead(t, block)
+	assert.Equal(t, []string{"first"}, contents)
+}
+
+func TestMemoryWALSegments(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	defer func(size int64) {
+		walSegmentMaxSize = size
+	}(walSegmentMaxSize)
+	walSegmentMaxSize = 1
+
+	block := walBufFromDir(t, dir)
+	defer block.Close(ctx)
+
+	walWrite(t, block, "first")
+	walWrite(t, block, "second")
+	walWrite(t, block, "third")
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*.wal"))
+	require.NoError(t, err)
+	assert.Len(t, segments, 3)
+
+	for _, exp := range []string{"first", "second"} {
+		contents, ackFn := walRead(t, block)
+		assert.Equal(t, []string{exp}, contents)
+		require.NoError(t, ackFn(ctx, nil))
+	}
+
+	// Only segments prior to the one holding the unacknowledged batch are
+	// removed, the acknowledgements themselves are held in new segments.
+	segments, err = filepath.Glob(filepath.Join(dir, "*.wal"))
+	require.NoError(t, err)
+	assert.Len(t, segments, 3)
+
+	contents, ackFn := walRead(t, block)
+	assert.Equal(t, []string{"third"}, contents)
+	require.NoError(t, ackFn(ctx, nil))
+
+	segments, err = filepath.Glob(filepath.Join(dir, "*.wal"))
+	require.NoError(t, err)
+	assert.Len(t, segments, 1)
+}
+
+func TestMemoryWALIDsContinue(t *testing.T) {
+	dir := t.TempDir()
+
+	w, recovered, err := openMemoryWAL(dir)
+	require.NoError(t, err)
+	require.Empty(t, recovered)
+
+	for _, c := range []string{"first", "second", "third"} {
+		_, err := w.write(service.MessageBatch{service.NewMessage([]byte(c))})
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.ack(0))
+	require.NoError(t, w.close())
+
+	w, recovered, err = openMemoryWAL(dir)
+	require.NoError(t, err)
+	require.Len(t, recovered, 2)
+	assert.Equal(t, uint64(3), recovered[0].id)
+	assert.Equal(t, uint64(4), recovered[1].id)
+
+	id, err := w.write(service.MessageBatch{service.NewMessage([]byte("fourth"))})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), id)
+	require.NoError(t, w.close())
+}
@@ -0,0 +1,227 @@
+package pure
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/OneOfOne/xxhash"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	bloomCacheFieldCapacity          = "capacity"
+	bloomCacheFieldFalsePositiveRate = "false_positive_rate"
+)
+
+func bloomCacheConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.18.0").
+		Summary(`Tracks the presence of keys in memory using a bloom filter, providing deduplication with bounded memory at the cost of occasional false positives.`).
+		Description(`
+This cache is intended for use with the `+"[`dedupe` processor](/docs/components/processors/dedupe)"+` on streams where storing every key would not fit in memory. Keys are hashed into a fixed size bit array and the keys themselves are never stored, therefore this cache only supports the `+"`add`"+` and `+"`set`"+` operations. A `+"`get`"+` of a key that is not present returns a not found error, and a `+"`get`"+` of a key that is present returns an error as values are not stored. Deleting keys is not supported.
+
+A bloom filter never reports a key that was added as missing, but may report a key that was never added as present, in which case a message would be wrongly considered a duplicate. The likelihood of this happening is controlled by the field `+"`false_positive_rate`"+`.
+
+### Memory Usage
+
+Keys are tracked across two generations of filters, each sized to hold `+"`capacity`"+` keys at the configured false positive rate. Once the current generation is full it becomes the previous generation, and the oldest generation is discarded. This means that the most recent `+"`capacity`"+` keys are always remembered, and memory usage never grows beyond two filters. A capacity of one million keys with a false positive rate of 1% uses roughly 2.3MiB of memory in total.
+
+TTLs are not supported and are ignored, keys are instead forgotten as generations are rotated. This cache is reset every time the service restarts.`).
+		Fields(
+			service.NewIntField(bloomCacheFieldCapacity).
+				Description("The number of keys each generation of the filter is sized to hold.").
+				Default(1000000),
+			service.NewFloatField(bloomCacheFieldFalsePositiveRate).
+				Description("The target probability of a key that was never added being reported as present, between 0 and 1.").
+				Default(0.01),
+		).
+		Example(
+			"Deduplicating a High Volume Stream",
+			"Here we drop messages with an ID seen within roughly the last ten million messages, using a small fixed amount of memory:",
+			`
+pipeline:
+  processors:
+    - dedupe:
+        cache: seen_ids
+        key: ${! this.id }
+
+cache_resources:
+  - label: seen_ids
+    bloom:
+      capacity: 10000000
+      false_positive_rate: 0.001
+`,
+		)
+}
+
+func init() {
+	err := service.RegisterCache(
+		"bloom", bloomCacheConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Cache, error) {
+			return bloomCacheFromConfig(conf)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func bloomCacheFromConfig(conf *service.ParsedConfig) (*bloomCache, error) {
+	capacity, err := conf.FieldInt(bloomCacheFieldCapacity)
+	if err != nil {
+		return nil, err
+	}
+	fpRate, err := conf.FieldFloat(bloomCacheFieldFalsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+	return newBloomCache(capacity, fpRate)
+}
+
+//------------------------------------------------------------------------------
+
+// The second hash is seeded differently in order to derive k indexes from two
+// hashes of the key.
+const bloomSecondSeed = 0x9e3779b97f4a7c15
+
+type bloomFilter struct {
+	bits []uint64
+	m    uint64
+	k    uint64
+}
+
+func (b *bloomFilter) indexes(h1, h2 uint64, fn func(word, mask uint64) bool) bool {
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		if !fn(bit/64, uint64(1)<<(bit%64)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *bloomFilter) contains(h1, h2 uint64) bool {
+	return b.indexes(h1, h2, func(word, mask uint64) bool {
+		return b.bits[word]&mask != 0
+	})
+}
+
+func (b *bloomFilter) add(h1, h2 uint64) {
+	_ = b.indexes(h1, h2, func(word, mask uint64) bool {
+		b.bits[word] |= mask
+		return true
+	})
+}
+
+//------------------------------------------------------------------------------
+
+var errBloomNoValues = errors.New("values are not stored by bloom caches")
+
+type bloomCache struct {
+	capacity int
+	m, k     uint64
+
+	mut      sync.Mutex
+	count    int
+	current  *bloomFilter
+	previous *bloomFilter
+}
+
+func newBloomCache(capacity int, fpRate float64) (*bloomCache, error) {
+	if capacity <= 0 {
+		return nil, errors.New("capacity must be greater than zero")
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		return nil, errors.New("false_positive_rate must be between 0 and 1")
+	}
+
+	m := math.Ceil(-float64(capacity) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/float64(capacity)*math.Ln2))
+
+	c := &bloomCache{
+		capacity: capacity,
+		m:        uint64(m),
+		k:        uint64(k),
+	}
+	c.current = c.newFilter()
+	return c, nil
+}
+
+func (c *bloomCache) newFilter() *bloomFilter {
+	return &bloomFilter{
+		bits: make([]uint64, (c.m+63)/64),
+		m:    c.m,
+		k:    c.k,
+	}
+}
+
+func bloomHashes(key string) (h1, h2 uint64) {
+	// The second hash is forced odd so that it never degrades to zero, which
+	// would map all k indexes to the same bit.
+	return xxhash.ChecksumString64(key), xxhash.ChecksumString64S(key, bloomSecondSeed) | 1
+}
+
+func (c *bloomCache) containsLocked(h1, h2 uint64) bool {
+	if c.current.contains(h1, h2) {
+		return true
+	}
+	return c.previous != nil && c.previous.contains(h1, h2)
+}
+
+func (c *bloomCache) addLocked(h1, h2 uint64) {
+	c.current.add(h1, h2)
+	if c.count++; c.count >= c.capacity {
+		c.previous = c.current
+		c.current = c.newFilter()
+		c.count = 0
+	}
+}
+
+func (c *bloomCache) Get(_ context.Context, key string) ([]byte, error) {
+	h1, h2 := bloomHashes(key)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.containsLocked(h1, h2) {
+		return nil, errBloomNoValues
+	}
+	return nil, service.ErrKeyNotFound
+}
+
+func (c *bloomCache) Set(_ context.Context, key string, _ []byte, _ *time.Duration) error {
+	h1, h2 := bloomHashes(key)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if !c.current.contains(h1, h2) {
+		c.addLocked(h1, h2)
+	}
+	return nil
+}
+
+func (c *bloomCache) Add(_ context.Context, key string, _ []byte, _ *time.Duration) error {
+	h1, h2 := bloomHashes(key)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.containsLocked(h1, h2) {
+		return service.ErrKeyAlreadyExists
+	}
+	c.addLocked(h1, h2)
+	return nil
+}
+
+func (c *bloomCache) Delete(_ context.Context, _ string) error {
+	return errors.New("deleting keys is not supported by bloom caches")
+}
+
+func (c *bloomCache) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,88 @@
+package pure
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestBloomCacheAdd(t *testing.T) {
+	conf, err := bloomCacheConfig().ParseYAML(``, nil)
+	require.NoError(t, err)
+
+	c, err := bloomCacheFromConfig(conf)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = c.Get(ctx, "foo")
+	assert.Equal(t, service.ErrKeyNotFound, err)
+
+	require.NoError(t, c.Add(ctx, "foo", nil, nil))
+	assert.Equal(t, service.ErrKeyAlreadyExists, c.Add(ctx, "foo", nil, nil))
+	require.NoError(t, c.Add(ctx, "bar", nil, nil))
+
+	_, err = c.Get(ctx, "foo")
+	assert.Equal(t, errBloomNoValues, err)
+
+	require.NoError(t, c.Set(ctx, "baz", nil, nil))
+	assert.Equal(t, service.ErrKeyAlreadyExists, c.Add(ctx, "baz", nil, nil))
+
+	assert.Error(t, c.Delete(ctx, "foo"))
+	require.NoError(t, c.Close(ctx))
+}
+
+func TestBloomCacheFalsePositiveRate(t *testing.T) {
+	c, err := newBloomCache(10000, 0.01)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 0; i < 9999; i++ {
+		require.NoError(t, c.Set(ctx, "seen"+strconv.Itoa(i), nil, nil))
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if _, err := c.Get(ctx, "unseen"+strconv.Itoa(i)); err != service.ErrKeyNotFound {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 300)
+}
+
+func TestBloomCacheRotation(t *testing.T) {
+	c, err := newBloomCache(10, 0.01)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, c.Add(ctx, "first", nil, nil))
+	for i := 0; i < 9; i++ {
+		require.NoError(t, c.Add(ctx, "a"+strconv.Itoa(i), nil, nil))
+	}
+
+	// The first generation has been rotated but is still remembered.
+	assert.Equal(t, service.ErrKeyAlreadyExists, c.Add(ctx, "first", nil, nil))
+
+	for i := 0; i < 10; i++ {
+		_ = c.Add(ctx, "b"+strconv.Itoa(i), nil, nil)
+	}
+
+	// The first generation has now been discarded.
+	require.NoError(t, c.Add(ctx, "first", nil, nil))
+}
+
+func TestBloomCacheBadConfig(t *testing.T) {
+	_, err := newBloomCache(0, 0.01)
+	require.Error(t, err)
+
+	_, err = newBloomCache(10, 0)
+	require.Error(t, err)
+
+	_, err = newBloomCache(10, 1)
+	require.Error(t, err)
+}
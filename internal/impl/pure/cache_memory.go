@@ -29,7 +29,9 @@ cache_resources:
         foo: bar
 ` + "```" + `
 
-These values can be overridden during execution, at which point the configured TTL is respected as usual.`).
+These values can be overridden during execution, at which point the configured TTL is respected as usual.
+
+This cache does not limit the number of items it holds. In order to bound the memory used by a cache with a maximum number of items use the ` + "[`ttlru` cache](/docs/components/caches/ttlru)" + ` or the ` + "[`lru` cache](/docs/components/caches/lru)" + ` instead.`).
 		Field(service.NewDurationField("default_ttl").
 			Description("The default TTL of each item. After this period an item will be eligible for removal during the next compaction.").
 			Default("5m")).
@@ -220,7 +222,7 @@ func (m *memoryCache) Add(_ context.Context, key string, value []byte, ttl *time
 	}
 	shard := m.getShard(key)
 	shard.Lock()
-	if _, exists := shard.items[key]; exists {
+	if existing, exists := shard.items[key]; exists && !shard.isExpired(existing) {
 		shard.Unlock()
 		return service.ErrKeyAlreadyExists
 	}
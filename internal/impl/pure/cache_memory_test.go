@@ -175,6 +175,31 @@ compaction_interval: 1ns
 	}
 }
 
+func TestMemoryCacheAddExpired(t *testing.T) {
+	defConf, err := memCacheConfig().ParseYAML(`
+default_ttl: 10ms
+compaction_interval: 1h
+`, nil)
+	require.NoError(t, err)
+
+	c, err := newMemCacheFromConfig(defConf)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	require.NoError(t, c.Add(ctx, "foo", []byte("1"), nil))
+	assert.Equal(t, service.ErrKeyAlreadyExists, c.Add(ctx, "foo", []byte("2"), nil))
+
+	<-time.After(time.Millisecond * 50)
+
+	// The item has expired but has not yet been compacted.
+	require.NoError(t, c.Add(ctx, "foo", []byte("3"), nil))
+
+	act, err := c.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "3", string(act))
+}
+
 //------------------------------------------------------------------------------
 
 func BenchmarkMemoryShards1(b *testing.B) {
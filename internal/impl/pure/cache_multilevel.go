@@ -63,6 +63,7 @@ func init() {
 //------------------------------------------------------------------------------
 
 type cacheProvider interface {
+	HasCache(name string) bool
 	AccessCache(ctx context.Context, name string, fn func(c service.Cache)) error
 }
 
@@ -76,9 +77,11 @@ func newMultilevelCache(levels []string, mgr cacheProvider, log *service.Logger)
 	if len(levels) < 2 {
 		return nil, fmt.Errorf("expected at least two cache levels, found %v", len(levels))
 	}
-	// TODO: Probe caches
-	// for _, name := range levels {
-	// }
+	for _, name := range levels {
+		if !mgr.HasCache(name) {
+			return nil, fmt.Errorf("cache resource '%v' was not found", name)
+		}
+	}
 	return &multilevelCache{
 		mgr:    mgr,
 		log:    log,
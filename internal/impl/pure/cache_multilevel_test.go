@@ -100,6 +100,11 @@ type mockCacheProv struct {
 	caches map[string]service.Cache
 }
 
+func (m *mockCacheProv) HasCache(name string) bool {
+	_, ok := m.caches[name]
+	return ok
+}
+
 func (m *mockCacheProv) AccessCache(ctx context.Context, name string, fn func(c service.Cache)) error {
 	c, ok := m.caches[name]
 	if !ok {
@@ -109,6 +114,17 @@ func (m *mockCacheProv) AccessCache(ctx context.Context, name string, fn func(c
 	return nil
 }
 
+func TestMultilevelCacheMissingLevel(t *testing.T) {
+	p := &mockCacheProv{
+		caches: map[string]service.Cache{
+			"foo": newMemCache(time.Minute, 0, 1, nil),
+		},
+	}
+
+	_, err := newMultilevelCache([]string{"foo", "bar"}, p, nil)
+	require.EqualError(t, err, "cache resource 'bar' was not found")
+}
+
 func TestMultilevelCacheGetting(t *testing.T) {
 	memCache1 := newMemCache(time.Minute, 0, 1, nil)
 	memCache2 := newMemCache(time.Minute, 0, 1, nil)
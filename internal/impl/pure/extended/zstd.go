@@ -11,7 +11,14 @@ import (
 
 var _ = pure.AddCompressFunc("zstd", func(level int, b []byte) ([]byte, error) {
 	buf := &bytes.Buffer{}
-	w, err := zstd.NewWriter(buf)
+
+	var opts []zstd.EOption
+	if level > 0 {
+		// The default compression level is zstd.SpeedDefault
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+
+	w, err := zstd.NewWriter(buf, opts...)
 	if err != nil {
 		return nil, err
 	}
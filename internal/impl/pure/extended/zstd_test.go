@@ -1,11 +1,17 @@
 package extended
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
+	"github.com/benthosdev/benthos/v4/internal/component/processor"
+	"github.com/benthosdev/benthos/v4/internal/docs"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+	"github.com/benthosdev/benthos/v4/internal/message"
 	"github.com/benthosdev/benthos/v4/public/bloblang"
 )
 
@@ -29,3 +35,46 @@ func TestZstdCompressionDecompression(t *testing.T) {
 
 	assert.Equal(t, input, decompressed)
 }
+
+func TestZstdProcessors(t *testing.T) {
+	input := []byte("hello world this is a really long string, hello world this is a really long string")
+
+	for _, level := range []int{-1, 1, 19} {
+		compConf := processor.NewConfig()
+		compConf.Type = "compress"
+		compConf.Compress.Algorithm = "zstd"
+		compConf.Compress.Level = level
+
+		comp, err := mock.NewManager().NewProcessor(compConf)
+		require.NoError(t, err)
+
+		decompConf := processor.NewConfig()
+		decompConf.Type = "decompress"
+		decompConf.Decompress.Algorithm = "zstd"
+
+		decomp, err := mock.NewManager().NewProcessor(decompConf)
+		require.NoError(t, err)
+
+		msgs, res := comp.ProcessBatch(context.Background(), message.QuickBatch([][]byte{input}))
+		require.NoError(t, res)
+		require.Len(t, msgs, 1)
+		assert.NotEqual(t, input, msgs[0].Get(0).AsBytes())
+
+		msgs, res = decomp.ProcessBatch(context.Background(), msgs[0])
+		require.NoError(t, res)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, input, msgs[0].Get(0).AsBytes(), level)
+	}
+}
+
+func TestZstdProcessorsLint(t *testing.T) {
+	for _, typ := range []string{"compress", "decompress"} {
+		var node yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(typ+`:
+  algorithm: zstd
+`), &node))
+
+		lints := docs.LintYAML(docs.NewLintContext(docs.NewLintConfig()), docs.TypeProcessor, &node)
+		assert.Empty(t, lints, typ)
+	}
+}
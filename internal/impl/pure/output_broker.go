@@ -74,6 +74,12 @@ following their order. If an output applies back pressure it will block all
 subsequent messages. If an output fails to send a message then the message will
 be re-attempted with the next input, and so on.
 
+The field ` + "`weights`" + ` can be used in order to assign outputs a
+proportionally larger share of messages, which is useful when brokering across
+outputs with differing throughputs. For example, with the weights ` + "`[ 3, 1 ]`" + `
+the first output is sent three messages for every one sent to the second.
+Messages are interleaved across outputs rather than sent in bursts.
+
 ### ` + "`greedy`" + `
 
 The greedy pattern results in higher output throughput at the cost of
@@ -88,6 +94,7 @@ outputs.`,
 				"fan_out", "fan_out_sequential", "round_robin", "greedy",
 			).HasDefault("fan_out"),
 			docs.FieldOutput("outputs", "A list of child outputs to broker.").Array().HasDefault([]any{}),
+			docs.FieldInt("weights", "An optional list of relative weights, one for each child output, which determines the share of messages allocated to each output when using the `round_robin` pattern. When empty all outputs are weighted equally.", []int{3, 1}).Array().Advanced().HasDefault([]any{}),
 			policy.FieldSpec(),
 		),
 		Categories: []string{
@@ -119,6 +126,25 @@ func newBroker(conf output.Config, mgr bundle.NewManagement) (output.Streamed, e
 		return b, nil
 	}
 
+	var weights []int
+	if len(conf.Broker.Weights) > 0 {
+		if conf.Broker.Pattern != "round_robin" {
+			return nil, fmt.Errorf("weights are not supported with broker pattern %v", conf.Broker.Pattern)
+		}
+		if len(conf.Broker.Weights) != len(outputConfs) {
+			return nil, fmt.Errorf("number of weights (%v) does not match number of outputs (%v)", len(conf.Broker.Weights), len(outputConfs))
+		}
+		for i, w := range conf.Broker.Weights {
+			if w <= 0 {
+				return nil, fmt.Errorf("weight %v must be greater than zero, got %v", i, w)
+			}
+		}
+		weights = make([]int, lOutputs)
+		for j := 0; j < conf.Broker.Copies; j++ {
+			copy(weights[j*len(outputConfs):], conf.Broker.Weights)
+		}
+	}
+
 	outputs := make([]output.Streamed, lOutputs)
 
 	_, isRetryWrapped := map[string]struct{}{
@@ -150,7 +176,7 @@ func newBroker(conf output.Config, mgr bundle.NewManagement) (output.Streamed, e
 	case "fan_out_sequential":
 		b, err = newFanOutSequentialOutputBroker(outputs)
 	case "round_robin":
-		b, err = newRoundRobinOutputBroker(outputs)
+		b, err = newRoundRobinOutputBroker(outputs, weights)
 	case "greedy":
 		b, err = newGreedyOutputBroker(outputs)
 	default:
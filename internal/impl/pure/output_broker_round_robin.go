@@ -14,14 +14,16 @@ type roundRobinOutputBroker struct {
 
 	outputTSChans []chan message.Transaction
 	outputs       []output.Streamed
+	picker        *roundRobinPicker
 
 	shutSig *shutdown.Signaller
 }
 
-func newRoundRobinOutputBroker(outputs []output.Streamed) (*roundRobinOutputBroker, error) {
+func newRoundRobinOutputBroker(outputs []output.Streamed, weights []int) (*roundRobinOutputBroker, error) {
 	o := &roundRobinOutputBroker{
 		transactions: nil,
 		outputs:      outputs,
+		picker:       newRoundRobinPicker(len(outputs), weights),
 		shutSig:      shutdown.NewSignaller(),
 	}
 	o.outputTSChans = make([]chan message.Transaction, len(o.outputs))
@@ -34,6 +36,53 @@ func newRoundRobinOutputBroker(outputs []output.Streamed) (*roundRobinOutputBrok
 	return o, nil
 }
 
+// roundRobinPicker selects the output index that each message is allocated to.
+// When weights are provided the index is calculated with a smooth weighted
+// round robin so that heavier outputs are interleaved with lighter ones rather
+// than receiving their share in bursts. Each pick is calculated from a running
+// state rather than an expanded schedule, and therefore large weights do not
+// increase memory usage.
+type roundRobinPicker struct {
+	n       int
+	next    int
+	weights []int
+	current []int
+	total   int
+}
+
+func newRoundRobinPicker(n int, weights []int) *roundRobinPicker {
+	p := &roundRobinPicker{n: n}
+	if len(weights) != n {
+		return p
+	}
+	p.weights = weights
+	p.current = make([]int, n)
+	for _, w := range weights {
+		p.total += w
+	}
+	return p
+}
+
+func (p *roundRobinPicker) pick() int {
+	if p.weights == nil {
+		i := p.next
+		if p.next++; p.next >= p.n {
+			p.next = 0
+		}
+		return i
+	}
+
+	best := 0
+	for i, w := range p.weights {
+		p.current[i] += w
+		if p.current[i] > p.current[best] {
+			best = i
+		}
+	}
+	p.current[best] -= p.total
+	return best
+}
+
 func (o *roundRobinOutputBroker) Consume(ts <-chan message.Transaction) error {
 	if o.transactions != nil {
 		return component.ErrAlreadyStarted
@@ -62,7 +111,6 @@ func (o *roundRobinOutputBroker) loop() {
 		o.shutSig.ShutdownComplete()
 	}()
 
-	i := 0
 	var open bool
 	for {
 		var ts message.Transaction
@@ -75,15 +123,10 @@ func (o *roundRobinOutputBroker) loop() {
 			return
 		}
 		select {
-		case o.outputTSChans[i] <- ts:
+		case o.outputTSChans[o.picker.pick()] <- ts:
 		case <-o.shutSig.CloseNowChan():
 			return
 		}
-
-		i++
-		if i >= len(o.outputTSChans) {
-			i = 0
-		}
 	}
 }
 
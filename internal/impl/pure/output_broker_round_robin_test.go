@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component/output"
@@ -17,7 +18,7 @@ import (
 var _ output.Streamed = &roundRobinOutputBroker{}
 
 func TestRoundRobinDoubleClose(t *testing.T) {
-	oTM, err := newRoundRobinOutputBroker([]output.Streamed{})
+	oTM, err := newRoundRobinOutputBroker([]output.Streamed{}, nil)
 	if err != nil {
 		t.Error(err)
 		return
@@ -50,7 +51,7 @@ func TestBasicRoundRobin(t *testing.T) {
 	readChan := make(chan message.Transaction)
 	resChan := make(chan error)
 
-	oTM, err := newRoundRobinOutputBroker(outputs)
+	oTM, err := newRoundRobinOutputBroker(outputs, nil)
 	if err != nil {
 		t.Error(err)
 		return
@@ -104,6 +105,93 @@ func TestBasicRoundRobin(t *testing.T) {
 	require.NoError(t, oTM.WaitForClose(tCtx))
 }
 
+func TestRoundRobinPicker(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		weights  []int
+		schedule []int
+	}{
+		{name: "no weights", n: 3, schedule: []int{0, 1, 2}},
+		{name: "equal weights", n: 3, weights: []int{1, 1, 1}, schedule: []int{0, 1, 2}},
+		{name: "heavy first", n: 2, weights: []int{3, 1}, schedule: []int{0, 0, 1, 0}},
+		{name: "heavy last", n: 3, weights: []int{1, 1, 2}, schedule: []int{2, 0, 1, 2}},
+		{name: "interleaved", n: 2, weights: []int{2, 3}, schedule: []int{1, 0, 1, 0, 1}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := newRoundRobinPicker(test.n, test.weights)
+			for round := 0; round < 2; round++ {
+				schedule := make([]int, len(test.schedule))
+				for i := range schedule {
+					schedule[i] = p.pick()
+				}
+				assert.Equal(t, test.schedule, schedule)
+			}
+		})
+	}
+}
+
+func TestRoundRobinPickerLargeWeights(t *testing.T) {
+	p := newRoundRobinPicker(2, []int{1000000, 1})
+
+	counts := make([]int, 2)
+	for i := 0; i < 1000001; i++ {
+		counts[p.pick()]++
+	}
+	assert.Equal(t, []int{1000000, 1}, counts)
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	mockOutputs := []*mock.OutputChanneled{{}, {}}
+	outputs := []output.Streamed{mockOutputs[0], mockOutputs[1]}
+
+	readChan := make(chan message.Transaction)
+	resChan := make(chan error)
+
+	oTM, err := newRoundRobinOutputBroker(outputs, []int{3, 1})
+	require.NoError(t, err)
+	require.NoError(t, oTM.Consume(readChan))
+
+	expected := []int{0, 0, 1, 0, 0, 0, 1, 0}
+	for i, exp := range expected {
+		content := []byte(fmt.Sprintf("hello world %v", i))
+		select {
+		case readChan <- message.NewTransaction(message.QuickBatch([][]byte{content}), resChan):
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for broker send")
+		}
+
+		var ts message.Transaction
+		select {
+		case ts = <-mockOutputs[exp].TChan:
+			assert.Equal(t, content, ts.Payload.Get(0).AsBytes())
+		case <-mockOutputs[(exp+1)%2].TChan:
+			t.Fatalf("Received message %v on wrong output", i)
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for broker propagate")
+		}
+		go func() {
+			require.NoError(t, ts.Ack(tCtx, nil))
+		}()
+
+		select {
+		case res := <-resChan:
+			require.NoError(t, res)
+		case <-time.After(time.Second):
+			t.Fatal("Timed out responding to broker")
+		}
+	}
+
+	oTM.TriggerCloseNow()
+	require.NoError(t, oTM.WaitForClose(tCtx))
+}
+
 //------------------------------------------------------------------------------
 
 func BenchmarkBasicRoundRobin(b *testing.B) {
@@ -123,7 +211,7 @@ func BenchmarkBasicRoundRobin(b *testing.B) {
 	readChan := make(chan message.Transaction)
 	resChan := make(chan error)
 
-	oTM, err := newRoundRobinOutputBroker(outputs)
+	oTM, err := newRoundRobinOutputBroker(outputs, nil)
 	if err != nil {
 		b.Error(err)
 		return
@@ -179,6 +179,55 @@ broker:
 	}
 }
 
+func TestBrokerWeightsErrors(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		config      string
+		errContains string
+	}{
+		{
+			name: "wrong pattern",
+			config: `
+broker:
+  pattern: fan_out
+  weights: [ 2, 1 ]
+  outputs: [ { drop: {} }, { drop: {} } ]
+`,
+			errContains: "weights are not supported with broker pattern fan_out",
+		},
+		{
+			name: "mismatched count",
+			config: `
+broker:
+  pattern: round_robin
+  weights: [ 2, 1, 1 ]
+  outputs: [ { drop: {} }, { drop: {} } ]
+`,
+			errContains: "number of weights (3) does not match number of outputs (2)",
+		},
+		{
+			name: "zero weight",
+			config: `
+broker:
+  pattern: round_robin
+  weights: [ 2, 0 ]
+  outputs: [ { drop: {} }, { drop: {} } ]
+`,
+			errContains: "weight 1 must be greater than zero",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			conf := output.NewConfig()
+			require.NoError(t, yaml.Unmarshal([]byte(test.config), &conf))
+
+			_, err := mock.NewManager().NewOutput(conf)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.errContains)
+		})
+	}
+}
+
 func TestGreedyBroker(t *testing.T) {
 	dir := t.TempDir()
 
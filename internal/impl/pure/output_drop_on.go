@@ -8,6 +8,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/component/output/processors"
 	"github.com/benthosdev/benthos/v4/internal/docs"
@@ -25,11 +26,13 @@ func init() {
 		if err != nil {
 			return nil, err
 		}
-		return newDropOnWriter(c.DropOn.DropOnConditions, wrapped, nm.Logger())
+		return newDropOnWriter(c.DropOn.DropOnConditions, wrapped, nm.Logger(), nm.Metrics())
 	}), docs.ComponentSpec{
-		Name:        "drop_on",
-		Summary:     `Attempts to write messages to a child output and if the write fails for one of a list of configurable reasons the message is dropped instead of being reattempted.`,
-		Description: `Regular Benthos outputs will apply back pressure when downstream services aren't accessible, and Benthos retries (or nacks) all messages that fail to be delivered. However, in some circumstances, or for certain output types, we instead might want to relax these mechanisms, which is when this output becomes useful.`,
+		Name:    "drop_on",
+		Summary: `Attempts to write messages to a child output and if the write fails for one of a list of configurable reasons the message is dropped instead of being reattempted.`,
+		Description: `Regular Benthos outputs will apply back pressure when downstream services aren't accessible, and Benthos retries (or nacks) all messages that fail to be delivered. However, in some circumstances, or for certain output types, we instead might want to relax these mechanisms, which is when this output becomes useful.
+
+Each message that is dropped increments the counter metric ` + "`output_dropped`" + `, which can be used in order to monitor how often messages are being discarded.`,
 		Categories: []string{
 			"Utility",
 		},
@@ -80,7 +83,8 @@ output:
 //------------------------------------------------------------------------------
 
 type dropOnWriter struct {
-	log log.Modular
+	log      log.Modular
+	mDropped metrics.StatCounter
 
 	onError        bool
 	onBackpressure time.Duration
@@ -92,7 +96,7 @@ type dropOnWriter struct {
 	shutSig *shutdown.Signaller
 }
 
-func newDropOnWriter(conf output.DropOnConditions, wrapped output.Streamed, log log.Modular) (*dropOnWriter, error) {
+func newDropOnWriter(conf output.DropOnConditions, wrapped output.Streamed, log log.Modular, stats metrics.Type) (*dropOnWriter, error) {
 	var backPressure time.Duration
 	if len(conf.BackPressure) > 0 {
 		var err error
@@ -103,6 +107,7 @@ func newDropOnWriter(conf output.DropOnConditions, wrapped output.Streamed, log
 
 	return &dropOnWriter{
 		log:             log,
+		mDropped:        stats.GetCounter("output_dropped"),
 		wrapped:         wrapped,
 		transactionsOut: make(chan message.Transaction),
 
@@ -179,6 +184,7 @@ func (d *dropOnWriter) loop() {
 				if gotBackPressure {
 					d.log.Warnln("Message dropped due to back pressure.")
 					if d.onError {
+						d.mDropped.Incr(int64(ts.Payload.Len()))
 						res = nil
 					} else {
 						res = fmt.Errorf("experienced back pressure beyond: %v", d.onBackpressure)
@@ -205,6 +211,7 @@ func (d *dropOnWriter) loop() {
 
 		if res != nil && d.onError {
 			d.log.Warnf("Message dropped due to: %v\n", res)
+			d.mDropped.Incr(int64(ts.Payload.Len()))
 			res = nil
 		}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v3"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	bmock "github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -97,7 +98,11 @@ http_client:
 	dropConf.DropOn.Error = true
 	dropConf.DropOn.Output = &childConf
 
-	d, err := bmock.NewManager().NewOutput(dropConf)
+	stats := metrics.NewLocal()
+	mgr := bmock.NewManager()
+	mgr.M = stats
+
+	d, err := mgr.NewOutput(dropConf)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		ctx, done := context.WithTimeout(context.Background(), time.Second*30)
@@ -125,6 +130,7 @@ http_client:
 	}
 
 	assert.NoError(t, res)
+	assert.Equal(t, int64(1), stats.GetCounters()["output_dropped"])
 }
 
 func TestDropOnBackpressureWithErrors(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/component/output/processors"
 	"github.com/benthosdev/benthos/v4/internal/docs"
@@ -44,6 +45,26 @@ output:
 
 When a given output fails the message routed to the following output will have a metadata value named ` + "`fallback_error`" + ` containing a string error message outlining the cause of the failure. The content of this string will depend on the particular output and can be used to enrich the message or provide information used to broker the data to an appropriate output using something like a ` + "`switch`" + ` output.
 
+When the failed output is a ` + "[`retry` output](/docs/components/outputs/retry)" + ` that has exhausted its retry attempts the message will also have a metadata value named ` + "`fallback_attempts`" + ` containing the number of attempts that were made. This makes it possible to build a dead letter queue where messages are retried a bounded number of times before being routed elsewhere, along with the reason and attempt count of the failure:
+
+` + "```yaml" + `
+output:
+  fallback:
+    - retry:
+        max_retries: 5
+        output:
+          http_client:
+            url: http://foo:4195/post/might/become/unreachable
+    - kafka:
+        addresses: [ localhost:9092 ]
+        topic: dead_letters
+      processors:
+        - mapping: |
+            root.content = content().string()
+            root.reason = @fallback_error
+            root.attempts = @fallback_attempts
+` + "```" + `
+
 ### Batching
 
 When an output within a fallback sequence uses batching, like so:
@@ -65,7 +86,11 @@ output:
 
 Benthos makes a best attempt at inferring which specific messages of the batch failed, and only propagates those individual messages to the next fallback tier.
 
-However, depending on the output and the error returned it is sometimes not possible to determine the individual messages that failed, in which case the whole batch is passed to the next tier in order to preserve at-least-once delivery guarantees.`,
+However, depending on the output and the error returned it is sometimes not possible to determine the individual messages that failed, in which case the whole batch is passed to the next tier in order to preserve at-least-once delivery guarantees.
+
+### Metrics
+
+Each time messages are passed on to a subsequent tier the counter ` + "`output_fallback`" + ` is incremented by the number of messages, labelled with the index of the tier that failed. Ending a fallback sequence with a ` + "[`drop` output](/docs/components/outputs/drop)" + ` therefore allows messages that cannot be delivered anywhere to be discarded whilst still being counted.`,
 		Categories: []string{
 			"Utility",
 		},
@@ -94,7 +119,7 @@ func newFallback(conf output.Config, mgr bundle.NewManagement) (output.Streamed,
 	}
 
 	var t *fallbackBroker
-	if t, err = newFallbackBroker(outputs); err != nil {
+	if t, err = newFallbackBroker(outputs, mgr.Metrics()); err != nil {
 		return nil, err
 	}
 	return t, nil
@@ -106,13 +131,16 @@ type fallbackBroker struct {
 	outputTSChans []chan message.Transaction
 	outputs       []output.Streamed
 
+	mFallback metrics.StatCounterVec
+
 	shutSig *shutdown.Signaller
 }
 
-func newFallbackBroker(outputs []output.Streamed) (*fallbackBroker, error) {
+func newFallbackBroker(outputs []output.Streamed, stats metrics.Type) (*fallbackBroker, error) {
 	t := &fallbackBroker{
 		transactions: nil,
 		outputs:      outputs,
+		mFallback:    stats.GetCounterVec("output_fallback", "tier"),
 		shutSig:      shutdown.NewSignaller(),
 	}
 	if len(outputs) == 0 {
@@ -184,9 +212,15 @@ func (t *fallbackBroker) loop() {
 			if err == nil || len(t.outputTSChans) <= i {
 				return tran.Ack(ctx, err)
 			}
+			t.mFallback.With(strconv.Itoa(i - 1)).Incr(int64(tran.Payload.Len()))
+			var rErr *errRetriesExhausted
+			retriesExhausted := errors.As(err, &rErr)
 			newPayload := tran.Payload.ShallowCopy()
 			_ = newPayload.Iter(func(i int, p *message.Part) error {
 				p.MetaSetMut("fallback_error", err.Error())
+				if retriesExhausted {
+					p.MetaSetMut("fallback_attempts", int64(rErr.attempts))
+				}
 				return nil
 			})
 			select {
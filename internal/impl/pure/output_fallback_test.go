@@ -18,6 +18,7 @@ import (
 	yaml "gopkg.in/yaml.v3"
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -110,7 +111,7 @@ fallback:
 }
 
 func TestFallbackDoubleClose(t *testing.T) {
-	oTM, err := newFallbackBroker([]output.Streamed{&mock.OutputChanneled{}})
+	oTM, err := newFallbackBroker([]output.Streamed{&mock.OutputChanneled{}}, metrics.Noop())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -140,7 +141,7 @@ func TestFallbackHappyPath(t *testing.T) {
 	readChan := make(chan message.Transaction)
 	resChan := make(chan error)
 
-	oTM, err := newFallbackBroker(outputs)
+	oTM, err := newFallbackBroker(outputs, metrics.Noop())
 	if err != nil {
 		t.Error(err)
 		return
@@ -212,7 +213,8 @@ func TestFallbackHappyishPath(t *testing.T) {
 	readChan := make(chan message.Transaction)
 	resChan := make(chan error)
 
-	oTM, err := newFallbackBroker(outputs)
+	stats := metrics.NewLocal()
+	oTM, err := newFallbackBroker(outputs, stats)
 	if err != nil {
 		t.Error(err)
 		return
@@ -284,6 +286,66 @@ func TestFallbackHappyishPath(t *testing.T) {
 
 	close(readChan)
 	require.NoError(t, oTM.WaitForClose(tCtx))
+
+	assert.Equal(t, map[string]int64{
+		`output_fallback{tier="0"}`: 10,
+	}, stats.GetCounters())
+}
+
+func TestFallbackRetriesExhausted(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	mockOutputs := []*mock.OutputChanneled{{}, {}}
+	outputs := []output.Streamed{mockOutputs[0], mockOutputs[1]}
+
+	readChan := make(chan message.Transaction)
+	resChan := make(chan error)
+
+	oTM, err := newFallbackBroker(outputs, metrics.Noop())
+	require.NoError(t, err)
+	require.NoError(t, oTM.Consume(readChan))
+
+	select {
+	case readChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("hello")}), resChan):
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for broker send")
+	}
+
+	var ts message.Transaction
+	select {
+	case ts = <-mockOutputs[0].TChan:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for broker propagate")
+	}
+	go func() {
+		require.NoError(t, ts.Ack(tCtx, &errRetriesExhausted{attempts: 4, err: errors.New("test err")}))
+	}()
+
+	select {
+	case ts = <-mockOutputs[1].TChan:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for broker propagate")
+	}
+
+	p := ts.Payload.Get(0)
+	assert.Equal(t, "message failed to reach a target destination after 4 attempts: test err", p.MetaGetStr("fallback_error"))
+	attempts, _ := p.MetaGetMut("fallback_attempts")
+	assert.Equal(t, int64(4), attempts)
+
+	go func() {
+		require.NoError(t, ts.Ack(tCtx, nil))
+	}()
+
+	select {
+	case res := <-resChan:
+		require.NoError(t, res)
+	case <-time.After(time.Second):
+		t.Fatal("Timed out responding to broker")
+	}
+
+	close(readChan)
+	require.NoError(t, oTM.WaitForClose(tCtx))
 }
 
 func TestFallbackAllFail(t *testing.T) {
@@ -304,7 +366,8 @@ func TestFallbackAllFail(t *testing.T) {
 	readChan := make(chan message.Transaction)
 	resChan := make(chan error)
 
-	oTM, err := newFallbackBroker(outputs)
+	stats := metrics.NewLocal()
+	oTM, err := newFallbackBroker(outputs, stats)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -357,6 +420,11 @@ func TestFallbackAllFail(t *testing.T) {
 
 	oTM.TriggerCloseNow()
 	require.NoError(t, oTM.WaitForClose(tCtx))
+
+	assert.Equal(t, map[string]int64{
+		`output_fallback{tier="0"}`: 10,
+		`output_fallback{tier="1"}`: 10,
+	}, stats.GetCounters())
 }
 
 func TestFallbackAllFailParallel(t *testing.T) {
@@ -376,7 +444,7 @@ func TestFallbackAllFailParallel(t *testing.T) {
 
 	readChan := make(chan message.Transaction)
 
-	oTM, err := newFallbackBroker(outputs)
+	oTM, err := newFallbackBroker(outputs, metrics.Noop())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3,6 +3,7 @@ package pure
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/component/output/processors"
 	"github.com/benthosdev/benthos/v4/internal/docs"
@@ -118,7 +120,18 @@ we want to avoid reapplying to the same message more than once in the pipeline.
 
 Rather than retrying the same output you may wish to retry the send using a
 different output target (a dead letter queue). In which case you should instead
-use the ` + "[`fallback`](/docs/components/outputs/fallback)" + ` output type.`,
+use the ` + "[`fallback`](/docs/components/outputs/fallback)" + ` output type.
+
+When the retry limits are reached the message is rejected with an error
+containing the number of attempts made and the error of the final attempt. In
+order to drop such messages instead wrap this output with a
+` + "[`drop_on`](/docs/components/outputs/drop_on)" + ` output.
+
+### Metrics
+
+The counter ` + "`output_retry`" + ` is incremented each time a message is
+reattempted, and the counter ` + "`output_retry_exhausted`" + ` is incremented
+each time a message is rejected due to the retry limits being reached.`,
 		Config: docs.FieldComponent().WithChildren(
 			docs.FieldInt("max_retries", "The maximum number of retries before giving up on the request. If set to zero there is no discrete limit.").HasDefault(0).Advanced(),
 			docs.FieldObject("backoff", "Control time intervals between retry attempts.").WithChildren(
@@ -139,6 +152,23 @@ use the ` + "[`fallback`](/docs/components/outputs/fallback)" + ` output type.`,
 
 //------------------------------------------------------------------------------
 
+// errRetriesExhausted is returned by a retry output once all retry attempts of
+// a message have failed, and wraps the error of the final attempt.
+type errRetriesExhausted struct {
+	attempts int
+	err      error
+}
+
+func (e *errRetriesExhausted) Error() string {
+	return fmt.Sprintf("message failed to reach a target destination after %v attempts: %v", e.attempts, e.err)
+}
+
+func (e *errRetriesExhausted) Unwrap() error {
+	return e.err
+}
+
+//------------------------------------------------------------------------------
+
 // RetryOutputIndefinitely returns a wrapped variant of the provided output
 // where send errors downstream are automatically caught and retried rather than
 // propagated upstream as nacks.
@@ -173,8 +203,11 @@ func newIndefiniteRetry(mgr bundle.NewManagement, backoffCtor func() backoff.Bac
 		}
 	}
 
+	stats := mgr.Metrics()
 	return &indefiniteRetry{
 		log:             mgr.Logger(),
+		mRetry:          stats.GetCounter("output_retry"),
+		mExhausted:      stats.GetCounter("output_retry_exhausted"),
 		wrapped:         wrapped,
 		backoffCtor:     backoffCtor,
 		transactionsOut: make(chan message.Transaction),
@@ -188,7 +221,9 @@ type indefiniteRetry struct {
 	wrapped     output.Streamed
 	backoffCtor func() backoff.BackOff
 
-	log log.Modular
+	log        log.Modular
+	mRetry     metrics.StatCounter
+	mExhausted metrics.StatCounter
 
 	transactionsIn  <-chan message.Transaction
 	transactionsOut chan message.Transaction
@@ -249,6 +284,7 @@ func (r *indefiniteRetry) loop() {
 			var backOff backoff.BackOff
 			var resOut error
 			var inErrLoop bool
+			attempts := 0
 
 			defer func() {
 				wg.Done()
@@ -272,6 +308,7 @@ func (r *indefiniteRetry) loop() {
 					return
 				}
 
+				attempts++
 				if res != nil {
 					if !inErrLoop {
 						inErrLoop = true
@@ -285,7 +322,8 @@ func (r *indefiniteRetry) loop() {
 					nextBackoff := backOff.NextBackOff()
 					if nextBackoff == backoff.Stop {
 						r.log.Errorf("Failed to send message: %v\n", res)
-						resOut = errors.New("message failed to reach a target destination")
+						resOut = &errRetriesExhausted{attempts: attempts, err: res}
+						r.mExhausted.Incr(1)
 						break
 					} else {
 						r.log.Warnf("Failed to send message: %v\n", res)
@@ -296,6 +334,7 @@ func (r *indefiniteRetry) loop() {
 						return
 					}
 
+					r.mRetry.Incr(1)
 					select {
 					case r.transactionsOut <- message.NewTransaction(ts.Payload.ShallowCopy(), resChan):
 					case <-r.shutSig.CloseNowChan():
@@ -3,6 +3,7 @@ package pure
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -188,6 +190,77 @@ func TestRetrySadPath(t *testing.T) {
 	require.NoError(t, output.WaitForClose(ctx))
 }
 
+func TestRetryExhausted(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	conf := output.NewConfig()
+	conf.Type = "retry"
+
+	childConf := output.NewConfig()
+	conf.Retry.Output = &childConf
+	conf.Retry.MaxRetries = 2
+	conf.Retry.Backoff.InitialInterval = "10us"
+	conf.Retry.Backoff.MaxInterval = "10us"
+
+	stats := metrics.NewLocal()
+	mgr := mock.NewManager()
+	mgr.M = stats
+
+	output, err := bundle.AllOutputs.Init(conf, mgr)
+	require.NoError(t, err)
+
+	ret, ok := output.(*indefiniteRetry)
+	require.True(t, ok)
+
+	mOut := &mock.OutputChanneled{}
+	ret.wrapped = mOut
+
+	tChan := make(chan message.Transaction)
+	resChan := make(chan error)
+	require.NoError(t, ret.Consume(tChan))
+
+	go func() {
+		select {
+		case tChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("hello")}), resChan):
+		case <-time.After(time.Second):
+			t.Error("timed out")
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		var tran message.Transaction
+		select {
+		case tran = <-mOut.TChan:
+		case <-resChan:
+			t.Fatal("Received response not retry")
+		case <-time.After(time.Second):
+			t.Fatal("timed out")
+		}
+		require.NoError(t, tran.Ack(ctx, fmt.Errorf("nope %v", i)))
+	}
+
+	select {
+	case res := <-resChan:
+		require.Error(t, res)
+		assert.EqualError(t, res, "message failed to reach a target destination after 3 attempts: nope 2")
+
+		var rErr *errRetriesExhausted
+		require.True(t, errors.As(res, &rErr))
+		assert.Equal(t, 3, rErr.attempts)
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+	}
+
+	assert.Equal(t, map[string]int64{
+		"output_retry":           2,
+		"output_retry_exhausted": 1,
+	}, stats.GetCounters())
+
+	output.TriggerCloseNow()
+	require.NoError(t, output.WaitForClose(ctx))
+}
+
 func expectFromRetry(
 	resReturn error,
 	tChan <-chan message.Transaction,
@@ -86,7 +86,7 @@ behavior is false, which will drop the message.`,
 			).Array().WithChildren(
 				docs.FieldBloblang(
 					"check",
-					"A [Bloblang query](/docs/guides/bloblang/about/) that should return a boolean value indicating whether a message should be routed to the case output. If left empty the case always passes.",
+					"A [Bloblang query](/docs/guides/bloblang/about/) that should return a boolean value indicating whether a message should be routed to the case output. If left empty the case always passes. If the check mapping throws an error, for example when comparing a field that does not exist, the case does not pass and the error is logged.",
 					`this.type == "foo"`,
 					`this.contents.urls.contains("https://benthos.dev/")`,
 				).HasDefault(""),
@@ -176,6 +176,56 @@ output:
           gcp_pubsub:
             project: people
             topic: that_i_dont_want_to_hang_with
+`,
+			},
+			{
+				Title: "Numeric Thresholds",
+				Summary: `
+Checks are able to compare numbers and test for the presence of fields directly. In the following example orders with a total over 1000 are routed to a review queue, orders with a ` + "`null`" + ` or missing customer ID are routed to a dead letter queue, and everything else goes to a standard queue.
+
+Since comparing a value that is not a number (or is missing) results in an error we use ` + "`number()`" + ` to accept totals that are numeric strings, and ` + "`catch`" + ` to fall back to zero when the total is missing entirely. Fields that do not exist are compared as ` + "`null`" + `, in order to distinguish between the two use ` + "`this.exists(\"customer_id\")`" + `.`,
+				Config: `
+output:
+  switch:
+    cases:
+      - check: this.total.number().catch(0) > 1000
+        output:
+          nats:
+            urls: [ nats://127.0.0.1:4222 ]
+            subject: orders.review
+
+      - check: this.customer_id == null
+        output:
+          nats:
+            urls: [ nats://127.0.0.1:4222 ]
+            subject: orders.dlq
+
+      - output:
+          nats:
+            urls: [ nats://127.0.0.1:4222 ]
+            subject: orders.standard
+`,
+			},
+			{
+				Title: "Canary Routing",
+				Summary: `
+Checks can route a random percentage of messages by comparing a random number against a threshold. In the following example roughly 5% of messages are sent to a new version of a service and the remainder are sent to the current version. The seed of ` + "`random_int`" + ` is resolved once, and so we seed it with the current time in order for each instance of Benthos to produce a different sequence.
+
+In order to mirror a percentage of messages to an additional output, rather than dividing them, use a ` + "[`broker` output](/docs/components/outputs/broker)" + ` with the ` + "`fan_out`" + ` pattern and a ` + "[`sample` processor](/docs/components/processors/sample)" + ` on the additional output.`,
+				Config: `
+output:
+  switch:
+    cases:
+      - check: 'random_int(seed: timestamp_unix_nano(), max: 99) < 5'
+        output:
+          http_client:
+            url: http://canary.example.com/events
+            verb: POST
+
+      - output:
+          http_client:
+            url: http://stable.example.com/events
+            verb: POST
 `,
 			},
 		},
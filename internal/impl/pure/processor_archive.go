@@ -6,9 +6,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
+	"github.com/klauspost/compress/gzip"
+
 	"github.com/benthosdev/benthos/v4/internal/batch"
 	"github.com/benthosdev/benthos/v4/internal/message"
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -28,6 +31,7 @@ The functionality of this processor depends on being applied across messages tha
 		Field(service.NewStringAnnotatedEnumField("format", map[string]string{
 			`concatenate`: `Join the raw contents of each message into a single binary message.`,
 			`tar`:         `Archive messages to a unix standard tape archive.`,
+			`tar_gzip`:    `Archive messages to a unix standard tape archive compressed with gzip.`,
 			`zip`:         `Archive messages to a zip file.`,
 			`binary`:      `Archive messages to a [binary blob format](https://github.com/benthosdev/benthos/blob/main/internal/message/message.go#L96).`,
 			`lines`:       `Join the raw contents of each message and insert a line break between each one.`,
@@ -52,6 +56,14 @@ pipeline:
     - archive:
         format: tar
         path: ${!json("doc.id")}.json
+`).
+		Example("Merging JSON Documents", `
+The `+"`json_array`"+` format can be combined with a `+"[`mapping` processor](/docs/components/processors/mapping)"+` in order to merge all JSON documents of a batch into a single document. Objects are merged deeply, and when fields collide their values are combined into an array:`, `
+pipeline:
+  processors:
+    - archive:
+        format: json_array
+    - mapping: 'root = this.fold({}, item -> item.tally.merge(item.value))'
 `)
 }
 
@@ -72,28 +84,48 @@ type archiveFunc func(hFunc headerFunc, msg service.MessageBatch) (*service.Mess
 
 type headerFunc func(index int, body *service.Message) os.FileInfo
 
-func tarArchive(hFunc headerFunc, msg service.MessageBatch) (*service.Message, error) {
-	buf := &bytes.Buffer{}
-	tw := tar.NewWriter(buf)
+func writeTarArchive(w io.Writer, hFunc headerFunc, msg service.MessageBatch) error {
+	tw := tar.NewWriter(w)
 
 	for i, part := range msg {
 		hdr, err := tar.FileInfoHeader(hFunc(i, part), "")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if err := tw.WriteHeader(hdr); err != nil {
-			return nil, err
+			return err
 		}
 		pBytes, err := part.AsBytes()
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if _, err := tw.Write(pBytes); err != nil {
-			return nil, err
+			return err
 		}
 	}
+	return tw.Close()
+}
+
+func tarArchive(hFunc headerFunc, msg service.MessageBatch) (*service.Message, error) {
+	buf := &bytes.Buffer{}
+	if err := writeTarArchive(buf, hFunc, msg); err != nil {
+		return nil, err
+	}
+
+	msg[0].SetBytes(buf.Bytes())
+	return msg[0], nil
+}
+
+func tarGzipArchive(hFunc headerFunc, msg service.MessageBatch) (*service.Message, error) {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	if err := writeTarArchive(gw, hFunc, msg); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
 
-	tw.Close()
 	msg[0].SetBytes(buf.Bytes())
 	return msg[0], nil
 }
@@ -185,6 +217,8 @@ func strToArchiver(str string) (archiveFunc, error) {
 	switch str {
 	case "tar":
 		return tarArchive, nil
+	case "tar_gzip":
+		return tarGzipArchive, nil
 	case "zip":
 		return zipArchive, nil
 	case "binary":
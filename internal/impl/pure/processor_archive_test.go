@@ -9,6 +9,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/klauspost/compress/gzip"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -83,6 +84,63 @@ path: 'foo-${!meta("path")}'
 	assert.Equal(t, len(exp), i)
 }
 
+func TestArchiveTarGzip(t *testing.T) {
+	conf, err := archiveProcConfig().ParseYAML(`
+format: tar_gzip
+path: 'foo-${!meta("path")}'
+`, nil)
+	require.NoError(t, err)
+
+	exp := [][]byte{
+		[]byte("hello world first part"),
+		[]byte("hello world second part"),
+		[]byte("third part"),
+	}
+
+	proc, err := newArchiveFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+
+	var msg service.MessageBatch
+	for i, e := range exp {
+		p := service.NewMessage(e)
+		p.MetaSet("path", fmt.Sprintf("bar%v", i))
+		msg = append(msg, p)
+	}
+
+	batches, err := proc.ProcessBatch(context.Background(), msg)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 1)
+
+	bBytes, err := batches[0][0].AsBytes()
+	require.NoError(t, err)
+
+	gr, err := gzip.NewReader(bytes.NewReader(bBytes))
+	require.NoError(t, err)
+
+	tr := tar.NewReader(gr)
+	i := 0
+	for {
+		var hdr *tar.Header
+		hdr, err = tr.Next()
+		if err == io.EOF {
+			// end of tar archive
+			break
+		}
+		require.NoError(t, err)
+
+		newPartBuf := bytes.Buffer{}
+		_, err = newPartBuf.ReadFrom(tr)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(exp[i]), newPartBuf.String())
+		assert.Equal(t, fmt.Sprintf("foo-bar%v", i), hdr.FileInfo().Name())
+		i++
+	}
+
+	assert.Equal(t, len(exp), i)
+}
+
 func TestArchiveZip(t *testing.T) {
 	conf, err := archiveProcConfig().ParseYAML(`
 format: zip
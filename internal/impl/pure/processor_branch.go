@@ -95,7 +95,17 @@ order to filter, DLQ or recover the failed messages.
 
 If the root of your request map is set to ` + "`deleted()`" + ` then the branch
 processors are skipped for the given message, this allows you to conditionally
-branch messages.`,
+branch messages.
+
+### Optional Fields
+
+A ` + "`request_map`" + ` that references a field missing from a message fails,
+which flags the message as having failed. When the field is optional you can
+instead fall back to ` + "`deleted()`" + ` with ` + "`root = this.foo | deleted()`" + `,
+which skips the branch for that message. Similarly, a ` + "`result_map`" + ` such
+as ` + "`root.bar = this.bar | deleted()`" + ` does not fail when the result lacks
+the field, although note that assigning ` + "`deleted()`" + ` removes any existing
+field ` + "`bar`" + ` from the original message.`,
 		Examples: []docs.AnnotatedExample{
 			{
 				Title: "HTTP Request",
@@ -136,6 +146,24 @@ pipeline:
 
 # Example input:  {"document":{"id":"foo","content":"hello world"}}
 # Example output: {"document":{"id":"foo","content":"hello world","description":"this is a cool doc"}}
+`,
+			},
+			{
+				Title: "Processing a Single Field",
+				Summary: `
+Processors that operate on the entire contents of a message can be applied to a single field by mapping that field out as the request and then writing the result back into it. In this example the field ` + "`payload`" + ` contains base64 encoded gzip data, which we decompress in place whilst preserving the rest of the document:`,
+				Config: `
+pipeline:
+  processors:
+    - branch:
+        request_map: 'root = this.payload.decode("base64")'
+        processors:
+          - decompress:
+              algorithm: gzip
+        result_map: 'root.payload = content().string()'
+
+# Example input:  {"id":"foo","payload":"H4sIAAAAAAAA/8pIzcnJVyjPL8pJAQQAAP//hRFKDQsAAAA="}
+# Example output: {"id":"foo","payload":"hello world"}
 `,
 			},
 			{
@@ -458,14 +486,14 @@ func (b *Branch) createResult(ctx context.Context, parts []*message.Part, refere
 	return alignedResult, mapErrs, nil
 }
 
-// overlayResult attempts to merge the result of a process_map with the original
-// payload as per the map specified in the postmap and postmap_optional fields.
+// overlayResult attempts to merge the result of a branch with the original
+// payload as per the result_map field.
 func (b *Branch) overlayResult(payload message.Batch, results []*message.Part) ([]branchMapError, error) {
 	if exp, act := payload.Len(), len(results); exp != act {
 		b.mError.Incr(1)
 		return nil, fmt.Errorf(
 			"message count returned from branch has diverged from the request, started with %v messages, finished with %v",
-			act, exp,
+			exp, act,
 		)
 	}
 
@@ -517,7 +545,7 @@ func alignBranchResult(length int, skipped, failed []int, result []message.Batch
 	if rLen, pLen := len(resMsgParts)+len(skippedOrFailed), length; rLen != pLen {
 		return nil, fmt.Errorf(
 			"message count from branch processors does not match request, started with %v messages, finished with %v",
-			rLen, pLen,
+			pLen, rLen,
 		)
 	}
 
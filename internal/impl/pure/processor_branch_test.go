@@ -176,6 +176,19 @@ func TestBranchBasic(t *testing.T) {
 				msg(`{"id":4,"name":"fifth"}`).withErr(errors.New("child processors resulted in zero messages")),
 			},
 		},
+		"optional request field": {
+			requestMap:   `root = this.value | deleted()`,
+			processorMap: `root.upper = content().uppercase().string()`,
+			resultMap:    `root.result = this.upper | deleted()`,
+			input: []mockMsg{
+				msg(`{"id":0,"value":"first"}`),
+				msg(`{"id":1}`),
+			},
+			output: []mockMsg{
+				msg(`{"id":0,"result":"FIRST","value":"first"}`),
+				msg(`{"id":1}`),
+			},
+		},
 		"filter some during processing": {
 			requestMap:   `root = if this.id == 3 { throw("foo") } else { this }`,
 			processorMap: `root = if this.id == 2 { deleted() }`,
@@ -188,11 +201,11 @@ func TestBranchBasic(t *testing.T) {
 				msg(`{"id":4,"name":"fifth"}`),
 			},
 			output: []mockMsg{
-				msg(`{"id":0,"name":"first"}`).withErr(errors.New("message count from branch processors does not match request, started with 4 messages, finished with 5")),
-				msg(`{"id":1,"name":"second"}`).withErr(errors.New("message count from branch processors does not match request, started with 4 messages, finished with 5")),
-				msg(`{"id":2,"name":"third"}`).withErr(errors.New("message count from branch processors does not match request, started with 4 messages, finished with 5")),
+				msg(`{"id":0,"name":"first"}`).withErr(errors.New("message count from branch processors does not match request, started with 5 messages, finished with 4")),
+				msg(`{"id":1,"name":"second"}`).withErr(errors.New("message count from branch processors does not match request, started with 5 messages, finished with 4")),
+				msg(`{"id":2,"name":"third"}`).withErr(errors.New("message count from branch processors does not match request, started with 5 messages, finished with 4")),
 				msg(`{"id":3,"name":"fourth"}`).withErr(errors.New("request mapping failed: failed assignment (line 1): foo")),
-				msg(`{"id":4,"name":"fifth"}`).withErr(errors.New("message count from branch processors does not match request, started with 4 messages, finished with 5")),
+				msg(`{"id":4,"name":"fifth"}`).withErr(errors.New("message count from branch processors does not match request, started with 5 messages, finished with 4")),
 			},
 		},
 	}
@@ -272,3 +285,26 @@ func TestBranchBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestBranchSingleField(t *testing.T) {
+	conf := parseYAMLConf(t, `
+branch:
+  request_map: 'root = this.payload.decode("base64")'
+  processors:
+    - decompress:
+        algorithm: gzip
+  result_map: 'root.payload = content().string()'
+`)
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	outMsgs, res := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte(`{"id":"foo","payload":"H4sIAAAAAAAA/8pIzcnJVyjPL8pJAQQAAP//hRFKDQsAAAA="}`),
+	}))
+	require.NoError(t, res)
+	require.Len(t, outMsgs, 1)
+	assert.Equal(t, [][]byte{
+		[]byte(`{"id":"foo","payload":"hello world"}`),
+	}, message.GetAllBytes(outMsgs[0]))
+}
@@ -90,6 +90,31 @@ pipeline:
         root = if errored().from(0) {
           deleted()
         }
+`,
+			},
+			{
+				Title: "Stashing State",
+				Summary: `
+Values can be stored in a cache for later retrieval, optionally with a per-key
+TTL, by using the ` + "`set`" + ` operator within a [` + "`branch`" + `](/docs/components/processors/branch)
+processor so that the original message payload is left untouched:`,
+				Config: `
+pipeline:
+  processors:
+    - branch:
+        request_map: 'root = this.user'
+        processors:
+          - cache:
+              resource: foocache
+              operator: set
+              key: '${! json("id") }'
+              value: '${! content() }'
+              ttl: '${! meta("user_ttl").or("1h") }'
+
+cache_resources:
+  - label: foocache
+    memory:
+      default_ttl: 24h
 `,
 			},
 			{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -202,3 +203,46 @@ func TestCacheDelete(t *testing.T) {
 	_, ok = mgr.Caches["foocache"]["3"]
 	require.False(t, ok)
 }
+
+func TestCacheSetTTL(t *testing.T) {
+	mgr := mock.NewManager()
+	mgr.Caches["foocache"] = map[string]mock.CacheItem{}
+
+	conf := processor.NewConfig()
+	conf.Type = "cache"
+	conf.Cache.Operator = "set"
+	conf.Cache.Key = "${!json(\"key\")}"
+	conf.Cache.Value = "${!json(\"value\")}"
+	conf.Cache.TTL = "${!json(\"ttl\")}"
+	conf.Cache.Resource = "foocache"
+	proc, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	input := message.QuickBatch([][]byte{
+		[]byte(`{"key":"1","value":"foo 1","ttl":"5m"}`),
+		[]byte(`{"key":"2","value":"foo 2","ttl":""}`),
+		[]byte(`{"key":"3","value":"foo 3","ttl":"nope"}`),
+	})
+
+	output, res := proc.ProcessBatch(context.Background(), input)
+	require.NoError(t, res)
+	require.Len(t, output, 1)
+
+	assert.NoError(t, output[0].Get(0).ErrorGet())
+	assert.NoError(t, output[0].Get(1).ErrorGet())
+	assert.Error(t, output[0].Get(2).ErrorGet())
+
+	actV, ok := mgr.Caches["foocache"]["1"]
+	require.True(t, ok)
+	assert.Equal(t, "foo 1", actV.Value)
+	require.NotNil(t, actV.TTL)
+	assert.Equal(t, 5*time.Minute, *actV.TTL)
+
+	actV, ok = mgr.Caches["foocache"]["2"]
+	require.True(t, ok)
+	assert.Equal(t, "foo 2", actV.Value)
+	assert.Nil(t, actV.TTL)
+
+	_, ok = mgr.Caches["foocache"]["3"]
+	require.False(t, ok)
+}
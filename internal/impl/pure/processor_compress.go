@@ -24,11 +24,11 @@ func init() {
 		},
 		Summary: `
 Compresses messages according to the selected algorithm. Supported compression
-algorithms are: gzip, pgzip, zlib, flate, snappy, lz4.`,
+algorithms are: gzip, pgzip, zlib, flate, snappy, lz4, zstd.`,
 		Description: `
 The 'level' field might not apply to all algorithms.`,
 		Config: docs.FieldComponent().WithChildren(
-			docs.FieldString("algorithm", "The compression algorithm to use.").HasOptions("gzip", "pgzip", "zlib", "flate", "snappy", "lz4"),
+			docs.FieldString("algorithm", "The compression algorithm to use. The `zstd` algorithm is only available in builds that include the `public/components/pure/extended` package, such as the standard Benthos distribution.").HasOptions("gzip", "pgzip", "zlib", "flate", "snappy", "lz4", "zstd"),
 			docs.FieldInt("level", "The level of compression to use. May not be applicable to all algorithms."),
 		).ChildDefaultAndTypesFromStruct(processor.NewCompressConfig()),
 	})
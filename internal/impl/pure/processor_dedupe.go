@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/benthosdev/benthos/v4/internal/bloblang/field"
 	"github.com/benthosdev/benthos/v4/internal/bundle"
@@ -29,7 +30,7 @@ func init() {
 		},
 		Summary: `Deduplicates messages by storing a key value in a cache using the ` + "`add`" + ` operator. If the key already exists within the cache it is dropped.`,
 		Description: `
-Caches must be configured as resources, for more information check out the [cache documentation here](/docs/components/caches/about).
+Caches must be configured as resources, for more information check out the [cache documentation here](/docs/components/caches/about). For high volume streams where storing every key would not fit in memory the ` + "[`bloom` cache](/docs/components/caches/bloom)" + ` provides deduplication with bounded memory at the cost of occasional false positives.
 
 When using this processor with an output target that might fail you should always wrap the output within an indefinite ` + "[`retry`](/docs/components/outputs/retry)" + ` block. This ensures that during outages your messages aren't reprocessed after failures, which would result in messages being dropped.
 
@@ -46,6 +47,10 @@ This problem can be mitigated by using an in-memory cache and distributing messa
 			docs.FieldString("cache", "The [`cache` resource](/docs/components/caches/about) to target with this processor."),
 			docs.FieldString("key", "An interpolated string yielding the key to deduplicate by for each message.", `${! meta("kafka_key") }`, `${! content().hash("xxhash64") }`).IsInterpolated(),
 			docs.FieldBool("drop_on_err", "Whether messages should be dropped when the cache returns a general error such as a network issue."),
+			docs.FieldString(
+				"ttl", "An optional duration string that determines how long a key is remembered for, after which a message with the same key is no longer considered a duplicate. When left empty the default TTL of the cache resource is used. Not all caches support per-key TTLs, those that do will have a configuration field `default_ttl`, and those that do not will fall back to their generally configured TTL setting.",
+				"60s", "5m", "36h",
+			).AtVersion("4.18.0").Advanced(),
 		).ChildDefaultAndTypesFromStruct(processor.NewDedupeConfig()),
 		Examples: []docs.AnnotatedExample{
 			{
@@ -78,6 +83,7 @@ type dedupeProc struct {
 	key       *field.Expression
 	mgr       bundle.NewManagement
 	cacheName string
+	ttl       *time.Duration
 }
 
 func newDedupe(conf processor.DedupeConfig, mgr bundle.NewManagement) (*dedupeProc, error) {
@@ -93,12 +99,22 @@ func newDedupe(conf processor.DedupeConfig, mgr bundle.NewManagement) (*dedupePr
 		return nil, fmt.Errorf("cache resource '%v' was not found", conf.Cache)
 	}
 
+	var ttl *time.Duration
+	if conf.TTL != "" {
+		td, err := time.ParseDuration(conf.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ttl: %w", err)
+		}
+		ttl = &td
+	}
+
 	return &dedupeProc{
 		log:       mgr.Logger(),
 		dropOnErr: conf.DropOnCacheErr,
 		key:       key,
 		mgr:       mgr,
 		cacheName: conf.Cache,
+		ttl:       ttl,
 	}, nil
 }
 
@@ -115,7 +131,7 @@ func (d *dedupeProc) ProcessBatch(ctx *processor.BatchProcContext, batch message
 		}
 
 		if cerr := d.mgr.AccessCache(context.Background(), d.cacheName, func(cache cache.V1) {
-			err = cache.Add(context.Background(), key, []byte{'t'}, nil)
+			err = cache.Add(context.Background(), key, []byte{'t'}, d.ttl)
 		}); cerr != nil {
 			err = cerr
 		}
@@ -3,6 +3,7 @@ package pure_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,3 +96,30 @@ func TestDedupeCacheErrors(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, msgs, 1)
 }
+
+func TestDedupeTTL(t *testing.T) {
+	mgr := mock.NewManager()
+	mgr.Caches["foocache"] = map[string]mock.CacheItem{}
+
+	conf := processor.NewConfig()
+	conf.Type = "dedupe"
+	conf.Dedupe.Cache = "foocache"
+	conf.Dedupe.Key = "${! content() }"
+	conf.Dedupe.TTL = "5m"
+
+	proc, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgOut, err := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{[]byte("foo")}))
+	require.NoError(t, err)
+	require.Len(t, msgOut, 1)
+
+	item, exists := mgr.Caches["foocache"]["foo"]
+	require.True(t, exists)
+	require.NotNil(t, item.TTL)
+	assert.Equal(t, 5*time.Minute, *item.TTL)
+
+	conf.Dedupe.TTL = "not a duration"
+	_, err = mgr.NewProcessor(conf)
+	require.Error(t, err)
+}
@@ -28,13 +28,31 @@ A processor that applies a list of child processors to messages of a batch as
 though they were each a batch of one message.`,
 		Description: `
 This is useful for forcing batch wide processors such as
-` + "[`dedupe`](/docs/components/processors/dedupe)" + ` or interpolations such
-as the ` + "`value`" + ` field of the ` + "`metadata`" + ` processor to execute
-on individual message parts of a batch instead.
+` + "[`archive`](/docs/components/processors/archive)" + ` or interpolations
+and mappings that use batch functions such as ` + "`batch_index()`" + ` and
+` + "`batch_size()`" + ` to execute on individual message parts of a batch
+instead.
 
 Please note that most processors already process per message of a batch, and
 this processor is not needed in those cases.`,
 		Config: docs.FieldProcessor("", "").Array().HasDefault([]any{}),
+		Examples: []docs.AnnotatedExample{
+			{
+				Title: "Transforming Nested Arrays",
+				Summary: `
+Given a batch of messages that each contain a JSON array of items, we can expand each array into individual messages, transform them, and then reassemble them back into one array per original message. Without the ` + "`for_each`" + ` processor the ` + "`archive`" + ` processor would instead combine the items of every message in the batch into a single array:`,
+				Config: `
+pipeline:
+  processors:
+    - for_each:
+      - unarchive:
+          format: json_array
+      - mapping: 'root = this.merge({ "position": batch_index() })'
+      - archive:
+          format: json_array
+`,
+			},
+		},
 	})
 	if err != nil {
 		panic(err)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
@@ -179,3 +180,28 @@ func TestForEachFilterAll(t *testing.T) {
 		t.Errorf("Wrong count of result msgs: %v", len(msgs))
 	}
 }
+
+func TestForEachArchivePerMessage(t *testing.T) {
+	conf := parseYAMLConf(t, `
+for_each:
+  - unarchive:
+      format: json_array
+  - mapping: 'root = this.merge({ "position": batch_index() })'
+  - archive:
+      format: json_array
+`)
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte(`[{"id":"a"},{"id":"b"}]`),
+		[]byte(`[{"id":"c"}]`),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, [][]byte{
+		[]byte(`[{"id":"a","position":0},{"id":"b","position":1}]`),
+		[]byte(`[{"id":"c","position":0}]`),
+	}, message.GetAllBytes(msgs[0]))
+}
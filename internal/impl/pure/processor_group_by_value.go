@@ -29,14 +29,11 @@ func init() {
 This allows you to group messages using arbitrary fields within their content or metadata, process them individually, and send them to unique locations as per their group.
 
 The functionality of this processor depends on being applied across messages that are batched. You can find out more about batching [in this doc](/docs/configuration/batching).`,
-		Footnotes: `
-## Examples
-
-If we were consuming Kafka messages and needed to group them by their key,
-archive the groups, and send them to S3 with the key as part of the path we
-could achieve that with the following:
-
-` + "```yaml" + `
+		Examples: []docs.AnnotatedExample{
+			{
+				Title:   "Grouped Archives",
+				Summary: "If we were consuming Kafka messages and needed to group them by their key, archive the groups, and send them to S3 with the key as part of the path we could achieve that with the following:",
+				Config: `
 pipeline:
   processors:
     - group_by_value:
@@ -49,7 +46,28 @@ output:
   aws_s3:
     bucket: TODO
     path: docs/${! meta("kafka_key") }/${! count("files") }-${! timestamp_unix_nano() }.tar.gz
-` + "```" + ``,
+`,
+			},
+			{
+				Title:   "Grouping by Field",
+				Summary: "When grouping by a field of the message contents the group value is lost once the messages are archived. Copying the value into a metadata field beforehand allows it to be referenced when writing each group, since an archived message adopts the metadata of the first message of its batch:",
+				Config: `
+pipeline:
+  processors:
+    - mapping: |
+        root = this
+        meta tenant = this.tenant.id
+    - group_by_value:
+        value: ${! meta("tenant") }
+    - archive:
+        format: json_array
+output:
+  aws_s3:
+    bucket: TODO
+    path: tenants/${! meta("tenant") }/${! timestamp_unix_nano() }.json
+`,
+			},
+		},
 		Config: docs.FieldComponent().WithChildren(
 			docs.FieldString(
 				"value", "The interpolated string to group based on.",
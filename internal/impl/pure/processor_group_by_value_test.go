@@ -70,3 +70,44 @@ func TestGroupByValueBasic(t *testing.T) {
 		t.Errorf("Wrong result: %s != %s", act, exp)
 	}
 }
+
+func TestGroupByValueMetadata(t *testing.T) {
+	conf := processor.NewConfig()
+	conf.Type = "group_by_value"
+	conf.GroupByValue.Value = "${! meta(\"tenant\") }"
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := message.QuickBatch([][]byte{
+		[]byte(`foo`),
+		[]byte(`bar`),
+		[]byte(`baz`),
+		[]byte(`qux`),
+	})
+	for i, tenant := range []string{"a", "b", "a", ""} {
+		if tenant != "" {
+			input.Get(i).MetaSetMut("tenant", tenant)
+		}
+	}
+
+	msgs, res := proc.ProcessBatch(context.Background(), input)
+	if res != nil {
+		t.Fatal(res)
+	}
+
+	exp := [][][]byte{
+		{[]byte(`foo`), []byte(`baz`)},
+		{[]byte(`bar`)},
+		{[]byte(`qux`)},
+	}
+	act := [][][]byte{}
+	for _, msg := range msgs {
+		act = append(act, message.GetAllBytes(msg))
+	}
+	if !reflect.DeepEqual(exp, act) {
+		t.Errorf("Wrong result: %s != %s", act, exp)
+	}
+}
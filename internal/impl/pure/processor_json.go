@@ -0,0 +1,257 @@
+package pure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Jeffail/gabs/v2"
+
+	"github.com/benthosdev/benthos/v4/internal/message"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	jpFieldOperator = "operator"
+	jpFieldPath     = "path"
+	jpFieldValue    = "value"
+)
+
+func jsonProcSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.18.0").
+		Categories("Mapping").
+		Summary("Performs a structural operation on a JSON document at a given dot path.").
+		Description(`
+Paths are specified in dot notation, where array elements are targeted by their index (e.g. `+"`foo.bar.0`"+`), and an empty path targets the root of the document.
+
+The `+"`value`"+` field supports [interpolation functions](/docs/configuration/interpolation#bloblang-queries). For the `+"`set`"+` and `+"`append`"+` operators the resolved value is parsed as JSON, and when it is not valid JSON it is used as a string instead. For the `+"`move`"+` and `+"`copy`"+` operators the resolved value is the destination path.
+
+If an operation fails, for example when a path to move or select does not exist, the message is flagged as having failed, allowing you to use [error handling patterns](/docs/configuration/error_handling).
+
+:::note Try out Bloblang
+Operations more complex than these can be expressed with the [`+"`mapping`"+` processor](/docs/components/processors/mapping).
+:::`).
+		Fields(
+			service.NewStringAnnotatedEnumField(jpFieldOperator, map[string]string{
+				"set":     "Sets the value at the path, replacing any existing value.",
+				"delete":  "Removes the value at the path.",
+				"move":    "Moves the value at the path to the destination path given by `value`.",
+				"copy":    "Copies the value at the path to the destination path given by `value`.",
+				"append":  "Appends the value to an array at the path, creating the array if it does not exist. If the existing value is not an array it is converted into one, and if the appended value is an array its elements are appended individually.",
+				"select":  "Replaces the document with the value at the path.",
+				"flatten": "Replaces the object or array at the path with an object of key/value pairs for each field, where the key is the full dot path of the field relative to the path.",
+			}).Description("The operation to perform."),
+			service.NewStringField(jpFieldPath).
+				Description("The dot path to operate on.").
+				Examples("foo.bar", "foo.bar.0", "").
+				Default(""),
+			service.NewInterpolatedStringField(jpFieldValue).
+				Description("A value to use with the operator, for `set` and `append` this is the value to write, for `move` and `copy` this is the destination path.").
+				Examples(`{"foo":"bar"}`, `${! meta("kafka_key") }`, "foo.baz").
+				Default(""),
+		).
+		Example(
+			"Tagging Documents",
+			"Here we add the Kafka key of each message to a list of tags and rename a field, without needing to write a mapping:",
+			`
+pipeline:
+  processors:
+    - json:
+        operator: append
+        path: tags
+        value: '${! meta("kafka_key") }'
+    - json:
+        operator: move
+        path: user.name
+        value: user.display_name
+`,
+		)
+}
+
+func init() {
+	err := service.RegisterProcessor(
+		"json", jsonProcSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Processor, error) {
+			return newJSONProcFromParsed(conf)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+type jsonOperator func(root any, value string) (any, error)
+
+type jsonProc struct {
+	pathStr string
+	path    []string
+	value   *service.InterpolatedString
+	op      jsonOperator
+}
+
+func newJSONProcFromParsed(conf *service.ParsedConfig) (*jsonProc, error) {
+	opStr, err := conf.FieldString(jpFieldOperator)
+	if err != nil {
+		return nil, err
+	}
+	pathStr, err := conf.FieldString(jpFieldPath)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &jsonProc{pathStr: pathStr}
+	if pathStr != "" {
+		j.path = gabs.DotPathToSlice(pathStr)
+	}
+	if j.value, err = conf.FieldInterpolatedString(jpFieldValue); err != nil {
+		return nil, err
+	}
+	if j.op, err = j.operator(opStr); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func parseJSONValue(value string) any {
+	var v any
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return value
+	}
+	return v
+}
+
+func (j *jsonProc) operator(op string) (jsonOperator, error) {
+	switch op {
+	case "set", "append", "select", "flatten":
+	default:
+		if len(j.path) == 0 {
+			return nil, fmt.Errorf("a path must be specified for the %v operator", op)
+		}
+	}
+
+	switch op {
+	case "set":
+		return func(root any, value string) (any, error) {
+			if len(j.path) == 0 {
+				return parseJSONValue(value), nil
+			}
+			gObj := gabs.Wrap(root)
+			if _, err := gObj.Set(parseJSONValue(value), j.path...); err != nil {
+				return nil, err
+			}
+			return gObj.Data(), nil
+		}, nil
+	case "delete":
+		return func(root any, value string) (any, error) {
+			gObj := gabs.Wrap(root)
+			if err := gObj.Delete(j.path...); err != nil && !errors.Is(err, gabs.ErrNotFound) {
+				return nil, err
+			}
+			return gObj.Data(), nil
+		}, nil
+	case "move", "copy":
+		isMove := op == "move"
+		return func(root any, value string) (any, error) {
+			if value == "" {
+				return nil, errors.New("a destination path must be specified as the value")
+			}
+			gObj := gabs.Wrap(root)
+			if !gObj.Exists(j.path...) {
+				return nil, fmt.Errorf("path %v not found", j.pathStr)
+			}
+			if isMove {
+				// Work on a copy so that a failed move leaves the document
+				// untouched rather than with the source already deleted.
+				gObj = gabs.Wrap(message.CopyJSON(root))
+			}
+			target := gObj.Search(j.path...).Data()
+			if isMove {
+				if err := gObj.Delete(j.path...); err != nil {
+					return nil, err
+				}
+			} else {
+				target = message.CopyJSON(target)
+			}
+			if _, err := gObj.Set(target, gabs.DotPathToSlice(value)...); err != nil {
+				return nil, err
+			}
+			return gObj.Data(), nil
+		}, nil
+	case "append":
+		return func(root any, value string) (any, error) {
+			var existing []any
+			gObj := gabs.Wrap(root)
+			if target := gObj.Search(j.path...).Data(); target != nil {
+				if arr, ok := target.([]any); ok {
+					existing = arr
+				} else {
+					existing = []any{target}
+				}
+			}
+			if arr, ok := parseJSONValue(value).([]any); ok {
+				existing = append(existing, arr...)
+			} else {
+				existing = append(existing, parseJSONValue(value))
+			}
+			if len(j.path) == 0 {
+				return existing, nil
+			}
+			if _, err := gObj.Set(existing, j.path...); err != nil {
+				return nil, err
+			}
+			return gObj.Data(), nil
+		}, nil
+	case "select":
+		return func(root any, value string) (any, error) {
+			gObj := gabs.Wrap(root)
+			if !gObj.Exists(j.path...) {
+				return nil, fmt.Errorf("path %v not found", j.pathStr)
+			}
+			return gObj.Search(j.path...).Data(), nil
+		}, nil
+	case "flatten":
+		return func(root any, value string) (any, error) {
+			gObj := gabs.Wrap(root)
+			if !gObj.Exists(j.path...) {
+				return nil, fmt.Errorf("path %v not found", j.pathStr)
+			}
+			flat, err := gObj.Search(j.path...).Flatten()
+			if err != nil {
+				return nil, err
+			}
+			if len(j.path) == 0 {
+				return flat, nil
+			}
+			if _, err := gObj.Set(flat, j.path...); err != nil {
+				return nil, err
+			}
+			return gObj.Data(), nil
+		}, nil
+	}
+	return nil, fmt.Errorf("operator not recognised: %v", op)
+}
+
+func (j *jsonProc) Process(ctx context.Context, msg *service.Message) (service.MessageBatch, error) {
+	value, err := j.value.TryString(msg)
+	if err != nil {
+		return nil, fmt.Errorf("value interpolation error: %w", err)
+	}
+
+	root, err := msg.AsStructuredMut()
+	if err != nil {
+		return nil, err
+	}
+
+	if root, err = j.op(root, value); err != nil {
+		return nil, err
+	}
+
+	msg.SetStructuredMut(root)
+	return service.MessageBatch{msg}, nil
+}
+
+func (j *jsonProc) Close(ctx context.Context) error {
+	return nil
+}
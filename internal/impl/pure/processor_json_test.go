@@ -0,0 +1,171 @@
+package pure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestJSONProcOperators(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		input  string
+		output string
+		errStr string
+	}{
+		{
+			name:   "set object",
+			config: `{ operator: set, path: foo.bar, value: '{"baz":"${! @id }"}' }`,
+			input:  `{"foo":{"qux":1}}`,
+			output: `{"foo":{"bar":{"baz":"abc"},"qux":1}}`,
+		},
+		{
+			name:   "set string",
+			config: `{ operator: set, path: foo, value: 'not json' }`,
+			input:  `{"foo":1}`,
+			output: `{"foo":"not json"}`,
+		},
+		{
+			name:   "set root",
+			config: `{ operator: set, value: '[1,2]' }`,
+			input:  `{"foo":1}`,
+			output: `[1,2]`,
+		},
+		{
+			name:   "delete",
+			config: `{ operator: delete, path: foo.bar }`,
+			input:  `{"foo":{"bar":1,"baz":2}}`,
+			output: `{"foo":{"baz":2}}`,
+		},
+		{
+			name:   "delete missing",
+			config: `{ operator: delete, path: foo.nope }`,
+			input:  `{"foo":{"bar":1}}`,
+			output: `{"foo":{"bar":1}}`,
+		},
+		{
+			name:   "move",
+			config: `{ operator: move, path: foo.bar, value: baz }`,
+			input:  `{"foo":{"bar":{"a":1}}}`,
+			output: `{"baz":{"a":1},"foo":{}}`,
+		},
+		{
+			name:   "move missing",
+			config: `{ operator: move, path: foo.nope, value: baz }`,
+			input:  `{"foo":{"bar":1}}`,
+			errStr: "path foo.nope not found",
+		},
+		{
+			name:   "copy",
+			config: `{ operator: copy, path: foo, value: bar.baz }`,
+			input:  `{"foo":{"a":1}}`,
+			output: `{"bar":{"baz":{"a":1}},"foo":{"a":1}}`,
+		},
+		{
+			name:   "append to existing",
+			config: `{ operator: append, path: tags, value: '${! meta("id") }' }`,
+			input:  `{"tags":["first"]}`,
+			output: `{"tags":["first","abc"]}`,
+		},
+		{
+			name:   "append array",
+			config: `{ operator: append, path: tags, value: '[2,3]' }`,
+			input:  `{"tags":1}`,
+			output: `{"tags":[1,2,3]}`,
+		},
+		{
+			name:   "append new",
+			config: `{ operator: append, path: tags, value: '"foo"' }`,
+			input:  `{}`,
+			output: `{"tags":["foo"]}`,
+		},
+		{
+			name:   "select",
+			config: `{ operator: select, path: foo.1 }`,
+			input:  `{"foo":["a",{"b":"c"}]}`,
+			output: `{"b":"c"}`,
+		},
+		{
+			name:   "select missing",
+			config: `{ operator: select, path: foo.bar }`,
+			input:  `{"foo":[]}`,
+			errStr: "path foo.bar not found",
+		},
+		{
+			name:   "flatten path",
+			config: `{ operator: flatten, path: foo }`,
+			input:  `{"foo":{"a":{"b":1},"c":[2]},"bar":true}`,
+			output: `{"bar":true,"foo":{"a.b":1,"c.0":2}}`,
+		},
+		{
+			name:   "flatten root",
+			config: `{ operator: flatten }`,
+			input:  `{"a":{"b":1}}`,
+			output: `{"a.b":1}`,
+		},
+		{
+			name:   "not json",
+			config: `{ operator: set, path: foo, value: bar }`,
+			input:  `not json`,
+			errStr: "invalid character 'o' in literal null (expecting 'u')",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := jsonProcSpec().ParseYAML(test.config, nil)
+			require.NoError(t, err)
+
+			proc, err := newJSONProcFromParsed(conf)
+			require.NoError(t, err)
+
+			msg := service.NewMessage([]byte(test.input))
+			msg.MetaSetMut("id", "abc")
+
+			batch, err := proc.Process(context.Background(), msg)
+			if test.errStr != "" {
+				require.EqualError(t, err, test.errStr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, batch, 1)
+
+			mBytes, err := batch[0].AsBytes()
+			require.NoError(t, err)
+			assert.Equal(t, test.output, string(mBytes))
+		})
+	}
+}
+
+func TestJSONProcMoveFailureKeepsSource(t *testing.T) {
+	conf, err := jsonProcSpec().ParseYAML(`{ operator: move, path: foo.bar, value: baz.nope }`, nil)
+	require.NoError(t, err)
+
+	proc, err := newJSONProcFromParsed(conf)
+	require.NoError(t, err)
+
+	msg := service.NewMessage([]byte(`{"foo":{"bar":1},"baz":[2]}`))
+
+	_, err = proc.Process(context.Background(), msg)
+	require.Error(t, err)
+
+	mBytes, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `{"baz":[2],"foo":{"bar":1}}`, string(mBytes))
+}
+
+func TestJSONProcMissingPath(t *testing.T) {
+	for _, op := range []string{"delete", "move", "copy"} {
+		conf, err := jsonProcSpec().ParseYAML(`operator: `+op, nil)
+		require.NoError(t, err)
+
+		_, err = newJSONProcFromParsed(conf)
+		require.Error(t, err, op)
+	}
+}
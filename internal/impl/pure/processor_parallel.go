@@ -2,6 +2,7 @@ package pure
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"sync"
 
@@ -33,6 +34,36 @@ The functionality of this processor depends on being applied across messages tha
 			docs.FieldInt("cap", "The maximum number of messages to have processing at a given time."),
 			docs.FieldProcessor("processors", "A list of child processors to apply.").Array(),
 		).ChildDefaultAndTypesFromStruct(processor.NewParallelConfig()),
+		Examples: []docs.AnnotatedExample{
+			{
+				Title: "Concurrent Enrichment",
+				Summary: `
+Enriching messages with the result of a slow HTTP request can bottleneck a pipeline when those requests are made one at a time. Here we consume batches of up to 50 messages and enrich each of them within a ` + "[`branch` processor](/docs/components/processors/branch)" + `, with at most 10 requests in flight at any given time in order to protect the upstream service:`,
+				Config: `
+input:
+  kafka:
+    addresses: [ TODO ]
+    topics: [ orders ]
+    consumer_group: enrichment
+    batching:
+      count: 50
+      period: 1s
+
+pipeline:
+  processors:
+    - parallel:
+        cap: 10
+        processors:
+          - branch:
+              request_map: 'root.customer_id = this.customer_id'
+              processors:
+                - http:
+                    url: http://example.com/customers
+                    verb: POST
+              result_map: 'root.customer = this'
+`,
+			},
+		},
 	})
 	if err != nil {
 		panic(err)
@@ -45,6 +76,10 @@ type parallelProc struct {
 }
 
 func newParallel(conf processor.ParallelConfig, mgr bundle.NewManagement) (processor.AutoObservedBatched, error) {
+	if conf.Cap < 0 {
+		return nil, errors.New("cap must not be negative")
+	}
+
 	var children []processor.V1
 	for i, pconf := range conf.Processors {
 		pMgr := mgr.IntoPath("parallel", strconv.Itoa(i))
@@ -78,9 +113,12 @@ func (p *parallelProc) ProcessBatch(ctx *processor.BatchProcContext, msg message
 
 	for i := 0; i < max; i++ {
 		go func() {
-			// TODO: V4 Handle processor errors when we migrate to service APIs
 			for index := range reqChan {
-				resMsgs, _ := processor.ExecuteAll(ctx.Context(), p.children, resultMsgs[index])
+				resMsgs, err := processor.ExecuteAll(ctx.Context(), p.children, resultMsgs[index])
+				if err != nil {
+					ctx.OnError(err, index, resultMsgs[index][0])
+					continue
+				}
 				resultParts := []*message.Part{}
 				for _, m := range resMsgs {
 					_ = m.Iter(func(i int, p *message.Part) error {
@@ -107,6 +145,9 @@ func (p *parallelProc) ProcessBatch(ctx *processor.BatchProcContext, msg message
 		})
 	}
 
+	if resMsg.Len() == 0 {
+		return nil, nil
+	}
 	return []message.Batch{resMsg}, nil
 }
 
@@ -2,6 +2,7 @@ package pure_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -168,3 +169,69 @@ parallel:
 		t.Errorf("Wrong result: %v != %v", act, exp)
 	}
 }
+
+func TestParallelNegativeCap(t *testing.T) {
+	conf := parseYAMLConf(t, `
+parallel:
+  cap: -1
+  processors: []
+`)
+
+	_, err := mock.NewManager().NewProcessor(conf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cap must not be negative")
+}
+
+func TestParallelFilterAll(t *testing.T) {
+	conf := parseYAMLConf(t, `
+parallel:
+  processors:
+    - mapping: 'root = deleted()'
+`)
+
+	h, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := h.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("foo"),
+		[]byte("bar"),
+	}))
+	require.NoError(t, res)
+	assert.Empty(t, msgs)
+}
+
+func TestParallelChildFailure(t *testing.T) {
+	mgr := mock.NewManager()
+	mgr.Processors["foo"] = func(b message.Batch) ([]message.Batch, error) {
+		if string(b.Get(0).AsBytes()) == "bar" {
+			return nil, errors.New("nope")
+		}
+		return []message.Batch{b}, nil
+	}
+
+	conf := parseYAMLConf(t, `
+parallel:
+  cap: 2
+  processors:
+    - resource: foo
+`)
+
+	h, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := h.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("foo"),
+		[]byte("bar"),
+		[]byte("baz"),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, [][]byte{
+		[]byte("foo"),
+		[]byte("bar"),
+		[]byte("baz"),
+	}, message.GetAllBytes(msgs[0]))
+	assert.NoError(t, msgs[0].Get(0).ErrorGet())
+	assert.EqualError(t, msgs[0].Get(1).ErrorGet(), "nope")
+	assert.NoError(t, msgs[0].Get(2).ErrorGet())
+}
@@ -31,6 +31,38 @@ Throttles the throughput of a pipeline according to a specified
 ` + "[`rate_limit`](/docs/components/rate_limits/about)" + ` resource. Rate limits are
 shared across components and therefore apply globally to all processing
 pipelines.`,
+		Description: `
+Each message is blocked until the rate limit grants access, at which point it continues through the pipeline. Since rate limit resources can also be referenced by other components, such as the ` + "[`http` processor](/docs/components/processors/http)" + ` and ` + "[`http_client` output](/docs/components/outputs/http_client)" + `, it is possible for multiple components to share a single budget of requests.`,
+		Examples: []docs.AnnotatedExample{
+			{
+				Title:   "Shared API Budget",
+				Summary: "In this example an enrichment stage and an output both call the same API, which permits a maximum of 100 requests per second. By referencing the same rate limit resource from both the `rate_limit` processor and the output they share a single global budget.",
+				Config: `
+pipeline:
+  processors:
+    - rate_limit:
+        resource: api_budget
+    - branch:
+        processors:
+          - http:
+              url: https://example.com/api/enrich
+              verb: POST
+        result_map: 'root.enrichment = this'
+
+output:
+  http_client:
+    url: https://example.com/api/ingest
+    verb: POST
+    rate_limit: api_budget
+
+rate_limit_resources:
+  - label: api_budget
+    local:
+      count: 100
+      interval: 1s
+`,
+			},
+		},
 		Config: docs.FieldComponent().WithChildren(
 			docs.FieldString("resource", "The target [`rate_limit` resource](/docs/components/rate_limits/about).").HasDefault(""),
 		),
@@ -70,7 +102,7 @@ func (r *rateLimitProc) Process(ctx context.Context, msg *message.Part) ([]*mess
 			err = rerr
 		}
 		if ctx.Err() != nil {
-			return nil, err
+			return nil, ctx.Err()
 		}
 		if err != nil {
 			r.mgr.Logger().Errorf("Failed to access rate limit: %v", err)
@@ -158,3 +158,35 @@ func TestRateLimitBlocked(t *testing.T) {
 		t.Error("Timed out")
 	}
 }
+
+func TestRateLimitCancelled(t *testing.T) {
+	rlFn := func(context.Context) (time.Duration, error) {
+		return 0, nil
+	}
+
+	mgr := mock.NewManager()
+	mgr.RateLimits["foo"] = rlFn
+
+	conf := processor.NewConfig()
+	conf.Type = "rate_limit"
+	conf.RateLimit.Resource = "foo"
+	proc, err := mgr.NewProcessor(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, done := context.WithCancel(context.Background())
+	done()
+
+	output, res := proc.ProcessBatch(ctx, message.QuickBatch([][]byte{
+		[]byte(`{"key":"1","value":"foo 1"}`),
+	}))
+	if res != nil {
+		t.Fatal(res)
+	}
+
+	if len(output) != 1 {
+		t.Fatalf("Wrong count of result messages: %v", len(output))
+	}
+	assert.Error(t, output[0].Get(0).ErrorGet())
+}
@@ -48,6 +48,31 @@ processor_resources:
       root.user.age = this.user.age.number()
 ` + "```" + `
 
+Only one instance of a processor resource is created regardless of how many times it is referenced, including when it is referenced from multiple streams in [streams mode](/docs/guides/streams_mode/about). This makes resources a good fit for processors that are expensive to initialise or that hold state worth sharing, such as the ` + "[`schema_registry_decode`](/docs/components/processors/schema_registry_decode)" + ` processor and its cache of schemas:
+
+` + "```yaml" + `
+input:
+  broker:
+    inputs:
+      - kafka:
+          addresses: [ TODO ]
+          topics: [ orders ]
+          consumer_group: benthos
+        processors:
+          - resource: decode_avro
+      - kafka:
+          addresses: [ TODO ]
+          topics: [ refunds ]
+          consumer_group: benthos
+        processors:
+          - resource: decode_avro
+
+processor_resources:
+  - label: decode_avro
+    schema_registry_decode:
+      url: http://localhost:8081
+` + "```" + `
+
 You can find out more about resources [in this document.](/docs/configuration/resources)`,
 		Config: docs.FieldString("", "").HasDefault(""),
 	})
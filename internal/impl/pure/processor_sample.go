@@ -0,0 +1,126 @@
+package pure
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/OneOfOne/xxhash"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	spFieldPercentage = "percentage"
+	spFieldKey        = "key"
+)
+
+// sampleScale is the number of buckets that messages are distributed across,
+// giving percentages a precision of 0.0001.
+const sampleScale = 1000000
+
+func sampleProcSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.18.0").
+		Categories("Utility").
+		Summary("Passes through a percentage of messages and drops the rest.").
+		Description(`
+By default messages are selected at random, and therefore the exact proportion of messages passed through will vary around the configured percentage.
+
+When a `+"`key`"+` is specified the decision is instead made deterministically from a hash of the resolved key, meaning all messages that share a key are either passed through or dropped together. This is useful for sampling whole sessions or traces rather than individual events.`).
+		Fields(
+			service.NewFloatField(spFieldPercentage).
+				Description("The percentage of messages to pass through, from `0` to `100`, with a precision of up to four decimal places.").
+				Examples(10, 0.5),
+			service.NewInterpolatedStringField(spFieldKey).
+				Description("An optional key to resolve for each message, when set the decision to keep a message is made deterministically from a hash of the key.").
+				Example(`${! meta("kafka_key") }`).
+				Example(`${! this.session.id }`).
+				Optional(),
+		).
+		Example(
+			"Sampling to an Expensive Sink",
+			"In this example we send all messages to Kafka, and a representative 5% of user sessions to an HTTP endpoint that is costly to call.",
+			`
+output:
+  broker:
+    pattern: fan_out
+    outputs:
+      - kafka:
+          addresses: [ TODO ]
+          topic: events
+      - http_client:
+          url: http://example.com/analytics
+          verb: POST
+        processors:
+          - sample:
+              percentage: 5
+              key: ${! this.session_id }
+`,
+		)
+}
+
+func init() {
+	err := service.RegisterProcessor(
+		"sample", sampleProcSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Processor, error) {
+			return newSampleProcFromParsed(conf)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+type sampleProc struct {
+	// Threshold out of sampleScale below which messages are kept.
+	threshold uint64
+	key       *service.InterpolatedString
+}
+
+func newSampleProcFromParsed(conf *service.ParsedConfig) (*sampleProc, error) {
+	percentage, err := conf.FieldFloat(spFieldPercentage)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(percentage) || percentage < 0 || percentage > 100 {
+		return nil, fmt.Errorf("percentage must be between 0 and 100, got %v", percentage)
+	}
+
+	threshold := uint64(math.Round(percentage * sampleScale / 100))
+	if threshold == 0 && percentage > 0 {
+		return nil, fmt.Errorf("percentage %v is smaller than the supported precision of 0.0001", percentage)
+	}
+
+	s := &sampleProc{
+		threshold: threshold,
+	}
+	if conf.Contains(spFieldKey) {
+		if s.key, err = conf.FieldInterpolatedString(spFieldKey); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *sampleProc) Process(ctx context.Context, msg *service.Message) (service.MessageBatch, error) {
+	var n uint64
+	if s.key != nil {
+		key, err := s.key.TryString(msg)
+		if err != nil {
+			return nil, fmt.Errorf("key interpolation error: %w", err)
+		}
+		n = xxhash.ChecksumString64(key) % sampleScale
+	} else {
+		n = uint64(rand.Int63n(sampleScale))
+	}
+	if n >= s.threshold {
+		return nil, nil
+	}
+	return service.MessageBatch{msg}, nil
+}
+
+func (s *sampleProc) Close(ctx context.Context) error {
+	return nil
+}
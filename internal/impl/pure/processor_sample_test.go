@@ -0,0 +1,115 @@
+package pure
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestSampleBounds(t *testing.T) {
+	for _, test := range []struct {
+		percentage string
+		expKept    int
+	}{
+		{percentage: "0", expKept: 0},
+		{percentage: "100", expKept: 100},
+	} {
+		conf, err := sampleProcSpec().ParseYAML(fmt.Sprintf(`percentage: %v`, test.percentage), nil)
+		require.NoError(t, err)
+
+		proc, err := newSampleProcFromParsed(conf)
+		require.NoError(t, err)
+
+		kept := 0
+		for i := 0; i < 100; i++ {
+			batch, err := proc.Process(context.Background(), service.NewMessage([]byte("hello world")))
+			require.NoError(t, err)
+			kept += len(batch)
+		}
+		assert.Equal(t, test.expKept, kept, test.percentage)
+	}
+}
+
+func TestSampleThresholdPrecision(t *testing.T) {
+	for _, test := range []struct {
+		percentage string
+		expected   uint64
+	}{
+		{percentage: "0.005", expected: 50},
+		{percentage: "0.0001", expected: 1},
+		{percentage: "12.34567", expected: 123457},
+		{percentage: "100", expected: sampleScale},
+	} {
+		conf, err := sampleProcSpec().ParseYAML(fmt.Sprintf(`percentage: %v`, test.percentage), nil)
+		require.NoError(t, err)
+
+		proc, err := newSampleProcFromParsed(conf)
+		require.NoError(t, err)
+
+		assert.Equal(t, test.expected, proc.threshold, test.percentage)
+	}
+}
+
+func TestSampleBadPercentage(t *testing.T) {
+	for _, p := range []string{"-1", "100.5", "0.00001", ".nan"} {
+		conf, err := sampleProcSpec().ParseYAML(fmt.Sprintf(`percentage: %v`, p), nil)
+		require.NoError(t, err)
+
+		_, err = newSampleProcFromParsed(conf)
+		require.Error(t, err, p)
+	}
+}
+
+func TestSampleRandom(t *testing.T) {
+	conf, err := sampleProcSpec().ParseYAML(`percentage: 50`, nil)
+	require.NoError(t, err)
+
+	proc, err := newSampleProcFromParsed(conf)
+	require.NoError(t, err)
+
+	kept := 0
+	for i := 0; i < 10000; i++ {
+		batch, err := proc.Process(context.Background(), service.NewMessage([]byte("hello world")))
+		require.NoError(t, err)
+		kept += len(batch)
+	}
+	assert.Greater(t, kept, 4000)
+	assert.Less(t, kept, 6000)
+}
+
+func TestSampleDeterministic(t *testing.T) {
+	conf, err := sampleProcSpec().ParseYAML(`
+percentage: 30
+key: ${! content() }
+`, nil)
+	require.NoError(t, err)
+
+	proc, err := newSampleProcFromParsed(conf)
+	require.NoError(t, err)
+
+	kept := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key%v", i)
+		batch, err := proc.Process(context.Background(), service.NewMessage([]byte(key)))
+		require.NoError(t, err)
+		kept[key] = len(batch) == 1
+	}
+
+	nKept := 0
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key%v", i)
+		batch, err := proc.Process(context.Background(), service.NewMessage([]byte(key)))
+		require.NoError(t, err)
+		assert.Equal(t, kept[key], len(batch) == 1, key)
+		if kept[key] {
+			nKept++
+		}
+	}
+	assert.Greater(t, nKept, 200)
+	assert.Less(t, nKept, 400)
+}
@@ -2,6 +2,7 @@ package pure
 
 import (
 	"context"
+	"errors"
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
@@ -29,9 +30,35 @@ This processor is for breaking batches down into smaller ones. In order to break
 
 If there is a remainder of messages after splitting a batch the remainder is also sent as a single batch. For example, if your target size was 10, and the processor received a batch of 95 message parts, the result would be 9 batches of 10 messages followed by a batch of 5 messages.`,
 		Config: docs.FieldComponent().WithChildren(
-			docs.FieldInt("size", "The target number of messages.").HasDefault(1),
+			docs.FieldInt("size", "The target number of messages. Set to `0` in order to split batches by `byte_size` only.").HasDefault(1),
 			docs.FieldInt("byte_size", "An optional target of total message bytes.").HasDefault(0),
 		),
+		Examples: []docs.AnnotatedExample{
+			{
+				Title:   "Respecting Output Limits",
+				Summary: "AWS SQS limits batches to a maximum of 10 messages totalling no more than 256KiB. When consuming large batches from an input we can use this processor in order to break them down into batches that are within these limits before they reach the output.",
+				Config: `
+input:
+  kafka:
+    addresses: [ TODO ]
+    topics: [ foo ]
+    consumer_group: bar
+    batching:
+      count: 100
+      period: 1s
+
+pipeline:
+  processors:
+    - split:
+        size: 10
+        byte_size: 262144
+
+output:
+  aws_sqs:
+    url: https://sqs.us-east-2.amazonaws.com/123456789012/MyQueue
+`,
+			},
+		},
 	})
 	if err != nil {
 		panic(err)
@@ -46,6 +73,12 @@ type splitProc struct {
 }
 
 func newSplit(conf processor.SplitConfig, mgr bundle.NewManagement) (*splitProc, error) {
+	if conf.Size < 0 {
+		return nil, errors.New("size must not be negative")
+	}
+	if conf.ByteSize < 0 {
+		return nil, errors.New("byte_size must not be negative")
+	}
 	return &splitProc{
 		log:      mgr.Logger(),
 		size:     conf.Size,
@@ -64,18 +97,20 @@ func (s *splitProc) ProcessBatch(ctx *processor.BatchProcContext, msg message.Ba
 	byteSize := 0
 
 	_ = msg.Iter(func(i int, p *message.Part) error {
+		pSize := len(p.AsBytes())
+		if s.byteSize > 0 && pSize > s.byteSize {
+			s.log.Warnf("A single message exceeds the target batch byte size of '%v', actual size: '%v'", s.byteSize, pSize)
+		}
 		if (s.size > 0 && nextMsg.Len() >= s.size) ||
-			(s.byteSize > 0 && (byteSize+len(p.AsBytes())) > s.byteSize) {
+			(s.byteSize > 0 && (byteSize+pSize) > s.byteSize) {
 			if nextMsg.Len() > 0 {
 				msgs = append(msgs, nextMsg)
 				nextMsg = message.QuickBatch(nil)
 				byteSize = 0
-			} else {
-				s.log.Warnf("A single message exceeds the target batch byte size of '%v', actual size: '%v'", s.byteSize, len(p.AsBytes()))
 			}
 		}
 		nextMsg = append(nextMsg, p)
-		byteSize += len(p.AsBytes())
+		byteSize += pSize
 		return nil
 	})
 
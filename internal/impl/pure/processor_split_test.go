@@ -170,3 +170,57 @@ func TestSplitByBytesTooLarge(t *testing.T) {
 		t.Errorf("Wrong contents: %v != %v", act, exp)
 	}
 }
+
+func TestSplitBySizeAndBytes(t *testing.T) {
+	conf := processor.NewConfig()
+	conf.Type = "split"
+	conf.Split.Size = 2
+	conf.Split.ByteSize = 7
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inMsg := message.QuickBatch([][]byte{
+		[]byte("a"),
+		[]byte("b"),
+		[]byte("c"),
+		[]byte("ddddd"),
+		[]byte("eeeeeeee"),
+		[]byte("f"),
+	})
+	msgs, _ := proc.ProcessBatch(context.Background(), inMsg)
+
+	exp := [][]string{{"a", "b"}, {"c", "ddddd"}, {"eeeeeeee"}, {"f"}}
+	if len(msgs) != len(exp) {
+		t.Fatalf("Wrong batch count: %v != %v", len(msgs), len(exp))
+	}
+	for i, e := range exp {
+		if act := msgs[i].Len(); act != len(e) {
+			t.Fatalf("Wrong message %v count: %v != %v", i, act, len(e))
+		}
+		for j, v := range e {
+			if act := string(msgs[i].Get(j).AsBytes()); act != v {
+				t.Errorf("Wrong contents: %v != %v", act, v)
+			}
+		}
+	}
+}
+
+func TestSplitNegativeSizes(t *testing.T) {
+	conf := processor.NewConfig()
+	conf.Type = "split"
+	conf.Split.Size = -1
+
+	if _, err := mock.NewManager().NewProcessor(conf); err == nil {
+		t.Error("Expected error from negative size")
+	}
+
+	conf.Split.Size = 1
+	conf.Split.ByteSize = -1
+
+	if _, err := mock.NewManager().NewProcessor(conf); err == nil {
+		t.Error("Expected error from negative byte_size")
+	}
+}
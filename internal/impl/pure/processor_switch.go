@@ -29,13 +29,17 @@ func init() {
 		Summary: `
 Conditionally processes messages based on their contents.`,
 		Description: `
-For each switch case a [Bloblang query](/docs/guides/bloblang/about) is checked and, if the result is true (or the check is empty) the child processors are executed on the message.`,
+For each switch case a [Bloblang query](/docs/guides/bloblang/about) is checked and, if the result is true (or the check is empty) the child processors are executed on the message.
+
+A case with an empty check therefore acts as an else branch, and should be placed last as any cases that follow it will not be reached unless it has ` + "`fallthrough`" + ` enabled.`,
 		Footnotes: `
 ## Batching
 
 When a switch processor executes on a [batch of messages](/docs/configuration/batching) they are checked individually and can be matched independently against cases. During processing the messages matched against a case are processed as a batch, although the ordering of messages during case processing cannot be guaranteed to match the order as received.
 
-At the end of switch processing the resulting batch will follow the same ordering as the batch was received. If any child processors have split or otherwise grouped messages this grouping will be lost as the result of a switch is always a single batch. In order to perform conditional grouping and/or splitting use the [` + "`group_by`" + ` processor](/docs/components/processors/group_by).`,
+At the end of switch processing the resulting batch will follow the same ordering as the batch was received. If any child processors have split or otherwise grouped messages this grouping will be lost as the result of a switch is always a single batch. In order to perform conditional grouping and/or splitting use the [` + "`group_by`" + ` processor](/docs/components/processors/group_by).
+
+A check is able to reference other messages of the batch with the [` + "`from`" + `](/docs/guides/bloblang/methods#from) and [` + "`from_all`" + `](/docs/guides/bloblang/methods#from_all) methods, which makes it possible to route an entire batch based on the contents of one or all of its messages. Note that the batch visible to a check consists only of the messages that have not yet passed a prior case.`,
 		Config: docs.FieldComponent().Array().WithChildren(
 			docs.FieldBloblang(
 				"check",
@@ -75,6 +79,23 @@ pipeline:
                 name: GeorgesAnger
                 value: ${! json("user.anger") }
             - mapping: root = deleted()
+`,
+			},
+			{
+				Title: "Checking Entire Batches",
+				Summary: `
+Checks are executed for each message individually, but can query the other messages of a batch. Here we flag all messages of a batch as urgent when any of them has a high priority, and otherwise flag them all as routine when the first message of the batch is from our own service:`,
+				Config: `
+pipeline:
+  processors:
+    - switch:
+        - check: 'json("priority").from_all().any(p -> p == "high")'
+          processors:
+            - mapping: 'meta flag = "urgent"'
+
+        - check: 'json("source").from(0) == "internal"'
+          processors:
+            - mapping: 'meta flag = "routine"'
 `,
 			},
 		},
@@ -219,6 +240,9 @@ func (s *switchProc) ProcessBatch(ctx *processor.BatchProcContext, msg message.B
 		}
 	}
 
+	// Messages that fell through the final case have no further cases to
+	// execute and are therefore added to the result as they are.
+	result = append(result, carryOver...)
 	result = append(result, remaining...)
 	if len(result) > 1 {
 		SwitchReorderFromGroup(sortGroup, result)
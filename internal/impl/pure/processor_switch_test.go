@@ -194,6 +194,42 @@ func TestSwitchError(t *testing.T) {
 	}, resStrs)
 }
 
+func TestSwitchFinalCaseFallthrough(t *testing.T) {
+	conf := processor.NewConfig()
+	conf.Type = "switch"
+
+	procConf := processor.NewConfig()
+	procConf.Type = "bloblang"
+	procConf.Bloblang = `root = "Hit case 0: " + content().string()`
+
+	conf.Switch = append(conf.Switch, processor.SwitchCaseConfig{
+		Check:       `content().contains("A")`,
+		Processors:  []processor.Config{procConf},
+		Fallthrough: true,
+	})
+
+	c, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	defer func() {
+		ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+		defer done()
+		assert.NoError(t, c.Close(ctx))
+	}()
+
+	msgs, res := c.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("A"), []byte("B"), []byte("AB"),
+	}))
+	require.Nil(t, res)
+	require.Len(t, msgs, 1)
+
+	resStrs := []string{}
+	for _, b := range message.GetAllBytes(msgs[0]) {
+		resStrs = append(resStrs, string(b))
+	}
+	assert.Equal(t, []string{"Hit case 0: A", "B", "Hit case 0: AB"}, resStrs)
+}
+
 func BenchmarkSwitch10(b *testing.B) {
 	conf := processor.NewConfig()
 	conf.Type = "switch"
@@ -379,3 +415,50 @@ func BenchmarkSortReverse(b *testing.B) {
 
 	pure.SwitchReorderFromGroup(group, unsortedParts)
 }
+
+func TestSwitchBatchWideChecks(t *testing.T) {
+	conf := parseYAMLConf(t, `
+switch:
+  - check: 'json("priority").from_all().any(p -> p == "high")'
+    processors:
+      - mapping: 'root = "urgent: " + content().string()'
+  - check: 'json("source").from(0) == "internal"'
+    processors:
+      - mapping: 'root = "routine: " + content().string()'
+`)
+
+	c, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		input    []string
+		expected []string
+	}{
+		{
+			input:    []string{`{"priority":"low"}`, `{"priority":"high"}`},
+			expected: []string{`urgent: {"priority":"low"}`, `urgent: {"priority":"high"}`},
+		},
+		{
+			input:    []string{`{"source":"internal"}`, `{"source":"external"}`},
+			expected: []string{`routine: {"source":"internal"}`, `routine: {"source":"external"}`},
+		},
+		{
+			input:    []string{`{"source":"external"}`, `{"source":"internal"}`},
+			expected: []string{`{"source":"external"}`, `{"source":"internal"}`},
+		},
+	} {
+		msg := message.QuickBatch(nil)
+		for _, s := range test.input {
+			msg = append(msg, message.NewPart([]byte(s)))
+		}
+		msgs, res := c.ProcessBatch(context.Background(), msg)
+		require.NoError(t, res)
+		require.Len(t, msgs, 1)
+
+		var actual []string
+		for _, b := range message.GetAllBytes(msgs[0]) {
+			actual = append(actual, string(b))
+		}
+		assert.Equal(t, test.expected, actual, test.input)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"io"
 	"strings"
 
+	"github.com/klauspost/compress/gzip"
+
 	"github.com/benthosdev/benthos/v4/internal/message"
 	"github.com/benthosdev/benthos/v4/public/service"
 )
@@ -26,10 +28,11 @@ When a message is unarchived the new messages replace the original message in th
 
 ## Metadata
 
-The metadata found on the messages handled by this processor will be copied into the resulting messages. For the unarchive formats that contain file information (tar, zip), a metadata field is also added to each message called ` + "`archive_filename`" + ` with the extracted filename.
+The metadata found on the messages handled by this processor will be copied into the resulting messages. For the unarchive formats that contain file information (tar, tar_gzip, zip), a metadata field is also added to each message called ` + "`archive_filename`" + ` with the extracted filename.
 `).
 		Field(service.NewStringAnnotatedEnumField("format", map[string]string{
 			`tar`:            `Extract messages from a unix standard tape archive.`,
+			`tar_gzip`:       `Extract messages from a unix standard tape archive compressed with gzip.`,
 			`zip`:            `Extract messages from a zip file.`,
 			`binary`:         `Extract messages from a [binary blob format](https://github.com/benthosdev/benthos/blob/main/internal/message/message.go#L96).`,
 			`lines`:          `Extract the lines of a message each into their own message.`,
@@ -59,9 +62,26 @@ func tarUnarchive(part *service.Message) (service.MessageBatch, error) {
 	if err != nil {
 		return nil, err
 	}
+	return readTarArchive(part, bytes.NewBuffer(pBytes))
+}
+
+func tarGzipUnarchive(part *service.Message) (service.MessageBatch, error) {
+	pBytes, err := part.AsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(pBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	return readTarArchive(part, gr)
+}
 
-	buf := bytes.NewBuffer(pBytes)
-	tr := tar.NewReader(buf)
+func readTarArchive(part *service.Message, r io.Reader) (service.MessageBatch, error) {
+	tr := tar.NewReader(r)
 
 	var newParts []*service.Message
 
@@ -288,6 +308,8 @@ func strToUnarchiver(str string) (unarchiveFunc, error) {
 	switch str {
 	case "tar":
 		return tarUnarchive, nil
+	case "tar_gzip":
+		return tarGzipUnarchive, nil
 	case "zip":
 		return zipUnarchive, nil
 	case "binary":
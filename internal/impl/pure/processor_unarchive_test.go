@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/klauspost/compress/gzip"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -86,6 +87,56 @@ format: tar
 	}
 }
 
+func TestUnarchiveTarGzip(t *testing.T) {
+	conf, err := unarchiveProcConfig().ParseYAML(`
+format: tar_gzip
+`, nil)
+	require.NoError(t, err)
+
+	input := [][]byte{
+		[]byte("hello world first part"),
+		[]byte("hello world second part"),
+		[]byte("third part"),
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	for i := range input {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: fmt.Sprintf("testfile%v", i),
+			Mode: 0o600,
+			Size: int64(len(input[i])),
+		}))
+		_, err := tw.Write(input[i])
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	proc, err := newUnarchiveFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+
+	msgs, res := proc.Process(context.Background(), service.NewMessage(buf.Bytes()))
+	require.NoError(t, res)
+	require.Len(t, msgs, len(input))
+
+	for i, e := range input {
+		key, exists := msgs[i].MetaGet("archive_filename")
+		require.True(t, exists)
+		assert.Equal(t, fmt.Sprintf("testfile%v", i), key)
+
+		mBytes, err := msgs[i].AsBytes()
+		require.NoError(t, err)
+		assert.Equal(t, string(e), string(mBytes))
+	}
+
+	_, res = proc.Process(context.Background(), service.NewMessage([]byte("not gzipped")))
+	require.Error(t, res)
+}
+
 func TestUnarchiveZip(t *testing.T) {
 	conf, err := unarchiveProcConfig().ParseYAML(`
 format: zip
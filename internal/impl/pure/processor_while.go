@@ -49,6 +49,36 @@ The conditions of this processor are applied across entire message batches. You
 			).HasDefault(""),
 			docs.FieldProcessor("processors", "A list of child processors to execute on each loop.").Array(),
 		).ChildDefaultAndTypesFromStruct(processor.NewWhileConfig()),
+		Examples: []docs.AnnotatedExample{
+			{
+				Title: "Paginated Enrichment",
+				Summary: `
+A common use for the while processor is fetching every page of a paginated API. In this example we collect all orders of a user by requesting pages until the API stops returning a ` + "`next_page`" + ` value, the ` + "`max_loops`" + ` field guards against a misbehaving API paginating forever:`,
+				Config: `
+pipeline:
+  processors:
+    - mapping: |
+        root = this
+        root.orders = []
+        meta user_id = this.user_id
+        meta page = 1
+
+    - while:
+        check: '@page != null'
+        max_loops: 50
+        processors:
+          - branch:
+              request_map: 'root = ""'
+              processors:
+                - http:
+                    url: http://example.com/users/${! @user_id }/orders?page=${! @page }
+                    verb: GET
+              result_map: |
+                root.orders = root.orders.concat(this.orders)
+                meta page = this.next_page
+`,
+			},
+		},
 	})
 	if err != nil {
 		panic(err)
@@ -137,10 +167,6 @@ func (w *whileProc) ProcessBatch(ctx *processor.BatchProcContext, msg message.Ba
 		ctx.Span(i).SetTag("result", strconv.FormatBool(condResult))
 	}
 
-	totalParts := 0
-	for _, msg := range msgs {
-		totalParts += msg.Len()
-	}
 	return
 }
 
@@ -61,6 +61,9 @@ func newLocalRatelimit(count int, interval time.Duration) (*localRatelimit, erro
 	if count <= 0 {
 		return nil, errors.New("count must be larger than zero")
 	}
+	if interval <= 0 {
+		return nil, errors.New("interval must be larger than zero")
+	}
 	return &localRatelimit{
 		bucket:      count,
 		lastRefresh: time.Now(),
@@ -17,7 +17,13 @@ func TestLocalRateLimitConfErrors(t *testing.T) {
 	_, err = newLocalRatelimitFromConfig(conf)
 	require.Error(t, err)
 
-	_, err = localRatelimitConfig().ParseYAML(`interval: nope`, nil)
+	conf, err = localRatelimitConfig().ParseYAML(`interval: nope`, nil)
+	require.NoError(t, err)
+
+	_, err = newLocalRatelimitFromConfig(conf)
+	require.Error(t, err)
+
+	conf, err = localRatelimitConfig().ParseYAML(`interval: 0s`, nil)
 	require.NoError(t, err)
 
 	_, err = newLocalRatelimitFromConfig(conf)
@@ -20,7 +20,8 @@ func redisCacheConfig() *service.ConfigSpec {
 
 	spec := service.NewConfigSpec().
 		Stable().
-		Summary(`Use a Redis instance as a cache. The expiration can be set to zero or an empty string in order to set no expiration.`)
+		Summary(`Use a Redis instance as a cache. The expiration can be set to zero or an empty string in order to set no expiration.`).
+		Description("The field `kind` determines whether the cache targets a single Redis instance, a cluster, or a group of instances managed by Sentinel with `failover`. Items written as a batch, such as by the [`cache` output](/docs/components/outputs/cache), are set within a single pipeline.")
 
 	for _, f := range clientFields() {
 		spec = spec.Field(f)
@@ -179,6 +180,45 @@ func (r *redisCache) Set(ctx context.Context, key string, value []byte, ttl *tim
 	}
 }
 
+func (r *redisCache) SetMulti(ctx context.Context, items ...service.CacheItem) error {
+	boff := r.boffPool.Get().(backoff.BackOff)
+	defer func() {
+		boff.Reset()
+		r.boffPool.Put(boff)
+	}()
+
+	for {
+		_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, item := range items {
+				key := item.Key
+				if len(r.prefix) > 0 {
+					key = r.prefix + key
+				}
+
+				t := r.defaultTTL
+				if item.TTL != nil {
+					t = *item.TTL
+				}
+				pipe.Set(ctx, key, item.Value, t)
+			}
+			return nil
+		})
+		if err == nil {
+			return nil
+		}
+
+		wait := boff.NextBackOff()
+		if wait == backoff.Stop {
+			return err
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
 func (r *redisCache) Add(ctx context.Context, key string, value []byte, ttl *time.Duration) error {
 	boff := r.boffPool.Get().(backoff.BackOff)
 	defer func() {
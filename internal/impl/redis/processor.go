@@ -19,6 +19,7 @@ func redisProcConfig() *service.ConfigSpec {
 		Stable().
 		Summary(`Performs actions against Redis that aren't possible using a ` + "[`cache`](/docs/components/processors/cache)" + ` processor. Actions are
 performed for each message and the message contents are replaced with the result. In order to merge the result into the original message compose this processor within a ` + "[`branch` processor](/docs/components/processors/branch)" + `.`).
+		Description(`When a command returns a nil reply, such as a ` + "`get`" + ` command targeting a key that does not exist, the message contents are replaced with ` + "`null`" + ` rather than the message being flagged as having failed.`).
 		Categories("Integration")
 
 	for _, f := range clientFields() {
@@ -76,6 +77,19 @@ pipeline:
               command: scard
               args_mapping: 'root = [ meta("set_key") ]'
         result_map: 'root.cardinality = this'
+`).
+		Example("Distributed Lock",
+			`The `+"`set`"+` command with the `+"`NX`"+` option can be used in order to acquire a lock that expires after a given period, where a nil reply indicates that the lock is already held elsewhere. Here we set a field `+"`lock_acquired`"+` to `+"`true`"+` when the lock for a given document has been obtained:`,
+			`
+pipeline:
+  processors:
+    - branch:
+        processors:
+          - redis:
+              url: TODO
+              command: set
+              args_mapping: 'root = [ "lock:" + this.doc.id, uuid_v4(), "NX", "PX", 30000 ]'
+        result_map: 'root.lock_acquired = this == "OK"'
 `).
 		Example("Running Total",
 			`If we have JSON data containing number of friends visited during covid 19:
@@ -342,12 +356,12 @@ func (r *redisProc) execRaw(ctx context.Context, index int, inBatch service.Mess
 	args = append([]any{command}, args...)
 
 	res, err := r.client.Do(ctx, args...).Result()
-	for i := 0; i <= r.retries && err != nil; i++ {
+	for i := 0; i <= r.retries && err != nil && !errors.Is(err, redis.Nil); i++ {
 		r.log.Errorf("%v command failed: %v", command, err)
 		<-time.After(r.retryPeriod)
 		res, err = r.client.Do(ctx, args...).Result()
 	}
-	if err != nil {
+	if err != nil && !errors.Is(err, redis.Nil) {
 		return err
 	}
 
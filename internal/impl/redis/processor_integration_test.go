@@ -72,6 +72,12 @@ func TestIntegrationRedisProcessor(t *testing.T) {
 	t.Run("testRedisIncrby", func(t *testing.T) {
 		testRedisIncrby(t, client, urlStr)
 	})
+	t.Run("testRedisNilReply", func(t *testing.T) {
+		testRedisNilReply(t, client, urlStr)
+	})
+	t.Run("testRedisScriptNilReply", func(t *testing.T) {
+		testRedisScriptNilReply(t, client, urlStr)
+	})
 
 	require.NoError(t, client.FlushAll(ctx).Err())
 
@@ -118,6 +124,69 @@ keys_mapping: 'root = [ "key" ]'
 	assert.Equal(t, "key: value", actI)
 }
 
+func testRedisScriptNilReply(t *testing.T, client *redis.Client, url string) {
+	conf, err := redisScriptProcConfig().ParseYAML(fmt.Sprintf(`
+url: %v
+script: "return redis.call('get', KEYS[1])"
+args_mapping: 'root = []'
+keys_mapping: 'root = [ "does_not_exist" ]'
+retries: 0
+`, url), nil)
+	require.NoError(t, err)
+
+	r, err := newRedisScriptProcFromConfig(conf, service.MockResources())
+	require.NoError(t, err)
+
+	resMsgs, response := r.ProcessBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`ignore`)),
+	})
+	require.NoError(t, response)
+
+	require.Len(t, resMsgs, 1)
+	require.Len(t, resMsgs[0], 1)
+	require.NoError(t, resMsgs[0][0].GetError())
+
+	actI, err := resMsgs[0][0].AsStructured()
+	require.NoError(t, err)
+	assert.Nil(t, actI)
+}
+
+func testRedisNilReply(t *testing.T, client *redis.Client, url string) {
+	conf, err := redisProcConfig().ParseYAML(fmt.Sprintf(`
+url: %v
+command: set
+args_mapping: 'root = [ "nillock", this.id, "NX" ]'
+retries: 0
+`, url), nil)
+	require.NoError(t, err)
+
+	r, err := newRedisProcFromConfig(conf, service.MockResources())
+	require.NoError(t, err)
+
+	resMsgs, response := r.ProcessBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"first"}`)),
+		service.NewMessage([]byte(`{"id":"second"}`)),
+	})
+	require.NoError(t, response)
+
+	require.Len(t, resMsgs, 1)
+	require.Len(t, resMsgs[0], 2)
+	require.NoError(t, resMsgs[0][0].GetError())
+	require.NoError(t, resMsgs[0][1].GetError())
+
+	actI, err := resMsgs[0][0].AsStructured()
+	require.NoError(t, err)
+	assert.Equal(t, "OK", actI)
+
+	actI, err = resMsgs[0][1].AsStructured()
+	require.NoError(t, err)
+	assert.Nil(t, actI)
+
+	v, err := client.Get(context.Background(), "nillock").Result()
+	require.NoError(t, err)
+	assert.Equal(t, "first", v)
+}
+
 func testRedisKeys(t *testing.T, client *redis.Client, url string) {
 	conf, err := redisProcConfig().ParseYAML(fmt.Sprintf(`
 url: %v
@@ -78,6 +78,9 @@ func newRedisRatelimitFromConfig(conf *service.ParsedConfig) (*redisRatelimit, e
 	if count <= 0 {
 		return nil, fmt.Errorf("count must be larger than zero")
 	}
+	if interval < time.Millisecond {
+		return nil, fmt.Errorf("interval must be at least one millisecond")
+	}
 
 	return &redisRatelimit{
 		size:   count,
@@ -92,7 +95,14 @@ if current == 1 then
 end
 
 if current > tonumber(ARGV[1]) then
-	return redis.call("PTTL", KEYS[1])
+	local ttl = redis.call("PTTL", KEYS[1])
+	if ttl < 0 then
+		-- The key has lost its expiry, reset it so that the limit cannot
+		-- become blocked indefinitely.
+		redis.call("PEXPIRE", KEYS[1], tonumber(ARGV[2]))
+		return tonumber(ARGV[2])
+	end
+	return ttl
 end
 
 return 0
@@ -117,5 +127,5 @@ func (r *redisRatelimit) Access(ctx context.Context) (time.Duration, error) {
 }
 
 func (r *redisRatelimit) Close(ctx context.Context) error {
-	return nil
+	return r.client.Close()
 }
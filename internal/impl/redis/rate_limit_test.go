@@ -16,7 +16,7 @@ key: asdf`, nil)
 	_, err = newRedisRatelimitFromConfig(conf)
 	require.Error(t, err)
 
-	_, err = redisRatelimitConfig().ParseYAML(`
+	conf, err = redisRatelimitConfig().ParseYAML(`
 url: redis://localhost:6379
 interval: nope
 key: asdf`, nil)
@@ -25,6 +25,15 @@ key: asdf`, nil)
 	_, err = newRedisRatelimitFromConfig(conf)
 	require.Error(t, err)
 
+	conf, err = redisRatelimitConfig().ParseYAML(`
+url: redis://localhost:6379
+interval: 0s
+key: asdf`, nil)
+	require.NoError(t, err)
+
+	_, err = newRedisRatelimitFromConfig(conf)
+	require.Error(t, err)
+
 	_, err = redisRatelimitConfig().ParseYAML(`key: asdf`, nil)
 	require.Error(t, err)
 
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -19,7 +20,9 @@ func redisScriptProcConfig() *service.ConfigSpec {
 		Summary(`Performs actions against Redis using [LUA scripts](https://redis.io/docs/manual/programmability/eval-intro/).`).
 		Description(`Actions are performed for each message and the message contents are replaced with the result.
 
-In order to merge the result into the original message compose this processor within a ` + "[`branch` processor](/docs/components/processors/branch)" + `.`).
+In order to merge the result into the original message compose this processor within a ` + "[`branch` processor](/docs/components/processors/branch)" + `.
+
+Scripts are executed with ` + "`EVALSHA`" + `, falling back to ` + "`EVAL`" + ` when the script has not yet been loaded by the server. When a script returns a nil reply the message contents are replaced with ` + "`null`" + `.`).
 		Categories("Integration")
 
 	for _, f := range clientFields() {
@@ -154,7 +157,7 @@ func (r *redisScriptProc) exec(ctx context.Context, index int, inBatch service.M
 	}
 
 	res, err := r.script.Run(ctx, r.client, keys, args...).Result()
-	for i := 0; i <= r.retries && err != nil; i++ {
+	for i := 0; i <= r.retries && err != nil && !errors.Is(err, redis.Nil); i++ {
 		r.log.Errorf("script failed: %v", err)
 		select {
 		case <-time.After(r.retryPeriod):
@@ -163,7 +166,7 @@ func (r *redisScriptProc) exec(ctx context.Context, index int, inBatch service.M
 		}
 		res, err = r.script.Run(ctx, r.client, keys, args...).Result()
 	}
-	if err != nil {
+	if err != nil && !errors.Is(err, redis.Nil) {
 		return err
 	}
 
@@ -96,6 +96,9 @@ func newSFTPWriter(conf output.SFTPConfig, mgr bundle.NewManagement) (*sftpWrite
 	if s.path, err = mgr.BloblEnvironment().NewField(conf.Path); err != nil {
 		return nil, fmt.Errorf("failed to parse path expression: %w", err)
 	}
+	if s.codecConf.Document && s.path.NumDynamicExpressions() > 0 {
+		return nil, fmt.Errorf("the codec %v does not support an interpolated path", conf.Codec)
+	}
 
 	return s, nil
 }
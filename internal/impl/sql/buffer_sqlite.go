@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"math"
+	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -15,6 +18,7 @@ import (
 	"github.com/vmihailenco/msgpack/v5"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/interop"
 	"github.com/benthosdev/benthos/v4/public/service"
 )
 
@@ -31,12 +35,46 @@ Stored messages are then consumed as a stream from the database and deleted only
 
 Messages are not acknowledged at the input level until they have been added to the SQLite database, and they are not removed from the SQLite database until they have been successfully delivered. This means at-least-once delivery guarantees are preserved in cases where the service is shut down unexpectedly. However, since this process relies on interaction with the disk (wherever the SQLite DB is stored) these delivery guarantees are not resilient to disk corruption or loss.
 
+By default writes are handed to the operating system without waiting for them to be flushed to disk, which survives the Benthos process crashing but not the host itself crashing or losing power. Setting the field `+"`sync_writes`"+` to `+"`true`"+` ensures that messages are only acknowledged once they have been flushed to disk, at the cost of throughput.
+
+## Disk Usage
+
+The field `+"`limit`"+` caps the total size of messages stored within the database, once this limit is reached consumption is stopped with back pressure upstream until stored messages have been delivered. This calculation is based on the size of messages as they are stored, after any `+"`pre_processors`"+` have been applied, and therefore does not account for the overhead of the database itself.
+
+## Replaying Messages
+
+When the field `+"`retention`"+` is set messages are retained within the database for that period after they have been delivered rather than being deleted, and an HTTP endpoint is registered at `+"`/sqlite/replay`"+` that queues retained messages to be delivered again. This allows messages to be recovered after a downstream outage without consuming them again from the source.
+
+The endpoint accepts `+"`POST`"+` requests with a query parameter `+"`from`"+`, which is an RFC 3339 timestamp, and retained messages that were originally written to the buffer at or after that time are replayed in the order they were written. Replayed messages are queued behind any messages that have not yet been delivered, and the number of messages replayed is returned in the response body:
+
+`+"```sh"+`
+curl -X POST "http://localhost:4195/sqlite/replay?from=2023-06-01T15:00:00Z"
+`+"```"+`
+
+Retained messages are not counted towards the `+"`limit`"+` until they are replayed.
+
 ## Batching
 
 Messages that are logically batched at the point where they are added to the buffer will continue to be associated with that batch when they are consumed. This buffer is also more efficient when storing messages within batches, and therefore it is recommended to use batching at the input level in high-throughput use cases even if they are not required for processing.
 `).
 		Field(service.NewStringField("path").
 			Description(`The path of the database file, which will be created if it does not already exist.`)).
+		Field(service.NewIntField("limit").
+			Description(`The maximum total size (in bytes) of messages to store before applying back pressure upstream. Set to zero in order to disable the limit.`).
+			Default(0).
+			Advanced().
+			Version("4.18.0")).
+		Field(service.NewBoolField("sync_writes").
+			Description(`Whether to wait for writes to be flushed to disk before messages are acknowledged at the input level.`).
+			Default(false).
+			Advanced().
+			Version("4.18.0")).
+		Field(service.NewDurationField("retention").
+			Description(`An optional period of time to retain messages within the database after they have been delivered, allowing them to be replayed.`).
+			Example("1h").
+			Optional().
+			Advanced().
+			Version("4.18.0")).
 		Field(service.NewProcessorListField("pre_processors").
 			Description(`An optional list of processors to apply to messages before they are stored within the buffer. These processors are useful for compressing, archiving or otherwise reducing the data in size before it's stored on disk.`).
 			Optional()).
@@ -84,6 +122,25 @@ func NewSQLiteBufferFromConfig(conf *service.ParsedConfig, res *service.Resource
 		return nil, err
 	}
 
+	limit, err := conf.FieldInt("limit")
+	if err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+	syncWrites, err := conf.FieldBool("sync_writes")
+	if err != nil {
+		return nil, err
+	}
+
+	var retention time.Duration
+	if conf.Contains("retention") {
+		if retention, err = conf.FieldDuration("retention"); err != nil {
+			return nil, err
+		}
+	}
+
 	var preProcs, postProcs []*service.OwnedProcessor
 	if conf.Contains("pre_processors") {
 		if preProcs, err = conf.FieldProcessorList("pre_processors"); err != nil {
@@ -96,7 +153,18 @@ func NewSQLiteBufferFromConfig(conf *service.ParsedConfig, res *service.Resource
 		}
 	}
 
-	return newSQLiteBuffer(path, preProcs, postProcs)
+	buf, err := newSQLiteBuffer(path, limit, syncWrites, retention, preProcs, postProcs)
+	if err != nil {
+		return nil, err
+	}
+	if retention > 0 {
+		interop.UnwrapManagement(res).RegisterEndpoint(
+			"/sqlite/replay",
+			"Replay messages retained by the sqlite buffer that were written at or after the RFC 3339 timestamp specified by the query parameter `from`.",
+			buf.handleReplay,
+		)
+	}
+	return buf, nil
 }
 
 //------------------------------------------------------------------------------
@@ -106,6 +174,19 @@ type SQLiteBuffer struct {
 	db        *sql.DB
 	preProcs  []*service.OwnedProcessor
 	postProcs []*service.OwnedProcessor
+	limit     int
+	retention time.Duration
+
+	lastPurge   time.Time
+	storedBytes int
+
+	// The backlog is tracked in memory so that it can be reported without
+	// querying the DB, with the exception of the oldest timestamp, which is
+	// only queried when it might have changed.
+	backlogMessages int
+	backlogBytes    int
+	backlogOldest   int64
+	oldestStale     bool
 
 	pending     []ackableBatch
 	cond        *sync.Cond
@@ -115,94 +196,335 @@ type SQLiteBuffer struct {
 	closed      bool
 }
 
-func newSQLiteBuffer(path string, preProcs, postProcs []*service.OwnedProcessor) (*SQLiteBuffer, error) {
-	db, err := sql.Open("sqlite", path)
+func newSQLiteBuffer(path string, limit int, syncWrites bool, retention time.Duration, preProcs, postProcs []*service.OwnedProcessor) (*SQLiteBuffer, error) {
+	// The synchronous pragma only applies to the connection it is executed
+	// on, and therefore it's set via the DSN so that every connection of the
+	// pool gets it.
+	synchronous := 0
+	if syncWrites {
+		synchronous = 2
+	}
+	dsnSep := "?"
+	if strings.Contains(path, "?") {
+		dsnSep = "&"
+	}
+	db, err := sql.Open("sqlite", fmt.Sprintf("%v%v_pragma=synchronous(%v)", path, dsnSep, synchronous))
 	if err != nil {
 		return nil, err
 	}
 
 	if _, err = db.Exec(`
-PRAGMA synchronous = 0;
-
 CREATE TABLE IF NOT EXISTS messages (
-  id       INTEGER PRIMARY KEY AUTOINCREMENT,
-  content  TEXT NOT NULL,
-  requeue  INTEGER NOT NULL
+  id         INTEGER PRIMARY KEY AUTOINCREMENT,
+  content    TEXT NOT NULL,
+  requeue    INTEGER NOT NULL,
+  created    INTEGER NOT NULL DEFAULT 0,
+  delivered  INTEGER NOT NULL DEFAULT 0
 )
 `); err != nil {
 		return nil, err
 	}
 
-	return &SQLiteBuffer{
-		db:        db,
-		preProcs:  preProcs,
-		postProcs: postProcs,
-		cond:      sync.NewCond(&sync.Mutex{}),
-	}, nil
+	// Databases created by older versions lack the columns used for retention
+	// and backlog reporting.
+	for _, column := range []string{"created", "delivered", "messages"} {
+		if err = addColumnIfMissing(db, column); err != nil {
+			return nil, err
+		}
+	}
+	if err = backfillMessageCounts(db); err != nil {
+		return nil, err
+	}
+	if _, err = db.Exec(`CREATE INDEX IF NOT EXISTS messages_unread ON messages (delivered, created)`); err != nil {
+		return nil, err
+	}
+
+	// Messages left over from a previous run count towards the limit, and are
+	// all yet to be read.
+	var storedMessages, storedBytes int
+	if err = db.QueryRow(`SELECT COALESCE(SUM(messages), 0), COALESCE(SUM(LENGTH(CAST(content AS BLOB))), 0) FROM messages WHERE delivered = 0`).Scan(&storedMessages, &storedBytes); err != nil {
+		return nil, err
+	}
+
+	m := &SQLiteBuffer{
+		db:              db,
+		preProcs:        preProcs,
+		postProcs:       postProcs,
+		limit:           limit,
+		retention:       retention,
+		storedBytes:     storedBytes,
+		backlogMessages: storedMessages,
+		backlogBytes:    storedBytes,
+		oldestStale:     true,
+		cond:            sync.NewCond(&sync.Mutex{}),
+	}
+	if err = m.purgeExpired(context.Background()); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func addColumnIfMissing(db *sql.DB, column string) error {
+	var exists int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('messages') WHERE name = ?`, column).Scan(&exists); err != nil {
+		return err
+	}
+	if exists > 0 {
+		return nil
+	}
+	_, err := db.Exec(fmt.Sprintf(`ALTER TABLE messages ADD COLUMN %v INTEGER NOT NULL DEFAULT 0`, column))
+	return err
+}
+
+// backfillMessageCounts sets the messages column of rows written by older
+// versions from the batch header of their contents.
+func backfillMessageCounts(db *sql.DB) error {
+	rows, err := db.Query(`SELECT id, SUBSTR(CAST(content AS BLOB), 1, 8) FROM messages WHERE messages = 0`)
+	if err != nil {
+		return err
+	}
+
+	counts := map[int]uint32{}
+	for rows.Next() {
+		var id int
+		var header []byte
+		if err := rows.Scan(&id, &header); err != nil {
+			rows.Close()
+			return err
+		}
+		// The header consists of the marshal version followed by the number
+		// of messages in the batch.
+		if _, header, err = readUint32(header); err != nil {
+			continue
+		}
+		if n, _, err := readUint32(header); err == nil && n > 0 {
+			counts[id] = n
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, n := range counts {
+		if _, err := db.Exec(`UPDATE messages SET messages = ? WHERE id = ?`, n, id); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 //------------------------------------------------------------------------------
 
+// unreadRows selects the rows that are yet to be read, either because they
+// follow the last row read or because they were requeued after a nack.
+func unreadRows(nextIndex, requeueFrom int) squirrel.Sqlizer {
+	return squirrel.And{
+		squirrel.Or{
+			squirrel.GtOrEq{"id": nextIndex},
+			squirrel.And{
+				squirrel.Gt{"requeue": requeueFrom},
+				squirrel.NotEq{"requeue": maxRequeue},
+			},
+		},
+		squirrel.Eq{"delivered": 0},
+	}
+}
+
+// storedRow describes a row that has been read from the DB.
+type storedRow struct {
+	index    int
+	size     int
+	messages int
+}
+
 // returns nil, nil when the rows are empty.
-func (m *SQLiteBuffer) tryGetBatch(ctx context.Context) (service.MessageBatch, int, error) {
-	var index int
+func (m *SQLiteBuffer) tryGetBatch(ctx context.Context) (service.MessageBatch, storedRow, error) {
+	var row storedRow
 	var requeueFrom int
+	var created int64
 	var contentBytes []byte
 
-	if err := queryRowRetries(ctx, squirrel.Select("id", "content", "requeue").
+	if err := queryRowRetries(ctx, squirrel.Select("id", "content", "requeue", "messages", "created").
 		From("messages").
-		Where(squirrel.Or{
-			squirrel.GtOrEq{"id": m.nextIndex},
-			squirrel.And{
-				squirrel.Gt{"requeue": m.requeueFrom},
-				squirrel.NotEq{"requeue": maxRequeue},
-			},
-		}).
+		Where(unreadRows(m.nextIndex, m.requeueFrom)).
 		OrderBy("requeue, id").
 		Limit(1).
-		RunWith(m.db), &index, &contentBytes, &requeueFrom); err != nil {
+		RunWith(m.db), &row.index, &contentBytes, &requeueFrom, &row.messages, &created); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = nil
 		}
-		return nil, 0, err
+		return nil, row, err
 	}
 
 	if requeueFrom != maxRequeue {
 		m.requeueFrom = requeueFrom
 	}
-	m.nextIndex = index + 1
+	m.nextIndex = row.index + 1
+
+	row.size = len(contentBytes)
+	m.backlogMessages -= row.messages
+	m.backlogBytes -= row.size
+	if created <= m.backlogOldest {
+		m.oldestStale = true
+	}
 
 	batch, _, err := readBatch(contentBytes)
-	return batch, index, err
+	return batch, row, err
 }
 
-func (m *SQLiteBuffer) requeue(ctx context.Context, index int) error {
+func (m *SQLiteBuffer) requeue(ctx context.Context, row storedRow) error {
 	if m.db == nil {
 		return errors.New("connection closed")
 	}
 	_, err := execRetries(ctx, squirrel.Update("messages").
 		Set("requeue", time.Now().UnixNano()).
-		Where(squirrel.Eq{"id": index}).
+		Where(squirrel.Eq{"id": row.index}).
 		RunWith(m.db))
+	if err == nil {
+		m.backlogMessages += row.messages
+		m.backlogBytes += row.size
+		m.oldestStale = true
+	}
 	m.cond.Broadcast()
 	return err
 }
 
+func (m *SQLiteBuffer) markDelivered(ctx context.Context, index int) error {
+	if m.retention <= 0 {
+		_, err := execRetries(ctx, squirrel.Delete("messages").
+			Where(squirrel.Eq{"id": index}).
+			RunWith(m.db))
+		return err
+	}
+
+	if _, err := execRetries(ctx, squirrel.Update("messages").
+		Set("delivered", time.Now().UnixNano()).
+		Where(squirrel.Eq{"id": index}).
+		RunWith(m.db)); err != nil {
+		return err
+	}
+
+	// Purging on every acknowledgement would be wasteful, and so we only
+	// purge at most once per second.
+	if time.Since(m.lastPurge) < time.Second {
+		return nil
+	}
+	return m.purgeExpired(ctx)
+}
+
+func (m *SQLiteBuffer) purgeExpired(ctx context.Context) error {
+	if m.retention <= 0 {
+		return nil
+	}
+	m.lastPurge = time.Now()
+	_, err := execRetries(ctx, squirrel.Delete("messages").
+		Where(squirrel.And{
+			squirrel.NotEq{"delivered": 0},
+			squirrel.Lt{"delivered": m.lastPurge.Add(-m.retention).UnixNano()},
+		}).
+		RunWith(m.db))
+	return err
+}
+
+// Replay queues messages retained after delivery that were written at or after
+// a given time to be delivered again, and returns the number of messages that
+// were replayed.
+func (m *SQLiteBuffer) Replay(ctx context.Context, from time.Time) (int, error) {
+	m.cond.L.Lock()
+	defer m.cond.L.Unlock()
+
+	if m.closed {
+		return 0, component.ErrTypeClosed
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	replayed := squirrel.And{
+		squirrel.NotEq{"delivered": 0},
+		squirrel.GtOrEq{"created": from.UnixNano()},
+	}
+
+	var count, messages, size int
+	if err := squirrel.Select("COUNT(*)", "COALESCE(SUM(messages), 0)", "COALESCE(SUM(LENGTH(CAST(content AS BLOB))), 0)").
+		From("messages").
+		Where(replayed).
+		RunWith(tx).
+		QueryRowContext(ctx).
+		Scan(&count, &messages, &size); err != nil {
+		return 0, err
+	}
+
+	// Replayed messages are copied to the end of the queue so that they are
+	// consumed in order once pending messages have been read.
+	if _, err := squirrel.Insert("messages").
+		Columns("content", "requeue", "created", "messages").
+		Select(squirrel.Select("content", strconv.Itoa(maxRequeue), "created", "messages").
+			From("messages").
+			Where(replayed).
+			OrderBy("id")).
+		RunWith(tx).
+		ExecContext(ctx); err != nil {
+		return 0, err
+	}
+	if _, err := squirrel.Delete("messages").
+		Where(replayed).
+		RunWith(tx).
+		ExecContext(ctx); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	m.storedBytes += size
+	m.backlogMessages += messages
+	m.backlogBytes += size
+	m.oldestStale = true
+	m.cond.Broadcast()
+	return count, nil
+}
+
+func (m *SQLiteBuffer) handleReplay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse query parameter from: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	count, err := m.Replay(r.Context(), from)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%v", count)
+}
+
 type ackableBatch struct {
 	b   service.MessageBatch
 	aFn service.AckFunc
 }
 
-func (m *SQLiteBuffer) toAckableBatches(batches []service.MessageBatch, index int) []ackableBatch {
+func (m *SQLiteBuffer) toAckableBatches(batches []service.MessageBatch, row storedRow) []ackableBatch {
 	endAckFn := func(ctx context.Context, err error) (ackErr error) {
 		m.cond.L.Lock()
 		defer m.cond.L.Unlock()
 		if err != nil {
-			ackErr = m.requeue(ctx, index)
-		} else {
-			_, ackErr = execRetries(ctx, squirrel.Delete("messages").
-				Where(squirrel.Eq{"id": index}).
-				RunWith(m.db))
+			ackErr = m.requeue(ctx, row)
+		} else if ackErr = m.markDelivered(ctx, row.index); ackErr == nil {
+			m.storedBytes -= row.size
+			m.cond.Broadcast()
 		}
 		return
 	}
@@ -238,7 +560,9 @@ func (m *SQLiteBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 
 	go func() {
 		<-ctx.Done()
+		m.cond.L.Lock()
 		m.cond.Broadcast()
+		m.cond.L.Unlock()
 	}()
 
 	m.cond.L.Lock()
@@ -252,7 +576,7 @@ func (m *SQLiteBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 			return nil, nil, ctx.Err()
 		}
 
-		nextBatch, outIndex, err := m.tryGetBatch(ctx)
+		nextBatch, outRow, err := m.tryGetBatch(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -269,7 +593,7 @@ func (m *SQLiteBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 				}
 				resBatches = tmpResBatch
 			}
-			if m.pending = m.toAckableBatches(resBatches, outIndex); len(m.pending) > 0 {
+			if m.pending = m.toAckableBatches(resBatches, outRow); len(m.pending) > 0 {
 				break
 			}
 			continue
@@ -277,6 +601,9 @@ func (m *SQLiteBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 		if m.endOfInput {
 			return nil, nil, service.ErrEndOfBuffer
 		}
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
 
 		// None of our exit conditions triggered, so exit
 		m.cond.Wait()
@@ -289,6 +616,16 @@ func (m *SQLiteBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 
 // WriteBatch adds a new message to the DB.
 func (m *SQLiteBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageBatch, aFn service.AckFunc) error {
+	ctx, done := context.WithCancel(ctx)
+	defer done()
+
+	go func() {
+		<-ctx.Done()
+		m.cond.L.Lock()
+		m.cond.Broadcast()
+		m.cond.L.Unlock()
+	}()
+
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
 
@@ -309,18 +646,44 @@ func (m *SQLiteBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageB
 		msgBatches = tmpResBatch
 	}
 
-	builder := squirrel.Insert("messages").Columns("content", "requeue")
+	extraBytes, extraMessages := 0, 0
+	created := time.Now().UnixNano()
+	builder := squirrel.Insert("messages").Columns("content", "requeue", "created", "messages")
 	for _, batch := range msgBatches {
 		contentBytes, err := appendBatchV0(nil, batch)
 		if err != nil {
 			return err
 		}
-		builder = builder.Values(contentBytes, maxRequeue)
+		extraBytes += len(contentBytes)
+		extraMessages += len(batch)
+		builder = builder.Values(contentBytes, maxRequeue, created, len(batch))
+	}
+
+	if m.limit > 0 {
+		if extraBytes > m.limit {
+			return component.ErrMessageTooLarge
+		}
+		for (m.storedBytes + extraBytes) > m.limit {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			m.cond.Wait()
+			if m.closed {
+				return component.ErrTypeClosed
+			}
+		}
 	}
 
 	if _, err := execRetries(ctx, builder.RunWith(m.db)); err != nil {
 		return err
 	}
+	m.storedBytes += extraBytes
+	m.backlogMessages += extraMessages
+	m.backlogBytes += extraBytes
+	if m.backlogOldest == 0 {
+		m.backlogOldest = created
+	}
+
 	if err := aFn(ctx, nil); err != nil {
 		return err
 	}
@@ -329,6 +692,46 @@ func (m *SQLiteBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageB
 	return nil
 }
 
+// Backlog returns the messages stored within the DB that are yet to be read.
+func (m *SQLiteBuffer) Backlog() service.BufferBacklog {
+	m.cond.L.Lock()
+	defer m.cond.L.Unlock()
+
+	if m.closed {
+		return service.BufferBacklog{}
+	}
+
+	if m.oldestStale {
+		// Rows are scanned in order of creation using the messages_unread
+		// index, and therefore this only visits rows that are being read.
+		var oldest int64
+		if err := squirrel.Select("created").
+			From("messages").
+			Where(squirrel.And{
+				unreadRows(m.nextIndex, m.requeueFrom),
+				squirrel.Gt{"created": 0},
+			}).
+			OrderBy("created").
+			Limit(1).
+			RunWith(m.db).
+			QueryRow().
+			Scan(&oldest); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return service.BufferBacklog{}
+		}
+		m.backlogOldest = oldest
+		m.oldestStale = false
+	}
+
+	b := service.BufferBacklog{
+		Messages: m.backlogMessages,
+		Bytes:    m.backlogBytes,
+	}
+	if m.backlogOldest > 0 {
+		b.Oldest = time.Unix(0, m.backlogOldest)
+	}
+	return b
+}
+
 // EndOfInput signals to the buffer that the input is finished and therefore
 // once the DB is drained it should close.
 func (m *SQLiteBuffer) EndOfInput() {
@@ -346,6 +749,7 @@ func (m *SQLiteBuffer) Close(ctx context.Context) error {
 	m.cond.L.Lock()
 	m.closed = true
 	err := m.db.Close()
+	m.cond.Broadcast()
 	m.cond.L.Unlock()
 	return err
 }
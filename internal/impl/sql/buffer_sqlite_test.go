@@ -2,6 +2,7 @@ package sql_test
 
 import (
 	"context"
+	gosql "database/sql"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/impl/sql"
 	"github.com/benthosdev/benthos/v4/public/service"
 
@@ -634,3 +636,272 @@ post_processors:
 
 	wg.Wait()
 }
+
+func TestBufferSQLiteLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	ctx := context.Background()
+	block := memBufFromConf(t, fmt.Sprintf(`
+path: "%v"
+limit: 30
+sync_writes: true
+`, filepath.Join(tmpDir, "foo.db")))
+	defer block.Close(ctx)
+
+	noopAck := func(ctx context.Context, err error) error { return nil }
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("first")),
+	}, noopAck))
+
+	// A batch that could never fit within the limit is rejected.
+	require.Equal(t, component.ErrMessageTooLarge, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("this message is far larger than the limit")),
+	}, noopAck))
+
+	// The second write must wait until the first has been delivered.
+	tCtx, done := context.WithTimeout(ctx, time.Millisecond*50)
+	require.Equal(t, context.DeadlineExceeded, block.WriteBatch(tCtx, service.MessageBatch{
+		service.NewMessage([]byte("second")),
+	}, noopAck))
+	done()
+
+	// A write with an already cancelled context must not block.
+	cCtx, cDone := context.WithCancel(ctx)
+	cDone()
+	require.Equal(t, context.Canceled, block.WriteBatch(cCtx, service.MessageBatch{
+		service.NewMessage([]byte("second")),
+	}, noopAck))
+
+	writeErr := make(chan error)
+	go func() {
+		writeErr <- block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte("second")),
+		}, noopAck)
+	}()
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	msgEqualStr(t, "first", m[0])
+
+	select {
+	case err := <-writeErr:
+		t.Fatalf("write should be blocked: %v", err)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	require.NoError(t, ackFunc(ctx, nil))
+	require.NoError(t, <-writeErr)
+
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	msgEqualStr(t, "second", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+}
+
+func TestBufferSQLiteLimitRestart(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "foo.db")
+
+	ctx := context.Background()
+	noopAck := func(ctx context.Context, err error) error { return nil }
+
+	block := memBufFromConf(t, fmt.Sprintf(`
+path: "%v"
+`, dbPath))
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("first")),
+	}, noopAck))
+	require.NoError(t, block.Close(ctx))
+
+	// Messages stored by the previous run count towards the limit.
+	block = memBufFromConf(t, fmt.Sprintf(`
+path: "%v"
+limit: 30
+`, dbPath))
+	defer block.Close(ctx)
+
+	tCtx, done := context.WithTimeout(ctx, time.Millisecond*50)
+	require.Equal(t, context.DeadlineExceeded, block.WriteBatch(tCtx, service.MessageBatch{
+		service.NewMessage([]byte("second")),
+	}, noopAck))
+	done()
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	msgEqualStr(t, "first", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("second")),
+	}, noopAck))
+}
+
+func TestBufferSQLiteReplay(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "foo.db")
+
+	ctx := context.Background()
+	noopAck := func(ctx context.Context, err error) error { return nil }
+
+	block := memBufFromConf(t, fmt.Sprintf(`
+path: "%v"
+retention: 1h
+`, dbPath))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(fmt.Sprintf("test%v", i))),
+		}, noopAck))
+	}
+
+	for i := 0; i < 3; i++ {
+		m, ackFunc, err := block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		msgEqualStr(t, fmt.Sprintf("test%v", i), m[0])
+		require.NoError(t, ackFunc(ctx, nil))
+	}
+
+	count, err := block.Replay(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = block.Replay(ctx, start)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	backlog := block.Backlog()
+	assert.Equal(t, 3, backlog.Messages)
+	assert.False(t, backlog.Oldest.Before(start))
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("test3")),
+	}, noopAck))
+	assert.Equal(t, 4, block.Backlog().Messages)
+	assert.Equal(t, backlog.Oldest, block.Backlog().Oldest)
+
+	for _, exp := range []string{"test0", "test1", "test2", "test3"} {
+		m, ackFunc, err := block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		msgEqualStr(t, exp, m[0])
+		require.NoError(t, ackFunc(ctx, nil))
+	}
+	assert.Equal(t, 0, block.Backlog().Messages)
+	assert.Equal(t, 0, block.Backlog().Bytes)
+	require.NoError(t, block.Close(ctx))
+
+	// Retained messages that have expired are purged on start up.
+	block = memBufFromConf(t, fmt.Sprintf(`
+path: "%v"
+retention: 1ns
+`, dbPath))
+	defer block.Close(ctx)
+
+	count, err = block.Replay(ctx, start)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestBufferSQLiteOldSchema(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "foo.db")
+
+	db, err := gosql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+CREATE TABLE messages (
+  id       INTEGER PRIMARY KEY AUTOINCREMENT,
+  content  TEXT NOT NULL,
+  requeue  INTEGER NOT NULL
+)`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	ctx := context.Background()
+	block := memBufFromConf(t, fmt.Sprintf(`
+path: "%v"
+retention: 1h
+`, dbPath))
+	defer block.Close(ctx)
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("test")),
+	}, func(ctx context.Context, err error) error { return nil }))
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	msgEqualStr(t, "test", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+}
+
+func TestBufferSQLiteBacklog(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "foo.db")
+
+	ctx := context.Background()
+	block := memBufFromConf(t, fmt.Sprintf(`
+path: "%v"
+`, dbPath))
+
+	assert.Equal(t, service.BufferBacklog{}, block.Backlog())
+
+	noopAck := func(ctx context.Context, err error) error { return nil }
+
+	before := time.Now()
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("first")),
+		service.NewMessage([]byte("second")),
+	}, noopAck))
+	<-time.After(time.Millisecond)
+	between := time.Now()
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("third")),
+	}, noopAck))
+
+	backlog := block.Backlog()
+	assert.Equal(t, 3, backlog.Messages)
+	assert.Greater(t, backlog.Bytes, 0)
+	assert.False(t, backlog.Oldest.Before(before))
+	assert.True(t, backlog.Oldest.Before(between))
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 2)
+
+	afterRead := block.Backlog()
+	assert.Equal(t, 1, afterRead.Messages)
+	assert.Less(t, afterRead.Bytes, backlog.Bytes)
+	assert.False(t, afterRead.Oldest.Before(between))
+
+	// A nack returns the batch to the backlog.
+	require.NoError(t, ackFunc(ctx, errors.New("nope")))
+	assert.Equal(t, backlog, block.Backlog())
+
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 2)
+	require.NoError(t, ackFunc(ctx, nil))
+	assert.Equal(t, afterRead, block.Backlog())
+	require.NoError(t, block.Close(ctx))
+
+	// Rows written by versions without a messages column are backfilled.
+	db, err := gosql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE messages SET messages = 0`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	block = memBufFromConf(t, fmt.Sprintf(`
+path: "%v"
+`, dbPath))
+	defer block.Close(ctx)
+
+	assert.Equal(t, 1, block.Backlog().Messages)
+}
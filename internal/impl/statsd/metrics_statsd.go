@@ -3,6 +3,7 @@ package statsd
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	statsd "github.com/smira/go-statsd"
@@ -23,13 +24,25 @@ Pushes metrics using the [StatsD protocol](https://github.com/statsd/statsd).
 Supported tagging formats are 'none', 'datadog' and 'influxdb'.`,
 		Description: `
 The underlying client library has recently been updated in order to support
-tagging.`,
+tagging.
+
+When a tag format other than 'none' is used labels of metrics, such as the
+label and path of the component, are sent as tags. The field ` + "`tags`" + ` can
+also be used in order to add a fixed set of tags to all metrics, which can
+make use of [environment variables](/docs/configuration/interpolation#environment-variables)
+in order to identify the host or deployment.`,
 		Config: docs.FieldComponent().WithChildren(
 			docs.FieldString("address", "The address to send metrics to.").HasDefault(""),
 			docs.FieldString("flush_period", "The time interval between metrics flushes.").HasDefault("100ms"),
 			docs.FieldString("tag_format", "Metrics tagging is supported in a variety of formats.").HasOptions(
 				"none", "datadog", "influxdb",
 			).HasDefault("none"),
+			docs.FieldString("tags", "A map of tags to add to all metrics, requires a `tag_format` other than 'none'.",
+				map[string]string{
+					"service":  "ingest",
+					"hostname": "${HOSTNAME}",
+				},
+			).Map().Advanced().HasDefault(map[string]any{}).AtVersion("4.18.0"),
 		),
 	})
 }
@@ -107,10 +120,27 @@ func newStatsd(config metrics.Config, nm bundle.NewManagement) (metrics.Type, er
 	case TagFormatDatadog:
 		statsdOpts = append(statsdOpts, statsd.TagStyle(statsd.TagFormatDatadog))
 	case TagFormatNone:
+		if len(config.Statsd.Tags) > 0 {
+			return nil, fmt.Errorf("tags cannot be added with the tag format '%s'", TagFormatNone)
+		}
 	default:
 		return nil, fmt.Errorf("tag format '%s' was not recognised", config.Statsd.TagFormat)
 	}
 
+	if len(config.Statsd.Tags) > 0 {
+		tagKeys := make([]string, 0, len(config.Statsd.Tags))
+		for k := range config.Statsd.Tags {
+			tagKeys = append(tagKeys, k)
+		}
+		sort.Strings(tagKeys)
+
+		defaultTags := make([]statsd.Tag, len(tagKeys))
+		for i, k := range tagKeys {
+			defaultTags[i] = statsd.StringTag(k, config.Statsd.Tags[k])
+		}
+		statsdOpts = append(statsdOpts, statsd.DefaultTags(defaultTags...))
+	}
+
 	client := statsd.NewClient(config.Statsd.Address, statsdOpts...)
 
 	s.s = client
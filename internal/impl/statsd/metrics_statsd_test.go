@@ -0,0 +1,53 @@
+package statsd
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+)
+
+func TestStatsdTags(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	conf := metrics.NewConfig()
+	conf.Statsd.Address = conn.LocalAddr().String()
+	conf.Statsd.FlushPeriod = "10ms"
+	conf.Statsd.TagFormat = TagFormatDatadog
+	conf.Statsd.Tags = map[string]string{
+		"service": "ingest",
+		"env":     "prod",
+	}
+
+	s, err := newStatsd(conf, mock.NewManager())
+	require.NoError(t, err)
+
+	s.GetCounterVec("foo", "label").With("bar").Incr(3)
+	require.NoError(t, s.Close())
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second*5)))
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo:3|c|#env:prod,service:ingest,label:bar", strings.TrimSpace(string(buf[:n])))
+}
+
+func TestStatsdTagsNoFormat(t *testing.T) {
+	conf := metrics.NewConfig()
+	conf.Statsd.Address = "127.0.0.1:8125"
+	conf.Statsd.Tags = map[string]string{
+		"service": "ingest",
+	}
+
+	_, err := newStatsd(conf, mock.NewManager())
+	require.EqualError(t, err, "tags cannot be added with the tag format 'none'")
+}
@@ -0,0 +1,101 @@
+package useragent
+
+import (
+	"context"
+
+	"github.com/mssola/useragent"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func processorConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Parsing").
+		Summary("Parses the contents of messages as a user agent string and replaces them with a JSON object describing the browser, operating system and device.").
+		Description(`
+The resulting object takes the following form, where fields that could not be determined are left empty:
+
+`+"```json"+`
+{
+  "browser": { "name": "Chrome", "version": "119.0.0.0" },
+  "engine": { "name": "AppleWebKit", "version": "537.36" },
+  "os": { "name": "Android", "version": "13", "full_name": "Android 13" },
+  "device": { "platform": "Linux", "model": "Pixel 7", "mobile": true, "bot": false }
+}
+`+"```"+`
+
+In order to parse a user agent string from a field of a structured message and merge the result back into the original message compose this processor within a `+"[`branch` processor](/docs/components/processors/branch)"+`.`).
+		Field(service.NewObjectField("").Default(struct{}{})).
+		Example(
+			"Enriching Access Logs",
+			"Given access logs containing a `user_agent` field we can add a `client` field describing the browser, operating system and device of each request:",
+			`
+pipeline:
+  processors:
+    - branch:
+        request_map: 'root = this.user_agent'
+        processors:
+          - parse_user_agent: {}
+        result_map: 'root.client = this'
+`,
+		).
+		Version("4.18.0")
+}
+
+func init() {
+	err := service.RegisterProcessor(
+		"parse_user_agent", processorConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Processor, error) {
+			return &processor{}, nil
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+type processor struct{}
+
+func userAgentToStructured(uaStr string) map[string]any {
+	ua := useragent.New(uaStr)
+
+	browserName, browserVersion := ua.Browser()
+	engineName, engineVersion := ua.Engine()
+	osInfo := ua.OSInfo()
+
+	return map[string]any{
+		"browser": map[string]any{
+			"name":    browserName,
+			"version": browserVersion,
+		},
+		"engine": map[string]any{
+			"name":    engineName,
+			"version": engineVersion,
+		},
+		"os": map[string]any{
+			"name":      osInfo.Name,
+			"version":   osInfo.Version,
+			"full_name": osInfo.FullName,
+		},
+		"device": map[string]any{
+			"platform": ua.Platform(),
+			"model":    ua.Model(),
+			"mobile":   ua.Mobile(),
+			"bot":      ua.Bot(),
+		},
+	}
+}
+
+func (p *processor) Process(ctx context.Context, msg *service.Message) (service.MessageBatch, error) {
+	mBytes, err := msg.AsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	msg.SetStructuredMut(userAgentToStructured(string(mBytes)))
+	return service.MessageBatch{msg}, nil
+}
+
+func (p *processor) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,65 @@
+package useragent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]any
+	}{
+		{
+			name:  "android chrome",
+			input: "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Mobile Safari/537.36",
+			expected: map[string]any{
+				"browser": map[string]any{"name": "Chrome", "version": "119.0.0.0"},
+				"engine":  map[string]any{"name": "AppleWebKit", "version": "537.36"},
+				"os":      map[string]any{"name": "Android", "version": "13", "full_name": "Android 13"},
+				"device":  map[string]any{"platform": "Linux", "model": "Pixel 7", "mobile": true, "bot": false},
+			},
+		},
+		{
+			name:  "windows firefox",
+			input: "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/119.0",
+			expected: map[string]any{
+				"browser": map[string]any{"name": "Firefox", "version": "119.0"},
+				"engine":  map[string]any{"name": "Gecko", "version": "20100101"},
+				"os":      map[string]any{"name": "Windows", "version": "10", "full_name": "Windows 10"},
+				"device":  map[string]any{"platform": "Windows", "model": "", "mobile": false, "bot": false},
+			},
+		},
+		{
+			name:  "bot",
+			input: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expected: map[string]any{
+				"browser": map[string]any{"name": "Googlebot", "version": "2.1"},
+				"engine":  map[string]any{"name": "", "version": ""},
+				"os":      map[string]any{"name": "", "version": "", "full_name": ""},
+				"device":  map[string]any{"platform": "", "model": "", "mobile": false, "bot": true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			proc := &processor{}
+
+			msgs, err := proc.Process(context.Background(), service.NewMessage([]byte(test.input)))
+			require.NoError(t, err)
+			require.Len(t, msgs, 1)
+
+			act, err := msgs[0].AsStructured()
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, act)
+		})
+	}
+}
@@ -126,7 +126,10 @@ func (p *wazeroAllocProcessor) newModule() (mod *moduleRunner, err error) {
 		return
 	}
 
-	mod.process = mod.mod.ExportedFunction(p.functionName)
+	if mod.process = mod.mod.ExportedFunction(p.functionName); mod.process == nil {
+		err = fmt.Errorf("function %v is not exported by the module", p.functionName)
+		return
+	}
 	mod.goMalloc = mod.mod.ExportedFunction("malloc")
 	mod.goFree = mod.mod.ExportedFunction("free")
 	mod.rustAlloc = mod.mod.ExportedFunction("allocate")
@@ -217,6 +220,10 @@ func (r *moduleRunner) allocateBytesInbound(ctx context.Context, data []byte) (c
 	if err != nil {
 		return
 	}
+	if len(results) == 0 {
+		err = errors.New("module does not export a malloc or allocate function")
+		return
+	}
 
 	contentPtr = results[0]
 
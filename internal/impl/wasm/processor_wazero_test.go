@@ -168,3 +168,11 @@ func BenchmarkWazeroWASIRustCalls(b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+func TestWazeroMissingFunction(t *testing.T) {
+	// The smallest valid WASM module, which exports nothing.
+	emptyModule := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	_, err := newWazeroAllocProcessor("process", emptyModule, service.MockResources())
+	require.EqualError(t, err, "function process is not exported by the module")
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/clbanning/mxj/v2"
+
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/docs"
@@ -90,9 +92,24 @@ With cast set to true, the resulting JSON structure would look like this:
     ]
   }
 }
+` + "```" + `
+
+### ` + "`from_json`" + `
+
+Converts a JSON document into XML, following the inverse of the rules applied by
+the ` + "`to_json`" + ` operator. Keys prefixed with a hyphen, ` + "`-`" + `, become
+attributes of their parent element, the key ` + "`#text`" + ` becomes the text of
+its parent element and arrays result in repeated elements. The document must be
+an object, and if it contains more than one key the elements are wrapped within a
+root element named ` + "`doc`" + `.
+
+For example, the JSON structure above would be converted back into:
+
+` + "```xml" + `
+<root><description tone="boring">This is a description</description><elements id="1">foo1</elements><elements id="2">foo2</elements><elements>foo3</elements><title>This is a title</title></root>
 ` + "```" + ``,
 		Config: docs.FieldComponent().WithChildren(
-			docs.FieldString("operator", "An XML [operation](#operators) to apply to messages.").HasOptions("to_json").HasDefault(""),
+			docs.FieldString("operator", "An XML [operation](#operators) to apply to messages.").HasOptions("to_json", "from_json").HasDefault(""),
 			docs.FieldBool("cast", "Whether to try to cast values that are numbers and booleans to the right type. Default: all values are strings.").HasDefault(false),
 		),
 	})
@@ -102,22 +119,46 @@ With cast set to true, the resulting JSON structure would look like this:
 }
 
 type xmlProc struct {
-	log  log.Modular
-	cast bool
+	log      log.Modular
+	cast     bool
+	fromJSON bool
 }
 
 func newXML(conf processor.XMLConfig, mgr bundle.NewManagement) (*xmlProc, error) {
-	if conf.Operator != "to_json" {
-		return nil, fmt.Errorf("operator not recognised: %v", conf.Operator)
-	}
 	j := &xmlProc{
 		log:  mgr.Logger(),
 		cast: conf.Cast,
 	}
+	switch conf.Operator {
+	case "to_json":
+	case "from_json":
+		j.fromJSON = true
+	default:
+		return nil, fmt.Errorf("operator not recognised: %v", conf.Operator)
+	}
 	return j, nil
 }
 
 func (p *xmlProc) Process(ctx context.Context, msg *message.Part) ([]*message.Part, error) {
+	if p.fromJSON {
+		v, err := msg.AsStructured()
+		if err != nil {
+			p.log.Debugf("Failed to parse part as JSON: %v", err)
+			return nil, err
+		}
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected object, got %T", v)
+		}
+		xmlBytes, err := mxj.Map(obj).Xml()
+		if err != nil {
+			p.log.Debugf("Failed to format part as XML: %v", err)
+			return nil, err
+		}
+		msg.SetBytes(xmlBytes)
+		return []*message.Part{msg}, nil
+	}
+
 	root, err := ToMap(msg.AsBytes(), p.cast)
 	if err != nil {
 		p.log.Debugf("Failed to parse part as XML: %v", err)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
@@ -141,3 +142,87 @@ func TestXMLWithCast(t *testing.T) {
 	}
 	assert.NoError(t, msgsOut[0].Get(0).ErrorGet())
 }
+
+func TestXMLFromJSON(t *testing.T) {
+	conf := processor.NewConfig()
+	conf.Type = "xml"
+	conf.XML.Operator = "from_json"
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       string
+		output      string
+		errContains string
+	}{
+		{
+			name:   "basic",
+			input:  `{"root":{"next":"foo1"}}`,
+			output: `<root><next>foo1</next></root>`,
+		},
+		{
+			name:   "attributes and arrays",
+			input:  `{"root":{"elements":[{"#text":"foo1","-id":"1"},"foo2"],"title":"This is a title"}}`,
+			output: `<root><elements id="1">foo1</elements><elements>foo2</elements><title>This is a title</title></root>`,
+		},
+		{
+			name:        "not an object",
+			input:       `["foo","bar"]`,
+			errContains: "expected object",
+		},
+		{
+			name:        "not json",
+			input:       `<root>nope</root>`,
+			errContains: "invalid character",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			msgsOut, res := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{[]byte(test.input)}))
+			require.NoError(t, res)
+			require.Len(t, msgsOut, 1)
+
+			part := msgsOut[0].Get(0)
+			if test.errContains != "" {
+				require.Error(t, part.ErrorGet())
+				assert.Contains(t, part.ErrorGet().Error(), test.errContains)
+				return
+			}
+			require.NoError(t, part.ErrorGet())
+			assert.Equal(t, test.output, string(part.AsBytes()))
+		})
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	toConf := processor.NewConfig()
+	toConf.Type = "xml"
+	toConf.XML.Operator = "to_json"
+
+	fromConf := processor.NewConfig()
+	fromConf.Type = "xml"
+	fromConf.XML.Operator = "from_json"
+
+	toProc, err := mock.NewManager().NewProcessor(toConf)
+	require.NoError(t, err)
+
+	fromProc, err := mock.NewManager().NewProcessor(fromConf)
+	require.NoError(t, err)
+
+	input := `<root><description tone="boring">This is a description</description><elements id="1">foo1</elements><elements>foo2</elements></root>`
+
+	msgs, res := toProc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{[]byte(input)}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+
+	msgs, res = fromProc.ProcessBatch(context.Background(), msgs[0])
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	require.NoError(t, msgs[0].Get(0).ErrorGet())
+
+	assert.Equal(t, input, string(msgs[0].Get(0).AsBytes()))
+}
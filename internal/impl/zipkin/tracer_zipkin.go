@@ -0,0 +1,229 @@
+package zipkin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/benthosdev/benthos/v4/internal/cli"
+	"github.com/benthosdev/benthos/v4/internal/tracing"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	zFieldURL           = "url"
+	zFieldTags          = "tags"
+	zFieldFlushInterval = "flush_interval"
+)
+
+func zipkinSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.18.0").
+		Summary("Send tracing events to a [Zipkin](https://zipkin.io/) collector.").
+		Description("Spans are sent in batches to the v2 JSON API of the collector.").
+		Fields(
+			service.NewURLField(zFieldURL).
+				Description("The URL of the Zipkin span ingestion endpoint.").
+				Example("http://localhost:9411/api/v2/spans"),
+			service.NewStringMapField(zFieldTags).
+				Description("A map of tags to add to all tracing spans.").
+				Default(map[string]string{}).
+				Advanced(),
+			service.NewDurationField(zFieldFlushInterval).
+				Description("The maximum period of time between each flush of tracing spans.").
+				Default("5s").
+				Advanced(),
+		)
+}
+
+func init() {
+	err := service.RegisterOtelTracerProvider(
+		"zipkin", zipkinSpec(),
+		func(conf *service.ParsedConfig) (trace.TracerProvider, error) {
+			return newZipkinFromConfig(conf)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newZipkinFromConfig(conf *service.ParsedConfig) (*tracesdk.TracerProvider, error) {
+	url, err := conf.FieldString(zFieldURL)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := conf.FieldStringMap(zFieldTags)
+	if err != nil {
+		return nil, err
+	}
+	flushInterval, err := conf.FieldDuration(zFieldFlushInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	var attrs []attribute.KeyValue
+	for k, v := range tracing.ServiceTags(tags, cli.Version) {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	return tracesdk.NewTracerProvider(
+		tracesdk.WithBatcher(newZipkinExporter(url), tracesdk.WithBatchTimeout(flushInterval)),
+		tracesdk.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+	), nil
+}
+
+//------------------------------------------------------------------------------
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+type zipkinAnnotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+type zipkinSpan struct {
+	TraceID       string             `json:"traceId"`
+	ID            string             `json:"id"`
+	ParentID      string             `json:"parentId,omitempty"`
+	Name          string             `json:"name"`
+	Kind          string             `json:"kind,omitempty"`
+	Timestamp     int64              `json:"timestamp"`
+	Duration      int64              `json:"duration"`
+	LocalEndpoint *zipkinEndpoint    `json:"localEndpoint,omitempty"`
+	Annotations   []zipkinAnnotation `json:"annotations,omitempty"`
+	Tags          map[string]string  `json:"tags,omitempty"`
+}
+
+func zipkinKind(k trace.SpanKind) string {
+	switch k {
+	case trace.SpanKindServer:
+		return "SERVER"
+	case trace.SpanKindClient:
+		return "CLIENT"
+	case trace.SpanKindProducer:
+		return "PRODUCER"
+	case trace.SpanKindConsumer:
+		return "CONSUMER"
+	}
+	return ""
+}
+
+func toZipkinSpan(s tracesdk.ReadOnlySpan) zipkinSpan {
+	zs := zipkinSpan{
+		TraceID:   s.SpanContext().TraceID().String(),
+		ID:        s.SpanContext().SpanID().String(),
+		Name:      s.Name(),
+		Kind:      zipkinKind(s.SpanKind()),
+		Timestamp: s.StartTime().UnixNano() / int64(time.Microsecond),
+		Duration:  s.EndTime().Sub(s.StartTime()).Microseconds(),
+	}
+	if s.Parent().HasSpanID() {
+		zs.ParentID = s.Parent().SpanID().String()
+	}
+
+	tags := map[string]string{}
+	if res := s.Resource(); res != nil {
+		for _, kv := range res.Attributes() {
+			if kv.Key == semconv.ServiceNameKey {
+				zs.LocalEndpoint = &zipkinEndpoint{ServiceName: kv.Value.Emit()}
+				continue
+			}
+			tags[string(kv.Key)] = kv.Value.Emit()
+		}
+	}
+	for _, kv := range s.Attributes() {
+		tags[string(kv.Key)] = kv.Value.Emit()
+	}
+	if status := s.Status(); status.Code == codes.Error {
+		tags["error"] = status.Description
+		if status.Description == "" {
+			tags["error"] = "true"
+		}
+	}
+	if len(tags) > 0 {
+		zs.Tags = tags
+	}
+
+	for _, e := range s.Events() {
+		value := e.Name
+		if len(e.Attributes) > 0 {
+			attrStrs := make([]string, 0, len(e.Attributes))
+			for _, kv := range e.Attributes {
+				attrStrs = append(attrStrs, string(kv.Key)+"="+kv.Value.Emit())
+			}
+			value += ": " + strings.Join(attrStrs, ", ")
+		}
+		zs.Annotations = append(zs.Annotations, zipkinAnnotation{
+			Timestamp: e.Time.UnixNano() / int64(time.Microsecond),
+			Value:     value,
+		})
+	}
+	return zs
+}
+
+// zipkinExporter is a span exporter that sends spans to the v2 JSON API of a
+// Zipkin collector.
+type zipkinExporter struct {
+	url    string
+	client *http.Client
+}
+
+func newZipkinExporter(url string) *zipkinExporter {
+	return &zipkinExporter{
+		url:    url,
+		client: &http.Client{Timeout: time.Second * 30},
+	}
+}
+
+func (z *zipkinExporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	zSpans := make([]zipkinSpan, 0, len(spans))
+	for _, s := range spans {
+		zSpans = append(zSpans, toZipkinSpan(s))
+	}
+	body, err := json.Marshal(zSpans)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, z.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := z.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("zipkin collector returned unexpected status code: %v", res.StatusCode)
+	}
+	return nil
+}
+
+func (z *zipkinExporter) Shutdown(ctx context.Context) error {
+	z.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,72 @@
+package zipkin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestZipkinExport(t *testing.T) {
+	spansChan := make(chan []zipkinSpan, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var spans []zipkinSpan
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&spans))
+		spansChan <- spans
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	pConf, err := zipkinSpec().ParseYAML(`
+url: `+ts.URL+`/api/v2/spans
+tags:
+  service.name: foo
+  env: prod
+`, nil)
+	require.NoError(t, err)
+
+	prov, err := newZipkinFromConfig(pConf)
+	require.NoError(t, err)
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	pCtx, parent := prov.Tracer("benthos").Start(ctx, "input_foo")
+	_, child := prov.Tracer("benthos").Start(pCtx, "processor_bar")
+	child.AddEvent("error")
+	child.SetStatus(codes.Error, "nope")
+	child.End()
+	parent.End()
+
+	require.NoError(t, prov.Shutdown(ctx))
+
+	var spans []zipkinSpan
+	select {
+	case spans = <-spansChan:
+	default:
+		t.Fatal("expected spans to be exported")
+	}
+	require.Len(t, spans, 2)
+
+	childSpan, parentSpan := spans[0], spans[1]
+
+	assert.Equal(t, "input_foo", parentSpan.Name)
+	assert.Equal(t, "", parentSpan.ParentID)
+	assert.Equal(t, &zipkinEndpoint{ServiceName: "foo"}, parentSpan.LocalEndpoint)
+	assert.Equal(t, "prod", parentSpan.Tags["env"])
+
+	assert.Equal(t, "processor_bar", childSpan.Name)
+	assert.Equal(t, parentSpan.TraceID, childSpan.TraceID)
+	assert.Equal(t, parentSpan.ID, childSpan.ParentID)
+	assert.Equal(t, "nope", childSpan.Tags["error"])
+	require.Len(t, childSpan.Annotations, 1)
+	assert.Equal(t, "error", childSpan.Annotations[0].Value)
+}
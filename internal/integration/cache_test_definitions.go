@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/cache"
 )
 
 // CacheTestOpenClose checks that the cache can be started, an item added, and
@@ -122,3 +123,34 @@ func CacheTestGetAndSet(n int) CacheTestDefinition {
 		},
 	)
 }
+
+// CacheTestGetAndSetMulti checks that we can set n items in a single batch and
+// then get them.
+func CacheTestGetAndSetMulti(n int) CacheTestDefinition {
+	return namedCacheTest(
+		"can get and set multiple",
+		func(t *testing.T, env *cacheTestEnvironment) {
+			c := initCache(t, env)
+			t.Cleanup(func() {
+				closeCache(t, c)
+			})
+
+			items := map[string]cache.TTLItem{}
+			for i := 0; i < n; i++ {
+				key := fmt.Sprintf("key:%v", i)
+				value := fmt.Sprintf("value:%v", i)
+				items[key] = cache.TTLItem{Value: []byte(value)}
+			}
+			require.NoError(t, c.SetMulti(env.ctx, items))
+
+			for i := 0; i < n; i++ {
+				key := fmt.Sprintf("key:%v", i)
+				value := fmt.Sprintf("value:%v", i)
+
+				res, err := c.Get(env.ctx, key)
+				require.NoError(t, err)
+				assert.Equal(t, value, string(res))
+			}
+		},
+	)
+}
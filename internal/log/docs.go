@@ -19,14 +19,27 @@ func Spec() docs.FieldSpecs {
 		docs.FieldBool("add_timestamp", "Whether to include timestamps in logs.").HasDefault(false),
 		docs.FieldString("timestamp_name", "The name of the timestamp field added to logs when `add_timestamp` is set to `true` and the `format` is `json`.").HasDefault("time"),
 		docs.FieldString("message_name", "The name of the message field added to logs when the the `format` is `json`.").HasDefault("msg"),
+		docs.FieldString("level_name", "The name of the level field added to logs when the `format` is `json`.").HasDefault("level").Advanced().AtVersion("4.18.0"),
 		docs.FieldString("static_fields", "A map of key/value pairs to add to each structured log.").Map().HasDefault(map[string]string{
 			"@service": "benthos",
 		}),
+		docs.FieldString("level_overrides", "A map of component labels or paths to the minimum severity level of logs emitted by them, overriding the `level` field. Paths apply to the component at the path as well as all of its children, and where multiple paths match a component the longest is used.", map[string]any{
+			"root.input":   "DEBUG",
+			"noisy_output": "ERROR",
+		}).Map().HasDefault(map[string]string{}).Advanced().AtVersion("4.18.0"),
 		docs.FieldObject("file", "Experimental: Specify fields for optionally writing logs to a file.").WithChildren(
 			docs.FieldString("path", "The file path to write logs to, if the file does not exist it will be created. Leave this field empty or unset to disable file based logging.").HasDefault(""),
 			docs.FieldBool("rotate", "Whether to rotate log files automatically.").HasDefault(false),
 			docs.FieldInt("rotate_max_age_days", "The maximum number of days to retain old log files based on the timestamp encoded in their filename, after which they are deleted. Setting to zero disables this mechanism.").HasDefault(0),
+			docs.FieldInt("rotate_max_size_mb", "The size in megabytes a log file is allowed to reach before it is rotated. Setting to zero uses the default of 10.").HasDefault(10).Advanced().AtVersion("4.18.0"),
+			docs.FieldBool("tee", "Whether to continue writing logs to stdout (or stderr) in addition to the file.").HasDefault(false).Advanced().AtVersion("4.18.0"),
 		),
+		docs.FieldObject("syslog", "Experimental: Specify fields for optionally sending logs to a syslog daemon in addition to stdout (or stderr) and any file. Syslog is not supported on Windows.").WithChildren(
+			docs.FieldBool("enabled", "Whether to send logs to syslog.").HasDefault(false),
+			docs.FieldString("network", "The network of the syslog daemon to connect to, one of `udp` or `tcp`. Leave this field empty in order to connect to the local syslog daemon.").HasDefault(""),
+			docs.FieldString("address", "The address of the syslog daemon to connect to, which is ignored when connecting to the local syslog daemon.", "localhost:514").HasDefault(""),
+			docs.FieldString("tag", "The tag that logs are sent with.").HasDefault("benthos"),
+		).Advanced().AtVersion("4.18.0"),
 	}
 }
 
@@ -21,17 +21,34 @@ type Config struct {
 	AddTimeStamp  bool              `json:"add_timestamp" yaml:"add_timestamp"`
 	MessageName   string            `json:"message_name" yaml:"message_name"`
 	TimestampName string            `json:"timestamp_name" yaml:"timestamp_name"`
+	LevelName     string            `json:"level_name" yaml:"level_name"`
 	StaticFields  map[string]string `json:"static_fields" yaml:"static_fields"`
+	Overrides     map[string]string `json:"level_overrides" yaml:"level_overrides"`
 	File          File              `json:"file" yaml:"file"`
+	Syslog        Syslog            `json:"syslog" yaml:"syslog"`
 }
 
 // File contains configuration for file based logging.
 type File struct {
-	Path         string `json:"path" yaml:"path"`
-	Rotate       bool   `json:"rotate" yaml:"rotate"`
-	RotateMaxAge int    `json:"rotate_max_age_days" yaml:"rotate_max_age_days"`
+	Path          string `json:"path" yaml:"path"`
+	Rotate        bool   `json:"rotate" yaml:"rotate"`
+	RotateMaxAge  int    `json:"rotate_max_age_days" yaml:"rotate_max_age_days"`
+	RotateMaxSize int    `json:"rotate_max_size_mb" yaml:"rotate_max_size_mb"`
+	Tee           bool   `json:"tee" yaml:"tee"`
 }
 
+// Syslog contains configuration for sending logs to a syslog daemon.
+type Syslog struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+	Network string `json:"network" yaml:"network"`
+	Address string `json:"address" yaml:"address"`
+	Tag     string `json:"tag" yaml:"tag"`
+}
+
+// The size in megabytes at which log files are rotated when a size is not
+// specified.
+const defaultRotateMaxSize = 10
+
 // NewConfig returns a config struct with the default values for each field.
 func NewConfig() Config {
 	return Config{
@@ -40,9 +57,16 @@ func NewConfig() Config {
 		AddTimeStamp:  false,
 		TimestampName: "time",
 		MessageName:   "msg",
+		LevelName:     "level",
 		StaticFields: map[string]string{
 			"@service": "benthos",
 		},
+		File: File{
+			RotateMaxSize: defaultRotateMaxSize,
+		},
+		Syslog: Syslog{
+			Tag: "benthos",
+		},
 	}
 }
 
@@ -94,16 +118,115 @@ func (conf *Config) UnmarshalYAML(unmarshal func(any) error) error {
 // Logger is an object with support for levelled logging and modular components.
 type Logger struct {
 	entry *logrus.Entry
+
+	// The underlying logrus logger is set to the most verbose of all levels
+	// and each logger enforces its own level, which can vary depending on the
+	// label and path of the component it belongs to.
+	level     logrus.Level
+	overrides *levelOverrides
+	label     string
+	path      string
+}
+
+func parseLevel(level string) (logrus.Level, error) {
+	switch strings.ToUpper(level) {
+	case "OFF", "NONE":
+		return logrus.PanicLevel, nil
+	case "FATAL":
+		return logrus.FatalLevel, nil
+	case "ERROR":
+		return logrus.ErrorLevel, nil
+	case "WARN":
+		return logrus.WarnLevel, nil
+	case "INFO":
+		return logrus.InfoLevel, nil
+	case "DEBUG":
+		return logrus.DebugLevel, nil
+	case "TRACE", "ALL":
+		return logrus.TraceLevel, nil
+	}
+	return 0, fmt.Errorf("log level '%v' not recognized", level)
+}
+
+// levelOverrides resolves the level of a logger from the label and path of the
+// component it belongs to. Labels are matched exactly and take precedence over
+// paths, and paths are matched by the longest override that is either equal to
+// the path or a parent of it.
+type levelOverrides struct {
+	base   logrus.Level
+	levels map[string]logrus.Level
+}
+
+func newLevelOverrides(base logrus.Level, overrides map[string]string) (*levelOverrides, error) {
+	o := &levelOverrides{
+		base:   base,
+		levels: make(map[string]logrus.Level, len(overrides)),
+	}
+	for k, v := range overrides {
+		lvl, err := parseLevel(v)
+		if err != nil {
+			return nil, fmt.Errorf("level override '%v': %w", k, err)
+		}
+		o.levels[k] = lvl
+	}
+	return o, nil
+}
+
+func (o *levelOverrides) mostVerbose() logrus.Level {
+	lvl := o.base
+	for _, l := range o.levels {
+		if l > lvl {
+			lvl = l
+		}
+	}
+	return lvl
+}
+
+func (o *levelOverrides) resolve(label, path string) logrus.Level {
+	if len(o.levels) == 0 {
+		return o.base
+	}
+	if label != "" {
+		if lvl, exists := o.levels[label]; exists {
+			return lvl
+		}
+	}
+	lvl, matched := o.base, -1
+	for k, l := range o.levels {
+		if len(k) > matched && (path == k || strings.HasPrefix(path, k+".")) {
+			lvl, matched = l, len(k)
+		}
+	}
+	return lvl
+}
+
+func (l *Logger) withContext(entry *logrus.Entry, label, path string) *Logger {
+	newLogger := *l
+	newLogger.entry = entry
+	if label != l.label || path != l.path {
+		newLogger.label, newLogger.path = label, path
+		newLogger.level = l.overrides.resolve(label, path)
+	}
+	return &newLogger
+}
+
+func (l *Logger) enabled(level logrus.Level) bool {
+	return l.level >= level
 }
 
 // New returns a new logger from a config, or returns an error if the config
 // is invalid.
 func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 	if config.File.Path != "" {
+		var fileStream io.Writer
 		if config.File.Rotate {
-			stream = &lumberjack.Logger{
+			maxSize := config.File.RotateMaxSize
+			if maxSize <= 0 {
+				maxSize = defaultRotateMaxSize
+			}
+			fileStream = &lumberjack.Logger{
 				Filename:   config.File.Path,
-				MaxSize:    10,
+				MaxSize:    maxSize,
 				MaxAge:     config.File.RotateMaxAge,
 				MaxBackups: 1,
 				Compress:   true,
@@ -112,7 +235,7 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 			fw, err := ifs.OS().OpenFile(config.File.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o666)
 			if err == nil {
 				var isw bool
-				if stream, isw = fw.(io.Writer); !isw {
+				if fileStream, isw = fw.(io.Writer); !isw {
 					err = errors.New("failed to open a writeable file")
 				}
 			}
@@ -120,6 +243,11 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 				return nil, err
 			}
 		}
+		if config.File.Tee {
+			stream = io.MultiWriter(stream, fileStream)
+		} else {
+			stream = fileStream
+		}
 	}
 
 	logger := logrus.New()
@@ -130,8 +258,9 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 		logger.SetFormatter(&logrus.JSONFormatter{
 			DisableTimestamp: !config.AddTimeStamp,
 			FieldMap: logrus.FieldMap{
-				logrus.FieldKeyTime: config.TimestampName,
-				logrus.FieldKeyMsg:  config.MessageName,
+				logrus.FieldKeyTime:  config.TimestampName,
+				logrus.FieldKeyMsg:   config.MessageName,
+				logrus.FieldKeyLevel: config.LevelName,
 			},
 		})
 	case "logfmt":
@@ -148,22 +277,24 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 		return nil, fmt.Errorf("log format '%v' not recognized", config.Format)
 	}
 
-	switch strings.ToUpper(config.LogLevel) {
-	case "OFF", "NONE":
-		logger.Level = logrus.PanicLevel
-	case "FATAL":
-		logger.Level = logrus.FatalLevel
-	case "ERROR":
-		logger.Level = logrus.ErrorLevel
-	case "WARN":
-		logger.Level = logrus.WarnLevel
-	case "INFO":
-		logger.Level = logrus.InfoLevel
-	case "DEBUG":
-		logger.Level = logrus.DebugLevel
-	case "TRACE", "ALL":
-		logger.Level = logrus.TraceLevel
-		logger.Level = logrus.TraceLevel
+	// Unrecognised levels have always fallen back to INFO, and so in order to
+	// remain backwards compatible we only warn about them.
+	level, levelErr := parseLevel(config.LogLevel)
+	if levelErr != nil {
+		level = logrus.InfoLevel
+	}
+	overrides, err := newLevelOverrides(level, config.Overrides)
+	if err != nil {
+		return nil, err
+	}
+	logger.Level = overrides.mostVerbose()
+
+	if config.Syslog.Enabled {
+		hook, err := newSyslogHook(config.Syslog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		logger.AddHook(hook)
 	}
 
 	sFields := logrus.Fields{}
@@ -172,7 +303,11 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 	}
 	logEntry := logger.WithFields(sFields)
 
-	return &Logger{entry: logEntry}, nil
+	l := &Logger{entry: logEntry, level: level, overrides: overrides}
+	if levelErr != nil {
+		l.Warnf("%v, falling back to INFO. Unrecognised log levels are deprecated and will be rejected in a future version.\n", levelErr)
+	}
+	return l, nil
 }
 
 //------------------------------------------------------------------------------
@@ -181,25 +316,34 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 func Noop() Modular {
 	logger := logrus.New()
 	logger.Out = io.Discard
-	return &Logger{entry: logger.WithFields(logrus.Fields{})}
+	return &Logger{
+		entry:     logger.WithFields(logrus.Fields{}),
+		level:     logger.Level,
+		overrides: &levelOverrides{base: logger.Level},
+	}
 }
 
 // WithFields returns a logger with new fields added to the JSON formatted
 // output.
 func (l *Logger) WithFields(inboundFields map[string]string) Modular {
+	label, path := l.label, l.path
 	newFields := make(logrus.Fields, len(inboundFields))
 	for k, v := range inboundFields {
 		newFields[k] = v
+		switch k {
+		case "label":
+			label = v
+		case "path":
+			path = v
+		}
 	}
-
-	newLogger := *l
-	newLogger.entry = l.entry.WithFields(newFields)
-	return &newLogger
+	return l.withContext(l.entry.WithFields(newFields), label, path)
 }
 
 // With returns a copy of the logger with new labels added to the logging
 // context.
 func (l *Logger) With(keyValues ...any) Modular {
+	label, path := l.label, l.path
 	newEntry := l.entry.WithFields(logrus.Fields{})
 	for i := 0; i < (len(keyValues) - 1); i += 2 {
 		key, ok := keyValues[i].(string)
@@ -207,42 +351,65 @@ func (l *Logger) With(keyValues ...any) Modular {
 			continue
 		}
 		newEntry = newEntry.WithField(key, keyValues[i+1])
+		if v, ok := keyValues[i+1].(string); ok {
+			switch key {
+			case "label":
+				label = v
+			case "path":
+				path = v
+			}
+		}
 	}
-
-	newLogger := *l
-	newLogger.entry = newEntry
-	return &newLogger
+	return l.withContext(newEntry, label, path)
 }
 
 //------------------------------------------------------------------------------
 
 // Fatalf prints a fatal message to the console. Does NOT cause panic.
 func (l *Logger) Fatalf(format string, v ...any) {
+	if !l.enabled(logrus.FatalLevel) {
+		return
+	}
 	l.entry.Fatalf(strings.TrimSuffix(format, "\n"), v...)
 }
 
 // Errorf prints an error message to the console.
 func (l *Logger) Errorf(format string, v ...any) {
+	if !l.enabled(logrus.ErrorLevel) {
+		return
+	}
 	l.entry.Errorf(strings.TrimSuffix(format, "\n"), v...)
 }
 
 // Warnf prints a warning message to the console.
 func (l *Logger) Warnf(format string, v ...any) {
+	if !l.enabled(logrus.WarnLevel) {
+		return
+	}
 	l.entry.Warnf(strings.TrimSuffix(format, "\n"), v...)
 }
 
 // Infof prints an information message to the console.
 func (l *Logger) Infof(format string, v ...any) {
+	if !l.enabled(logrus.InfoLevel) {
+		return
+	}
 	l.entry.Infof(strings.TrimSuffix(format, "\n"), v...)
 }
 
 // Debugf prints a debug message to the console.
 func (l *Logger) Debugf(format string, v ...any) {
+	if !l.enabled(logrus.DebugLevel) {
+		return
+	}
 	l.entry.Debugf(strings.TrimSuffix(format, "\n"), v...)
 }
 
 // Tracef prints a trace message to the console.
 func (l *Logger) Tracef(format string, v ...any) {
+	if !l.enabled(logrus.TraceLevel) {
+		return
+	}
 	l.entry.Tracef(strings.TrimSuffix(format, "\n"), v...)
 }
 
@@ -250,30 +417,48 @@ func (l *Logger) Tracef(format string, v ...any) {
 
 // Fatalln prints a fatal message to the console. Does NOT cause panic.
 func (l *Logger) Fatalln(message string) {
+	if !l.enabled(logrus.FatalLevel) {
+		return
+	}
 	l.entry.Fatalln(message)
 }
 
 // Errorln prints an error message to the console.
 func (l *Logger) Errorln(message string) {
+	if !l.enabled(logrus.ErrorLevel) {
+		return
+	}
 	l.entry.Errorln(message)
 }
 
 // Warnln prints a warning message to the console.
 func (l *Logger) Warnln(message string) {
+	if !l.enabled(logrus.WarnLevel) {
+		return
+	}
 	l.entry.Warnln(message)
 }
 
 // Infoln prints an information message to the console.
 func (l *Logger) Infoln(message string) {
+	if !l.enabled(logrus.InfoLevel) {
+		return
+	}
 	l.entry.Infoln(message)
 }
 
 // Debugln prints a debug message to the console.
 func (l *Logger) Debugln(message string) {
+	if !l.enabled(logrus.DebugLevel) {
+		return
+	}
 	l.entry.Debugln(message)
 }
 
 // Traceln prints a trace message to the console.
 func (l *Logger) Traceln(message string) {
+	if !l.enabled(logrus.TraceLevel) {
+		return
+	}
 	l.entry.Traceln(message)
 }
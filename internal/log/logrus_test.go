@@ -2,6 +2,8 @@ package log
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -138,3 +140,117 @@ func TestLogLevels(t *testing.T) {
 		}
 	}
 }
+
+func TestLoggerJSONFieldNames(t *testing.T) {
+	loggerConfig := NewConfig()
+	loggerConfig.Format = "json"
+	loggerConfig.MessageName = "message"
+	loggerConfig.LevelName = "severity"
+	loggerConfig.StaticFields = map[string]string{
+		"@service": "benthos_service",
+		"env":      "prod",
+	}
+
+	var buf bytes.Buffer
+
+	logger, err := New(&buf, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+
+	logger.With("label", "foo").Warnln("Warning message")
+
+	assert.JSONEq(t, `{"@service":"benthos_service","env":"prod","label":"foo","message":"Warning message","severity":"warning"}`, buf.String())
+}
+
+func TestLoggerBadLevel(t *testing.T) {
+	loggerConfig := NewConfig()
+	loggerConfig.LogLevel = "VERBOSE"
+
+	var buf bytes.Buffer
+	logger, err := New(&buf, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+
+	logger.Infoln("Info message")
+	logger.Debugln("Debug message")
+
+	assert.Equal(t, `level=warning msg="log level 'VERBOSE' not recognized, falling back to INFO. Unrecognised log levels are deprecated and will be rejected in a future version." @service=benthos
+level=info msg="Info message" @service=benthos
+`, buf.String())
+}
+
+func TestLoggerRotateDefaultSize(t *testing.T) {
+	loggerConfig := NewConfig()
+	loggerConfig.File.Path = filepath.Join(t.TempDir(), "benthos.log")
+	loggerConfig.File.Rotate = true
+	loggerConfig.File.RotateMaxSize = 0
+
+	_, err := New(&bytes.Buffer{}, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+}
+
+func TestLoggerFileTee(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "benthos.log")
+
+	loggerConfig := NewConfig()
+	loggerConfig.StaticFields = map[string]string{}
+	loggerConfig.File.Path = logPath
+	loggerConfig.File.Tee = true
+
+	var buf bytes.Buffer
+
+	logger, err := New(&buf, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+
+	logger.Infoln("hello world")
+
+	expected := "level=info msg=\"hello world\"\n"
+	assert.Equal(t, expected, buf.String())
+
+	fileBytes, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(fileBytes))
+}
+
+func TestLoggerLevelOverrides(t *testing.T) {
+	loggerConfig := NewConfig()
+	loggerConfig.LogLevel = "INFO"
+	loggerConfig.StaticFields = map[string]string{}
+	loggerConfig.Overrides = map[string]string{
+		"root.input":                   "DEBUG",
+		"root.output":                  "ERROR",
+		"root.output.broker.outputs.1": "TRACE",
+		"noisy":                        "OFF",
+	}
+
+	var buf bytes.Buffer
+
+	logger, err := New(&buf, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+
+	logger.Debugln("root debug")
+	logger.Infoln("root info")
+
+	inputLogger := logger.WithFields(map[string]string{"path": "root.input"})
+	inputLogger.Debugln("input debug")
+	inputLogger.Traceln("input trace")
+
+	outputLogger := logger.WithFields(map[string]string{"path": "root.output.broker.outputs.0"})
+	outputLogger.Warnln("output warn")
+	outputLogger.Errorln("output error")
+
+	logger.With("path", "root.output.broker.outputs.1").Traceln("output trace")
+	logger.WithFields(map[string]string{"path": "root.outputs"}).Infoln("not an output")
+
+	logger.WithFields(map[string]string{"label": "noisy"}).With("path", "root.input").Errorln("noisy error")
+
+	expected := `level=info msg="root info"
+level=debug msg="input debug" path=root.input
+level=error msg="output error" path=root.output.broker.outputs.0
+level=trace msg="output trace" path=root.output.broker.outputs.1
+level=info msg="not an output" path=root.outputs
+`
+	assert.Equal(t, expected, buf.String())
+
+	loggerConfig.Overrides = map[string]string{"root.input": "LOUD"}
+	_, err = New(&buf, ifs.OS(), loggerConfig)
+	require.EqualError(t, err, "level override 'root.input': log level 'LOUD' not recognized")
+}
@@ -0,0 +1,14 @@
+//go:build !windows && !plan9
+
+package log
+
+import (
+	"log/syslog"
+
+	"github.com/sirupsen/logrus"
+	lsyslog "github.com/sirupsen/logrus/hooks/syslog"
+)
+
+func newSyslogHook(conf Syslog) (logrus.Hook, error) {
+	return lsyslog.NewSyslogHook(conf.Network, conf.Address, syslog.LOG_INFO|syslog.LOG_USER, conf.Tag)
+}
@@ -0,0 +1,14 @@
+//go:build windows || plan9
+
+package log
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+// newSyslogHook returns an error as syslog is not supported on this platform.
+func newSyslogHook(conf Syslog) (logrus.Hook, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,56 @@
+//go:build !windows && !plan9
+
+package log
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/filepath/ifs"
+)
+
+func TestLoggerMultipleTargets(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	logPath := filepath.Join(t.TempDir(), "benthos.log")
+
+	loggerConfig := NewConfig()
+	loggerConfig.StaticFields = map[string]string{}
+	loggerConfig.File.Path = logPath
+	loggerConfig.File.Tee = true
+	loggerConfig.Syslog.Enabled = true
+	loggerConfig.Syslog.Network = "udp"
+	loggerConfig.Syslog.Address = conn.LocalAddr().String()
+
+	var buf bytes.Buffer
+
+	logger, err := New(&buf, ifs.OS(), loggerConfig)
+	require.NoError(t, err)
+
+	logger.Warnln("hello world")
+
+	expected := "level=warning msg=\"hello world\"\n"
+	assert.Equal(t, expected, buf.String())
+
+	fileBytes, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(fileBytes))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second*5)))
+	packet := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(packet)
+	require.NoError(t, err)
+	assert.Contains(t, string(packet[:n]), "benthos")
+	assert.Contains(t, string(packet[:n]), expected)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/buffer"
 	"github.com/benthosdev/benthos/v4/internal/component/cache"
+	"github.com/benthosdev/benthos/v4/internal/component/connection"
 	"github.com/benthosdev/benthos/v4/internal/component/input"
 	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
@@ -85,6 +86,8 @@ type Type struct {
 	stats  *metrics.Namespaced
 	tracer trace.TracerProvider
 
+	connEvents *connection.Bus
+
 	pipes    map[string]<-chan message.Transaction
 	pipeLock *sync.RWMutex
 }
@@ -132,6 +135,14 @@ func OptSetTracer(tracer trace.TracerProvider) OptFunc {
 	}
 }
 
+// OptSetConnectionEvents sets the bus to which connection events published by
+// components of this manager are sent.
+func OptSetConnectionEvents(bus *connection.Bus) OptFunc {
+	return func(t *Type) {
+		t.connEvents = bus
+	}
+}
+
 // OptSetEnvironment determines the environment from which the manager
 // initializes components and resources. This option is for internal use only.
 func OptSetEnvironment(e *bundle.Environment) OptFunc {
@@ -431,6 +442,18 @@ func (t *Type) Tracer() trace.TracerProvider {
 	return t.tracer
 }
 
+// PublishConnectionEvent publishes a connection event from a component of
+// this manager, adding the label, path and stream of the component.
+func (t *Type) PublishConnectionEvent(ev connection.Event) {
+	if t.connEvents == nil {
+		return
+	}
+	ev.Label = t.label
+	ev.Path = "root." + query.SliceToDotPath(t.componentPath...)
+	ev.Stream = t.stream
+	t.connEvents.Publish(ev)
+}
+
 // Environment returns a bundle environment used by the manager. This is for
 // internal use only.
 func (t *Type) Environment() *bundle.Environment {
@@ -899,6 +922,9 @@ func (t *Type) RemoveRateLimit(ctx context.Context, name string) error {
 // itself has finished shutting down and when it is the sole owner of the
 // observability components.
 func (t *Type) CloseObservability(ctx context.Context) error {
+	if t.connEvents != nil {
+		t.connEvents.Close()
+	}
 	if t.tracer != nil {
 		if shutter, ok := t.tracer.(interface {
 			Shutdown(context.Context) error
@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+)
+
+// newAWSSecretsManagerLookup creates a lookup from a URN of the form
+// `aws_secrets_manager://?region=foo&prefix=bar/`, where the name of a secret
+// is the key with the prefix added, and a key of the form `foo.bar` extracts
+// the field `bar` from the secret `foo` as a JSON object.
+func newAWSSecretsManagerLookup(u *url.URL) (LookupFn, error) {
+	query := u.Query()
+
+	awsConf := aws.NewConfig()
+	if region := query.Get("region"); region != "" {
+		awsConf = awsConf.WithRegion(region)
+	}
+	if endpoint := query.Get("endpoint"); endpoint != "" {
+		awsConf = awsConf.WithEndpoint(endpoint)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConf,
+		Profile:           query.Get("profile"),
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return awsSecretsManagerLookup(secretsmanager.New(sess), query.Get("prefix")), nil
+}
+
+func awsSecretsManagerLookup(client secretsmanageriface.SecretsManagerAPI, prefix string) LookupFn {
+	return func(ctx context.Context, key string) (string, bool, error) {
+		name, field := splitKey(key)
+
+		out, err := client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(prefix + name),
+		})
+		if err != nil {
+			var aErr awserr.Error
+			if errors.As(err, &aErr) && aErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+				return "", false, nil
+			}
+			return "", false, err
+		}
+
+		var secret []byte
+		if out.SecretString != nil {
+			secret = []byte(*out.SecretString)
+		} else {
+			secret = out.SecretBinary
+		}
+		return extractField(secret, field)
+	}
+}
@@ -0,0 +1,145 @@
+// Package secrets provides lookup functions for resolving variable references
+// within configs, such as `${DB_PASSWORD}`, from external secret stores as an
+// alternative to environment variables.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// LookupFn attempts to obtain the value of a variable by its key, and returns
+// false when the variable could not be found. An error is returned when the
+// lookup itself failed, in which case it is unknown whether the variable
+// exists.
+type LookupFn func(ctx context.Context, key string) (string, bool, error)
+
+// DefaultLookupTimeout is the maximum time given to a lookup against a remote
+// secret store before it is abandoned.
+const DefaultLookupTimeout = time.Second * 10
+
+// ParseLookupURNs attempts to parse a series of secrets lookup URNs into a
+// single lookup function, where each lookup is attempted in order until one
+// yields a value. When no URNs are provided the lookup falls back to
+// environment variables.
+//
+// A lookup error from a store is returned immediately rather than falling
+// through to the next store, as the secret may well exist.
+func ParseLookupURNs(urns ...string) (LookupFn, error) {
+	if len(urns) == 0 {
+		return envLookup, nil
+	}
+
+	var fns []LookupFn
+	for _, urn := range urns {
+		fn, err := parseLookupURN(urn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse secrets lookup '%v': %w", urn, err)
+		}
+		fns = append(fns, fn)
+	}
+
+	if len(fns) == 1 {
+		return fns[0], nil
+	}
+	return func(ctx context.Context, key string) (string, bool, error) {
+		for _, fn := range fns {
+			if v, ok, err := fn(ctx, key); err != nil || ok {
+				return v, ok, err
+			}
+		}
+		return "", false, nil
+	}, nil
+}
+
+func parseLookupURN(urn string) (LookupFn, error) {
+	u, err := url.Parse(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	var fn LookupFn
+	switch u.Scheme {
+	case "none":
+		return func(ctx context.Context, key string) (string, bool, error) {
+			return "", false, nil
+		}, nil
+	case "env":
+		return envLookup, nil
+	case "aws_secrets_manager":
+		if fn, err = newAWSSecretsManagerLookup(u); err != nil {
+			return nil, err
+		}
+	case "vault":
+		if fn, err = newVaultLookup(u); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("secrets scheme %v not recognised", u.Scheme)
+	}
+
+	return func(ctx context.Context, key string) (string, bool, error) {
+		ctx, done := context.WithTimeout(ctx, DefaultLookupTimeout)
+		defer done()
+
+		v, ok, err := fn(ctx, key)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to look up secret '%v' from %v: %w", key, u.Scheme, err)
+		}
+		return v, ok, nil
+	}, nil
+}
+
+func envLookup(ctx context.Context, key string) (string, bool, error) {
+	v, ok := os.LookupEnv(key)
+	return v, ok, nil
+}
+
+//------------------------------------------------------------------------------
+
+// splitKey separates a variable key of the form `foo.bar` into the name of a
+// secret `foo` and the field `bar` to extract from it.
+func splitKey(key string) (name, field string) {
+	if i := strings.Index(key, "."); i != -1 {
+		return key[:i], key[i+1:]
+	}
+	return key, ""
+}
+
+// extractField obtains a field from a secret that is expected to be a JSON
+// object, and when no field is specified returns the secret as is.
+func extractField(secret []byte, field string) (string, bool, error) {
+	if field == "" {
+		return string(secret), true, nil
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(secret, &obj); err != nil {
+		return "", false, fmt.Errorf("failed to parse secret as a JSON object: %w", err)
+	}
+	return fieldFromObj(obj, field)
+}
+
+func fieldFromObj(obj map[string]any, field string) (string, bool, error) {
+	v, exists := obj[field]
+	if !exists {
+		return "", false, nil
+	}
+	switch t := v.(type) {
+	case string:
+		return t, true, nil
+	case nil:
+		return "", false, nil
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", false, err
+		}
+		return string(b), true, nil
+	}
+}
@@ -0,0 +1,177 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLookupURNs(t *testing.T) {
+	os.Setenv("BENTHOS_SECRETS_TEST_FOO", "foo value")
+	defer os.Unsetenv("BENTHOS_SECRETS_TEST_FOO")
+
+	ctx := context.Background()
+
+	fn, err := ParseLookupURNs()
+	require.NoError(t, err)
+
+	v, ok, err := fn(ctx, "BENTHOS_SECRETS_TEST_FOO")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "foo value", v)
+
+	fn, err = ParseLookupURNs("none:")
+	require.NoError(t, err)
+
+	_, ok, err = fn(ctx, "BENTHOS_SECRETS_TEST_FOO")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	fn, err = ParseLookupURNs("none:", "env:")
+	require.NoError(t, err)
+
+	v, ok, err = fn(ctx, "BENTHOS_SECRETS_TEST_FOO")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "foo value", v)
+
+	_, err = ParseLookupURNs("meow://foo")
+	require.Error(t, err)
+
+	_, err = ParseLookupURNs("vault:///secret")
+	require.Error(t, err)
+
+	_, err = ParseLookupURNs("vault://localhost:8200")
+	require.Error(t, err)
+}
+
+type mockSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+	secrets map[string]string
+}
+
+func (m *mockSecretsManager) GetSecretValueWithContext(ctx aws.Context, input *secretsmanager.GetSecretValueInput, opts ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	if *input.SecretId == "prod/broken" {
+		return nil, errors.New("nope")
+	}
+	v, exists := m.secrets[*input.SecretId]
+	if !exists {
+		return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+	}
+	return &secretsmanager.GetSecretValueOutput{
+		SecretString: aws.String(v),
+	}, nil
+}
+
+func TestAWSSecretsManagerLookup(t *testing.T) {
+	fn := awsSecretsManagerLookup(&mockSecretsManager{
+		secrets: map[string]string{
+			"prod/foo": "foo value",
+			"prod/db":  `{"user":"bar","password":"baz","port":5432}`,
+		},
+	}, "prod/")
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		key   string
+		value string
+		found bool
+		err   bool
+	}{
+		{key: "foo", value: "foo value", found: true},
+		{key: "db.password", value: "baz", found: true},
+		{key: "db.port", value: "5432", found: true},
+		{key: "db.nope", found: false},
+		{key: "bar", found: false},
+		{key: "foo.bar", err: true},
+		{key: "broken", err: true},
+	} {
+		v, ok, err := fn(ctx, test.key)
+		if test.err {
+			assert.Error(t, err, test.key)
+			continue
+		}
+		require.NoError(t, err, test.key)
+		assert.Equal(t, test.found, ok, test.key)
+		assert.Equal(t, test.value, v, test.key)
+	}
+}
+
+func TestVaultLookup(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "meow" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/benthos/db":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"baz","value":"qux"},"metadata":{}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	conf := vaultLookupConfig{
+		address: ts.URL,
+		mount:   "secret",
+		prefix:  "benthos",
+		token:   "meow",
+	}
+	fn := vaultLookup(ts.Client(), conf)
+
+	v, ok, err := fn(ctx, "db.password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "baz", v)
+
+	v, ok, err = fn(ctx, "db")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "qux", v)
+
+	_, ok, err = fn(ctx, "db.nope")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = fn(ctx, "nope")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	conf.token = "woof"
+	_, _, err = vaultLookup(ts.Client(), conf)(ctx, "db")
+	require.Error(t, err)
+}
+
+func TestParseLookupURNsErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	os.Setenv("BENTHOS_SECRETS_TEST_FOO", "foo value")
+	defer os.Unsetenv("BENTHOS_SECRETS_TEST_FOO")
+
+	fn, err := ParseLookupURNs("vault://"+strings.TrimPrefix(ts.URL, "http://")+"/secret?tls=false", "env:")
+	require.NoError(t, err)
+
+	// A failed lookup must not be mistaken for the secret being missing and
+	// therefore fall through to the next store.
+	_, ok, err := fn(context.Background(), "BENTHOS_SECRETS_TEST_FOO")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to look up secret 'BENTHOS_SECRETS_TEST_FOO' from vault")
+	assert.False(t, ok)
+}
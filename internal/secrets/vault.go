@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// newVaultLookup creates a lookup from a URN of the form
+// `vault://host:8200/mount/prefix`, which reads secrets from a KV version 2
+// secrets engine at the mount `mount` and the path `prefix/<name>`, where a key
+// of the form `foo.bar` extracts the field `bar` from the secret `foo`. When no
+// field is specified the field `value` is read.
+//
+// The token used for authentication is read from the environment variable
+// `VAULT_TOKEN`. The query parameter `tls=false` switches requests to plain
+// HTTP and `namespace` sets the Vault namespace.
+func newVaultLookup(u *url.URL) (LookupFn, error) {
+	if u.Host == "" {
+		return nil, errors.New("a vault host must be specified")
+	}
+
+	mount, prefix, _ := strings.Cut(strings.Trim(u.Path, "/"), "/")
+	if mount == "" {
+		return nil, errors.New("a vault secrets engine mount must be specified in the path")
+	}
+
+	query := u.Query()
+	scheme := "https"
+	if query.Get("tls") == "false" {
+		scheme = "http"
+	}
+
+	return vaultLookup(http.DefaultClient, vaultLookupConfig{
+		address:   scheme + "://" + u.Host,
+		mount:     mount,
+		prefix:    prefix,
+		namespace: query.Get("namespace"),
+		token:     os.Getenv("VAULT_TOKEN"),
+	}), nil
+}
+
+type vaultLookupConfig struct {
+	address   string
+	mount     string
+	prefix    string
+	namespace string
+	token     string
+}
+
+func vaultLookup(client *http.Client, conf vaultLookupConfig) LookupFn {
+	return func(ctx context.Context, key string) (string, bool, error) {
+		name, field := splitKey(key)
+		if field == "" {
+			field = "value"
+		}
+
+		secretPath := name
+		if conf.prefix != "" {
+			secretPath = conf.prefix + "/" + name
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%v/v1/%v/data/%v", conf.address, conf.mount, secretPath), http.NoBody)
+		if err != nil {
+			return "", false, err
+		}
+		if conf.token != "" {
+			req.Header.Set("X-Vault-Token", conf.token)
+		}
+		if conf.namespace != "" {
+			req.Header.Set("X-Vault-Namespace", conf.namespace)
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			return "", false, err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode == http.StatusNotFound {
+			return "", false, nil
+		}
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return "", false, err
+		}
+		if res.StatusCode != http.StatusOK {
+			return "", false, fmt.Errorf("unexpected status code %v: %s", res.StatusCode, body)
+		}
+
+		var secret struct {
+			Data struct {
+				Data map[string]any `json:"data"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &secret); err != nil {
+			return "", false, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return fieldFromObj(secret.Data.Data, field)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
+	"github.com/benthosdev/benthos/v4/internal/component/connection"
 	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	ioutput "github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
@@ -130,8 +131,19 @@ func NewHandler(conf config.Type) (*Handler, error) {
 		trac = trace.NewNoopTracerProvider()
 	}
 
+	connEvents := connection.NewBus()
+	if err = connection.Subscribe(conf.ConnectionEvents, logger, connEvents); err != nil {
+		return nil, fmt.Errorf("failed to initialise connection events: %w", err)
+	}
+
 	// Create resource manager.
-	manager, err := manager.New(conf.ResourceConfig, manager.OptSetLogger(logger), manager.OptSetMetrics(stats), manager.OptSetTracer(trac))
+	manager, err := manager.New(
+		conf.ResourceConfig,
+		manager.OptSetLogger(logger),
+		manager.OptSetMetrics(stats),
+		manager.OptSetTracer(trac),
+		manager.OptSetConnectionEvents(connEvents),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource: %v", err)
 	}
@@ -182,6 +194,7 @@ func NewHandler(conf config.Type) (*Handler, error) {
 			}
 
 			defer func() {
+				connEvents.Close()
 				if shutter, ok := trac.(interface {
 					Shutdown(context.Context) error
 				}); ok {
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 
@@ -34,6 +35,11 @@ func (m *Type) registerEndpoints(enableCrud bool) {
 		"Returns 200 OK if the inputs and outputs of all running streams are connected, otherwise a 503 is returned. If there are no active streams 200 is returned.",
 		m.HandleStreamReady,
 	)
+	m.manager.RegisterEndpoint(
+		"/connectivity",
+		"Returns a JSON object describing whether the inputs and outputs of all running streams are connected, naming those that are not. The status code is 200 if all are connected, otherwise a 503 is returned.",
+		m.HandleStreamConnectivity,
+	)
 	if !enableCrud {
 		return
 	}
@@ -158,7 +164,17 @@ func (m *Type) HandleStreamsCRUD(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if r.URL.Query().Get("chilled") != "true" {
+	ignoreLints := r.URL.Query().Get("chilled") == "true"
+
+	if setBytes, requestErr = config.ReplaceEnvVariables(setBytes, os.LookupEnv); requestErr != nil {
+		var errEnvMissing *config.ErrMissingEnvVars
+		if !ignoreLints || !errors.As(requestErr, &errEnvMissing) {
+			return
+		}
+		setBytes, requestErr = errEnvMissing.BestAttempt, nil
+	}
+
+	if !ignoreLints {
 		nodeSet := map[string]yaml.Node{}
 		if requestErr = yaml.Unmarshal(setBytes, &nodeSet); requestErr != nil {
 			return
@@ -649,3 +665,38 @@ func (m *Type) HandleStreamReady(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusServiceUnavailable)
 	fmt.Fprintf(w, "streams %v are not connected\n", strings.Join(notReady, ", "))
 }
+
+// HandleStreamConnectivity is an http.HandleFunc for providing a JSON report of
+// the connectivity of all running streams.
+func (m *Type) HandleStreamConnectivity(w http.ResponseWriter, r *http.Request) {
+	report := struct {
+		Ready     bool                                 `json:"ready"`
+		Streams   map[string]stream.ConnectivityReport `json:"streams"`
+		Unhealthy []string                             `json:"unhealthy"`
+	}{
+		Streams:   map[string]stream.ConnectivityReport{},
+		Unhealthy: []string{},
+	}
+
+	m.lock.Lock()
+	for k, v := range m.streams {
+		if !v.IsRunning() {
+			continue
+		}
+		sReport := v.Connectivity()
+		for _, layer := range sReport.Unhealthy {
+			report.Unhealthy = append(report.Unhealthy, k+"."+layer)
+		}
+		report.Streams[k] = sReport
+	}
+	m.lock.Unlock()
+
+	sort.Strings(report.Unhealthy)
+	report.Ready = len(report.Unhealthy) == 0
+
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -33,6 +33,7 @@ import (
 func router(m *manager.Type) *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc("/ready", m.HandleStreamReady)
+	router.HandleFunc("/connectivity", m.HandleStreamConnectivity)
 	router.HandleFunc("/streams", m.HandleStreamsCRUD)
 	router.HandleFunc("/streams/{id}", m.HandleStreamCRUD)
 	router.HandleFunc("/streams/{id}/stats", m.HandleStreamStats)
@@ -143,8 +144,9 @@ func TestTypeAPIDisabled(t *testing.T) {
 	_ = manager.New(rMgr,
 		manager.OptAPIEnabled(false),
 	)
-	assert.Len(t, r.endpoints, 1)
+	assert.Len(t, r.endpoints, 2)
 	assert.Contains(t, r.endpoints, "/ready")
+	assert.Contains(t, r.endpoints, "/connectivity")
 }
 
 func TestTypeAPIBadMethods(t *testing.T) {
@@ -536,6 +538,54 @@ func TestTypeAPISetStreams(t *testing.T) {
 	assert.Equal(t, "root = this.BAZ_ONE", gabs.Wrap(conf.Config).S("input", "generate", "mapping").Data())
 }
 
+func TestTypeAPISetStreamsEnvVars(t *testing.T) {
+	res, err := bmanager.New(bmanager.NewResourceConfig())
+	require.NoError(t, err)
+
+	mgr := manager.New(res)
+
+	r := router(mgr)
+
+	testVar := "__TEST_STREAMS_INPUT_MAPPING"
+	originalEnv, orignalSet := os.LookupEnv(testVar)
+	defer func() {
+		_ = os.Unsetenv(testVar)
+		if orignalSet {
+			_ = os.Setenv(testVar, originalEnv)
+		}
+	}()
+	_ = os.Setenv(testVar, `root = this.FROM_ENV`)
+
+	fooConf := harmlessConf()
+	_, _ = gabs.Wrap(fooConf).Set("${__TEST_STREAMS_INPUT_MAPPING}", "input", "generate", "mapping")
+
+	request := genRequest("POST", "/streams", map[string]any{
+		"foo": fooConf,
+	})
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	request = genRequest("GET", "/streams/foo", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	conf := parseGetBody(t, response.Body)
+	assert.Equal(t, "root = this.FROM_ENV", gabs.Wrap(conf.Config).S("input", "generate", "mapping").Data())
+
+	barConf := harmlessConf()
+	_, _ = gabs.Wrap(barConf).Set("${__TEST_STREAMS_DEFINITELY_MISSING}", "input", "generate", "mapping")
+
+	request = genRequest("POST", "/streams", map[string]any{
+		"bar": barConf,
+	})
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusBadRequest, response.Code, response.Body.String())
+	assert.Contains(t, response.Body.String(), "__TEST_STREAMS_DEFINITELY_MISSING")
+}
+
 func TestTypeAPIStreamsDefaultConf(t *testing.T) {
 	res, err := bmanager.New(bmanager.NewResourceConfig())
 	require.NoError(t, err)
@@ -979,4 +1029,17 @@ output:
 		r.ServeHTTP(response, request)
 		return response.Code == http.StatusServiceUnavailable
 	}, time.Second*10, time.Millisecond*50)
+
+	request = genRequest("GET", "/connectivity", nil)
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+  "ready": false,
+  "streams": {
+    "bar": {"ready": false, "connected": {"input": true, "output": false}, "unhealthy": ["output"]}
+  },
+  "unhealthy": ["bar.output"]
+}`, response.Body.String())
 }
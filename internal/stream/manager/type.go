@@ -49,6 +49,12 @@ func (s *StreamStatus) IsReady() bool {
 	return s.strm.IsReady()
 }
 
+// Connectivity returns a report of whether the input and output of the stream
+// are connected.
+func (s *StreamStatus) Connectivity() stream.ConnectivityReport {
+	return s.strm.Connectivity()
+}
+
 // Uptime returns a time.Duration indicating the current uptime of the stream.
 func (s *StreamStatus) Uptime() time.Duration {
 	if stoppedAfter := atomic.LoadInt64(&s.stoppedAfter); stoppedAfter > 0 {
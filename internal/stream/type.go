@@ -3,6 +3,7 @@ package stream
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"runtime/pprof"
@@ -29,17 +30,19 @@ type Type struct {
 
 	manager bundle.NewManagement
 
-	onClose func()
-	closed  uint32
+	onClose      func()
+	onUngraceful func()
+	closed       uint32
 }
 
 // New creates a new stream.Type.
 func New(conf Config, mgr bundle.NewManagement, opts ...func(*Type)) (*Type, error) {
 	t := &Type{
-		conf:    conf,
-		manager: mgr,
-		onClose: func() {},
-		closed:  0,
+		conf:         conf,
+		manager:      mgr,
+		onClose:      func() {},
+		onUngraceful: func() {},
+		closed:       0,
 	}
 	for _, opt := range opts {
 		opt(t)
@@ -75,11 +78,60 @@ func New(conf Config, mgr bundle.NewManagement, opts ...func(*Type)) (*Type, err
 		"Returns 200 OK if all inputs and outputs are connected, otherwise a 503 is returned.",
 		healthCheck,
 	)
+
+	connectivityCheck := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadUint32(&t.closed) == 1 {
+			http.Error(w, "Stream terminated", http.StatusNotFound)
+			return
+		}
+
+		report := t.Connectivity()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}
+	t.manager.RegisterEndpoint(
+		"/connectivity",
+		"Returns a JSON object describing whether the input and output are connected, naming those that are not. The status code is 200 if all are connected, otherwise a 503 is returned.",
+		connectivityCheck,
+	)
 	return t, nil
 }
 
 //------------------------------------------------------------------------------
 
+// ConnectivityReport describes whether the input and output layers of a stream
+// are connected.
+type ConnectivityReport struct {
+	Ready     bool            `json:"ready"`
+	Connected map[string]bool `json:"connected"`
+	Unhealthy []string        `json:"unhealthy"`
+}
+
+// Connectivity returns a report of whether the input and output layers of the
+// stream are connected.
+func (t *Type) Connectivity() ConnectivityReport {
+	report := ConnectivityReport{
+		Connected: map[string]bool{
+			"input":  t.inputLayer.Connected(),
+			"output": t.outputLayer.Connected(),
+		},
+		Unhealthy: []string{},
+	}
+	for _, layer := range []string{"input", "output"} {
+		if !report.Connected[layer] {
+			report.Unhealthy = append(report.Unhealthy, layer)
+		}
+	}
+	report.Ready = len(report.Unhealthy) == 0
+	return report
+}
+
+//------------------------------------------------------------------------------
+
 // OptOnClose sets a closure to be called when the stream closes.
 func OptOnClose(onClose func()) func(*Type) {
 	return func(t *Type) {
@@ -87,6 +139,15 @@ func OptOnClose(onClose func()) func(*Type) {
 	}
 }
 
+// OptOnUngracefulStop sets a closure to be called when an attempt to stop the
+// stream gracefully has failed or reached its deadline, immediately before the
+// stream is stopped ungracefully.
+func OptOnUngracefulStop(fn func()) func(*Type) {
+	return func(t *Type) {
+		t.onUngraceful = fn
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // IsReady returns a boolean indicating whether both the input and output layers
@@ -256,6 +317,7 @@ func (t *Type) Stop(ctx context.Context) error {
 	// If graceful termination failed then call unordered termination, if the
 	// overall ctx is already cancelled this will still trigger asynchronous
 	// clean up of resources, which is a best attempt.
+	t.onUngraceful()
 	if err = t.StopUnordered(ctx); err == nil {
 		return nil
 	}
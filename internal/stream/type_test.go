@@ -144,10 +144,11 @@ func TestTypeCloseUnordered(t *testing.T) {
 
 type mockAPIReg struct {
 	server *httptest.Server
+	mux    *http.ServeMux
 }
 
 func (ar mockAPIReg) RegisterEndpoint(path, desc string, h http.HandlerFunc) {
-	ar.server.Config.Handler = h
+	ar.mux.HandleFunc(path, h)
 }
 
 func (ar mockAPIReg) Close() {
@@ -155,8 +156,10 @@ func (ar mockAPIReg) Close() {
 }
 
 func newMockAPIReg() mockAPIReg {
+	mux := http.NewServeMux()
 	return mockAPIReg{
-		server: httptest.NewServer(nil),
+		server: httptest.NewServer(mux),
+		mux:    mux,
 	}
 }
 
@@ -199,6 +202,14 @@ func TestHealthCheck(t *testing.T) {
 
 	validateHealthCheckResponse(t, mockAPIReg.server.URL, "OK")
 
+	res, err := http.Get(mockAPIReg.server.URL + "/connectivity")
+	require.NoError(t, err)
+	data, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.JSONEq(t, `{"ready":true,"connected":{"input":true,"output":true},"unhealthy":[]}`, string(data))
+
 	stopCtx, stopDone := context.WithTimeout(context.Background(), time.Minute)
 	defer stopDone()
 
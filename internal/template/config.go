@@ -58,7 +58,12 @@ func (c FieldConfig) FieldSpec() (docs.FieldSpec, error) {
 	if c.Type == nil {
 		return f, errors.New("missing type field")
 	}
-	f = f.HasType(docs.FieldType(*c.Type))
+	switch t := docs.FieldType(*c.Type); t {
+	case docs.FieldTypeString, docs.FieldTypeInt, docs.FieldTypeFloat, docs.FieldTypeBool, docs.FieldTypeUnknown:
+		f = f.HasType(t)
+	default:
+		return f, fmt.Errorf("unrecognised field type: %v", *c.Type)
+	}
 	if c.Kind != nil {
 		switch *c.Kind {
 		case "map":
@@ -67,7 +72,7 @@ func (c FieldConfig) FieldSpec() (docs.FieldSpec, error) {
 			f = f.Array()
 		case "scalar":
 		default:
-			return f, fmt.Errorf("unrecognised scalar type: %v", *c.Kind)
+			return f, fmt.Errorf("unrecognised field kind: %v", *c.Kind)
 		}
 	}
 	return f, nil
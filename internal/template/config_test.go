@@ -0,0 +1,138 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTemplateExpand(t *testing.T) {
+	conf, lints, err := ReadConfigYAML([]byte(`
+name: standard_sink
+type: output
+fields:
+  - name: topic
+    type: string
+  - name: partitions
+    type: int
+    default: 4
+  - name: tags
+    type: string
+    kind: map
+    default: {}
+mapping: |
+  root.kafka.topic = this.topic
+  root.kafka.max_in_flight = this.partitions
+  root.kafka.metadata.include_patterns = this.tags.keys().sort()
+`))
+	require.NoError(t, err)
+	assert.Empty(t, lints)
+
+	tmpl, err := conf.compile()
+	require.NoError(t, err)
+
+	expand := func(in string) (any, error) {
+		var node yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(in), &node))
+
+		outNode, err := tmpl.ExpandToNode(&node)
+		if err != nil {
+			return nil, err
+		}
+
+		var out any
+		require.NoError(t, outNode.Decode(&out))
+		return out, nil
+	}
+
+	out, err := expand(`
+topic: foo
+tags:
+  b: x
+  a: y
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"kafka": map[string]any{
+			"topic":         "foo",
+			"max_in_flight": 4,
+			"metadata": map[string]any{
+				"include_patterns": []any{"a", "b"},
+			},
+		},
+	}, out)
+
+	out, err = expand(`
+topic: bar
+partitions: 10
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"kafka": map[string]any{
+			"topic":         "bar",
+			"max_in_flight": 10,
+			"metadata": map[string]any{
+				"include_patterns": []any{},
+			},
+		},
+	}, out)
+
+	_, err = expand(`partitions: 10`)
+	require.Error(t, err)
+
+	_, err = expand(`
+topic: foo
+partitions: nope
+`)
+	require.Error(t, err)
+}
+
+func TestTemplateFieldSpecErrors(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		fields      string
+		errContains string
+	}{
+		{
+			name: "missing type",
+			fields: `
+  - name: foo
+`,
+			errContains: "missing type field",
+		},
+		{
+			name: "unrecognised type",
+			fields: `
+  - name: foo
+    type: integer
+`,
+			errContains: "unrecognised field type: integer",
+		},
+		{
+			name: "unrecognised kind",
+			fields: `
+  - name: foo
+    type: int
+    kind: set
+`,
+			errContains: "unrecognised field kind: set",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			conf, _, err := ReadConfigYAML([]byte(`
+name: foo
+type: processor
+fields:` + test.fields + `
+mapping: 'root.noop = {}'
+`))
+			require.NoError(t, err)
+
+			_, err = conf.compile()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.errContains)
+		})
+	}
+}
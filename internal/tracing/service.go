@@ -0,0 +1,24 @@
+package tracing
+
+import (
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// ServiceTags returns a copy of the tags configured for a tracer or metrics
+// exporter along with default service name and version tags.
+func ServiceTags(tags map[string]string, version string) map[string]string {
+	res := make(map[string]string, len(tags)+2)
+	for k, v := range tags {
+		res[k] = v
+	}
+	if _, ok := tags[string(semconv.ServiceNameKey)]; !ok {
+		res[string(semconv.ServiceNameKey)] = "benthos"
+
+		// Only set the default service version tag if the user doesn't provide
+		// a custom service name tag.
+		if _, ok := tags[string(semconv.ServiceVersionKey)]; !ok {
+			res[string(semconv.ServiceVersionKey)] = version
+		}
+	}
+	return res
+}
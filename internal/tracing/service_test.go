@@ -0,0 +1,24 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceTags(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"foo":             "bar",
+		"service.name":    "benthos",
+		"service.version": "1.2.3",
+	}, ServiceTags(map[string]string{"foo": "bar"}, "1.2.3"))
+
+	assert.Equal(t, map[string]string{
+		"service.name": "meow",
+	}, ServiceTags(map[string]string{"service.name": "meow"}, "1.2.3"))
+
+	assert.Equal(t, map[string]string{
+		"service.name":    "benthos",
+		"service.version": "4.5.6",
+	}, ServiceTags(map[string]string{"service.version": "4.5.6"}, "1.2.3"))
+}
@@ -12,6 +12,7 @@ import (
 	_ "github.com/benthosdev/benthos/v4/public/components/azure"
 	_ "github.com/benthosdev/benthos/v4/public/components/beanstalkd"
 	_ "github.com/benthosdev/benthos/v4/public/components/cassandra"
+	_ "github.com/benthosdev/benthos/v4/public/components/cbor"
 	_ "github.com/benthosdev/benthos/v4/public/components/confluent"
 	_ "github.com/benthosdev/benthos/v4/public/components/couchbase"
 	_ "github.com/benthosdev/benthos/v4/public/components/crypto"
@@ -47,5 +48,7 @@ import (
 	_ "github.com/benthosdev/benthos/v4/public/components/sql"
 	_ "github.com/benthosdev/benthos/v4/public/components/statsd"
 	_ "github.com/benthosdev/benthos/v4/public/components/twitter"
+	_ "github.com/benthosdev/benthos/v4/public/components/useragent"
 	_ "github.com/benthosdev/benthos/v4/public/components/wasm"
+	_ "github.com/benthosdev/benthos/v4/public/components/zipkin"
 )
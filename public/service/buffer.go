@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/buffer"
@@ -69,6 +70,31 @@ type BatchBuffer interface {
 	Closer
 }
 
+// BufferBacklog describes the messages held within a buffer that are yet to be
+// read.
+type BufferBacklog struct {
+	// Messages is the number of messages waiting to be read.
+	Messages int
+
+	// Bytes is the total size of the messages waiting to be read.
+	Bytes int
+
+	// Oldest is the time at which the oldest message waiting to be read was
+	// written to the buffer, or the zero value when the buffer is empty.
+	Oldest time.Time
+}
+
+// BatchBufferBacklogger is an optional interface that can be implemented by a
+// BatchBuffer in order to report the messages it holds. When implemented the
+// backlog is periodically exposed as the gauge metrics
+// buffer_backlog_messages, buffer_backlog_bytes and buffer_backlog_age_ns.
+//
+// Backlog is called concurrently with reads and writes and should therefore
+// be cheap to call.
+type BatchBufferBacklogger interface {
+	Backlog() BufferBacklog
+}
+
 //------------------------------------------------------------------------------
 
 // Implements buffer.ReaderWriter.
@@ -78,7 +104,11 @@ type airGapBatchBuffer struct {
 }
 
 func newAirGapBatchBuffer(b BatchBuffer) buffer.ReaderWriter {
-	return &airGapBatchBuffer{b: b, sig: shutdown.NewSignaller()}
+	a := &airGapBatchBuffer{b: b, sig: shutdown.NewSignaller()}
+	if bl, ok := b.(BatchBufferBacklogger); ok {
+		return &airGapBacklogBatchBuffer{airGapBatchBuffer: a, bl: bl}
+	}
+	return a
 }
 
 func (a *airGapBatchBuffer) Write(ctx context.Context, msg message.Batch, aFn buffer.AckFunc) error {
@@ -115,3 +145,18 @@ func (a *airGapBatchBuffer) EndOfInput() {
 func (a *airGapBatchBuffer) Close(ctx context.Context) error {
 	return a.b.Close(ctx)
 }
+
+// Implements buffer.ReaderWriter and buffer.Backlogger.
+type airGapBacklogBatchBuffer struct {
+	*airGapBatchBuffer
+	bl BatchBufferBacklogger
+}
+
+func (a *airGapBacklogBatchBuffer) Backlog() buffer.Backlog {
+	b := a.bl.Backlog()
+	return buffer.Backlog{
+		Messages: b.Messages,
+		Bytes:    b.Bytes,
+		Oldest:   b.Oldest,
+	}
+}
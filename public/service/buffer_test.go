@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component/buffer"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
@@ -259,3 +260,45 @@ func TestStreamBufferClosing(t *testing.T) {
 	// Should already be shut down.
 	assert.NoError(t, b.WaitForClose(ctx))
 }
+
+type backlogMemoryBuffer struct {
+	*memoryBuffer
+	oldest time.Time
+}
+
+func (b *backlogMemoryBuffer) Backlog() BufferBacklog {
+	return BufferBacklog{
+		Messages: 3,
+		Bytes:    9,
+		Oldest:   b.oldest,
+	}
+}
+
+func TestStreamBufferBacklog(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	stats := metrics.NewLocal()
+	mgr := mock.NewManager()
+	mgr.M = stats
+
+	mBuf := &backlogMemoryBuffer{
+		memoryBuffer: newMemoryBuffer(10),
+		oldest:       time.Now().Add(-time.Hour),
+	}
+	b := buffer.NewStream("meow", newAirGapBatchBuffer(mBuf), mgr)
+	require.NoError(t, b.Consume(make(chan message.Transaction)))
+	defer func() {
+		b.TriggerCloseNow()
+		require.NoError(t, b.WaitForClose(ctx))
+	}()
+
+	assert.Eventually(t, func() bool {
+		counters := stats.GetCounters()
+		return counters["buffer_backlog_age_ns"] >= time.Hour.Nanoseconds()
+	}, time.Second*5, time.Millisecond*10)
+
+	counters := stats.GetCounters()
+	assert.Equal(t, int64(3), counters["buffer_backlog_messages"])
+	assert.Equal(t, int64(9), counters["buffer_backlog_bytes"])
+}
@@ -138,6 +138,14 @@ func NewFloatField(name string) *ConfigField {
 	}
 }
 
+// NewFloatListField describes a new config field consisting of a list of
+// floats.
+func NewFloatListField(name string) *ConfigField {
+	return &ConfigField{
+		field: docs.FieldFloat(name, "").Array(),
+	}
+}
+
 // NewBoolField describes a new bool type config field.
 func NewBoolField(name string) *ConfigField {
 	return &ConfigField{
@@ -490,6 +498,29 @@ func (c *ConfigView) IsDeprecated() bool {
 	return c.component.Status == docs.StatusDeprecated
 }
 
+// Status returns the stability status of the component, one of `stable`,
+// `beta`, `experimental` or `deprecated`.
+func (c *ConfigView) Status() string {
+	return string(c.component.Status)
+}
+
+// Categories returns the string tags used for grouping the component in
+// documentation. The returned slice is a copy and can be modified freely.
+func (c *ConfigView) Categories() []string {
+	if len(c.component.Categories) == 0 {
+		return nil
+	}
+	categories := make([]string, len(c.component.Categories))
+	copy(categories, c.component.Categories)
+	return categories
+}
+
+// Version returns the version at which the component was introduced, or an
+// empty string if it was not specified.
+func (c *ConfigView) Version() string {
+	return c.component.Version
+}
+
 // FormatJSON returns a byte slice of the component configuration formatted as a
 // JSON object. The schema of this method is undocumented and is not intended
 // for general use.
@@ -792,6 +823,32 @@ func (p *ParsedConfig) FieldFloat(path ...string) (float64, error) {
 	return f, nil
 }
 
+// FieldFloatList accesses a field that is a list of floats from the parsed
+// config by its name and returns the value. Returns an error if the field is
+// not found, or is not a list of floats.
+func (p *ParsedConfig) FieldFloatList(path ...string) ([]float64, error) {
+	v, exists := p.field(path...)
+	if !exists {
+		return nil, fmt.Errorf("field '%v' was not found in the config", p.fullDotPath(path...))
+	}
+	iList, ok := v.([]any)
+	if !ok {
+		if fList, ok := v.([]float64); ok {
+			return fList, nil
+		}
+		return nil, fmt.Errorf("expected field '%v' to be a float list, got %T", p.fullDotPath(path...), v)
+	}
+	fList := make([]float64, len(iList))
+	for i, ev := range iList {
+		f, err := query.IGetNumber(ev)
+		if err != nil {
+			return nil, fmt.Errorf("expected field '%v' to be a float list, found an element of type %T", p.fullDotPath(path...), ev)
+		}
+		fList[i] = f
+	}
+	return fList, nil
+}
+
 // FieldBool accesses a bool field from the parsed config by its name and
 // returns the value. Returns an error if the field is not found or is not a
 // bool.
@@ -127,6 +127,7 @@ func TestConfigTypedFields(t *testing.T) {
 				NewStringMapField("k"),
 				NewIntListField("l"),
 				NewIntMapField("m"),
+				NewFloatListField("n"),
 			),
 		))
 
@@ -149,6 +150,7 @@ c:
     m:
       first: 21
       second: 22
+    n: [ 0.5, 2 ]
 `, nil)
 	require.NoError(t, err)
 
@@ -200,6 +202,10 @@ c:
 	assert.NoError(t, err)
 	assert.Equal(t, map[string]int{"first": 21, "second": 22}, im)
 
+	fl, err := parsedConfig.FieldFloatList("c", "f", "n")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0.5, 2}, fl)
+
 	// Testing namespaces
 	nsC := parsedConfig.Namespace("c")
 	nsFOne := nsC.Namespace("f")
@@ -94,6 +94,46 @@ rate_limit_resources:
 	assert.Error(t, envTwo.NewStreamBuilder().SetYAML(testConfig))
 }
 
+func TestEnvironmentConfigView(t *testing.T) {
+	env := service.NewEnvironment()
+
+	require.NoError(t, env.RegisterProcessor(
+		"foo_processor", service.NewConfigSpec().
+			Beta().
+			Categories("Mapping", "Utility").
+			Version("4.18.0").
+			Summary("processor foo"),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Processor, error) {
+			return nil, errors.New("processor foo err")
+		},
+	))
+	require.NoError(t, env.RegisterProcessor(
+		"bar_processor", service.NewConfigSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Processor, error) {
+			return nil, errors.New("processor bar err")
+		},
+	))
+
+	views := map[string]*service.ConfigView{}
+	env.WalkProcessors(func(name string, config *service.ConfigView) {
+		views[name] = config
+	})
+
+	require.Contains(t, views, "foo_processor")
+	assert.Equal(t, "beta", views["foo_processor"].Status())
+	assert.Equal(t, []string{"Mapping", "Utility"}, views["foo_processor"].Categories())
+	assert.Equal(t, "4.18.0", views["foo_processor"].Version())
+
+	// Modifying the returned categories must not affect the component.
+	views["foo_processor"].Categories()[0] = "Nope"
+	assert.Equal(t, []string{"Mapping", "Utility"}, views["foo_processor"].Categories())
+
+	require.Contains(t, views, "bar_processor")
+	assert.Equal(t, "experimental", views["bar_processor"].Status())
+	assert.Empty(t, views["bar_processor"].Categories())
+	assert.Equal(t, "", views["bar_processor"].Version())
+}
+
 func TestEnvironmentBloblangIsolation(t *testing.T) {
 	bEnv := bloblang.NewEnvironment().WithoutFunctions("now")
 	require.NoError(t, bEnv.RegisterFunctionV2("meow", bloblang.NewPluginSpec(), func(args *bloblang.ParsedParams) (bloblang.Function, error) {
@@ -24,6 +24,7 @@ type Stream struct {
 	strmMut sync.Mutex
 	shutSig *shutdown.Signaller
 	onStart func()
+	onStop  func()
 
 	conf   stream.Config
 	mgr    *manager.Type
@@ -40,6 +41,7 @@ func newStream(
 	tracer trace.TracerProvider,
 	logger log.Modular,
 	onStart func(),
+	onStop func(),
 ) *Stream {
 	return &Stream{
 		conf:    conf,
@@ -50,6 +52,7 @@ func newStream(
 		logger:  logger,
 		shutSig: shutdown.NewSignaller(),
 		onStart: onStart,
+		onStop:  onStop,
 	}
 }
 
@@ -60,15 +63,23 @@ func (s *Stream) Run(ctx context.Context) (err error) {
 	if s.strm != nil {
 		err = errors.New("stream has already been run")
 	} else {
-		s.strm, err = stream.New(s.conf, s.mgr,
+		opts := []func(*stream.Type){
 			stream.OptOnClose(func() {
 				s.shutSig.ShutdownComplete()
-			}))
+			}),
+		}
+		if s.onStop != nil {
+			opts = append(opts, stream.OptOnUngracefulStop(s.onStop))
+		}
+		s.strm, err = stream.New(s.conf, s.mgr, opts...)
 	}
 	s.strmMut.Unlock()
 	if err != nil {
 		return
 	}
+	if s.onStop != nil {
+		defer s.onStop()
+	}
 
 	if s.httpAPI != nil {
 		go func() {
@@ -111,7 +122,6 @@ func (s *Stream) Stop(ctx context.Context) (err error) {
 	if strm == nil {
 		return errors.New("stream has not been run yet")
 	}
-
 	stopStats := s.stats
 	closeStats := func() error {
 		if stopStats == nil {
@@ -158,7 +168,14 @@ func (s *Stream) Stop(ctx context.Context) (err error) {
 		_ = closeHTTP(context.Background())
 	}()
 
-	if err = strm.Stop(ctx); err != nil {
+	// Consumers are unblocked once the stream has stopped, or earlier by the
+	// stream itself when a graceful stop fails, but never before in-flight
+	// messages have been given the chance to drain.
+	err = strm.Stop(ctx)
+	if s.onStop != nil {
+		s.onStop()
+	}
+	if err != nil {
 		return
 	}
 
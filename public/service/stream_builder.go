@@ -299,6 +299,11 @@ func (s *StreamBuilder) AddProcessorYAML(conf string) error {
 // and therefore it is recommended to implement some form of throttling or mutex
 // locking in cases where the call is non-blocking.
 //
+// The context provided to the MessageHandlerFunc is cancelled when a graceful
+// stop of the stream fails or reaches its deadline, once the stream has
+// stopped, or when Run returns. A blocked call should therefore return when its
+// context is done in order to allow an ungraceful shutdown to complete.
+//
 // Only one consumer can be added to a stream builder, and subsequent calls will
 // return an error.
 func (s *StreamBuilder) AddConsumerFunc(fn MessageHandlerFunc) error {
@@ -338,6 +343,11 @@ func (s *StreamBuilder) AddConsumerFunc(fn MessageHandlerFunc) error {
 // goroutines, and therefore it is recommended to implement some form of
 // throttling or mutex locking in cases where the call is non-blocking.
 //
+// The context provided to the MessageBatchHandlerFunc is cancelled when a
+// graceful stop of the stream fails or reaches its deadline, once the stream
+// has stopped, or when Run returns. A blocked call should therefore return when
+// its context is done in order to allow an ungraceful shutdown to complete.
+//
 // Only one consumer can be added to a stream builder, and subsequent calls will
 // return an error.
 //
@@ -733,7 +743,7 @@ func (s *StreamBuilder) WalkComponents(fn func(w *WalkedComponent) error) error
 
 //------------------------------------------------------------------------------
 
-func (s *StreamBuilder) runConsumerFunc(mgr *manager.Type) error {
+func (s *StreamBuilder) runConsumerFunc(ctx context.Context, mgr *manager.Type) error {
 	if s.consumerFunc == nil {
 		return nil
 	}
@@ -752,7 +762,7 @@ func (s *StreamBuilder) runConsumerFunc(mgr *manager.Type) error {
 				batch[i] = newMessageFromPart(part)
 				return nil
 			})
-			err := s.consumerFunc(context.Background(), batch)
+			err := s.consumerFunc(ctx, batch)
 			_ = tran.Ack(context.Background(), err)
 		}
 	}()
@@ -832,9 +842,14 @@ func (s *StreamBuilder) buildWithEnv(env *bundle.Environment) (*Stream, error) {
 			return nil, fmt.Errorf("unable to create stream HTTP server due to: %w. Tip: you can disable the server with `http.enabled` set to `false`, or override the configured server with SetHTTPMux", err)
 		}
 		apiMut = apiType
-	} else if hler := stats.HandlerFunc(); hler != nil {
-		apiMut.RegisterEndpoint("/stats", "Exposes service-wide metrics in the format configured.", hler)
-		apiMut.RegisterEndpoint("/metrics", "Exposes service-wide metrics in the format configured.", hler)
+	} else {
+		if hler := stats.HandlerFunc(); hler != nil {
+			apiMut.RegisterEndpoint("/stats", "Exposes service-wide metrics in the format configured.", hler)
+			apiMut.RegisterEndpoint("/metrics", "Exposes service-wide metrics in the format configured.", hler)
+		}
+		if hler := metrics.JSONHandlerFunc(stats); hler != nil {
+			apiMut.RegisterEndpoint("/metrics/json", "Exposes a JSON snapshot of the current values of service-wide metrics.", hler)
+		}
 	}
 
 	mgr, err := manager.New(
@@ -855,11 +870,15 @@ func (s *StreamBuilder) buildWithEnv(env *bundle.Environment) (*Stream, error) {
 		mgr.SetPipe(s.producerID, s.producerChan)
 	}
 
+	// The context given to consumer funcs is cancelled once a graceful stop of
+	// the stream has failed or the stream has stopped, which unblocks consumers
+	// so that an ungraceful shutdown can complete.
+	consumerCtx, consumerDone := context.WithCancel(context.Background())
 	return newStream(conf.Config, apiType, mgr, stats, tracer, logger, func() {
-		if err := s.runConsumerFunc(mgr); err != nil {
+		if err := s.runConsumerFunc(consumerCtx, mgr); err != nil {
 			logger.Errorf("Failed to run func consumer: %v", err)
 		}
-	}), nil
+	}, consumerDone), nil
 }
 
 type builderConfig struct {
@@ -255,6 +255,123 @@ file:
 	outMut.Unlock()
 }
 
+func TestStreamBuilderConsumerFuncCancelledOnStop(t *testing.T) {
+	b := service.NewStreamBuilder()
+	require.NoError(t, b.SetLoggerYAML("level: NONE"))
+	require.NoError(t, b.AddInputYAML(`
+generate:
+  count: 1
+  interval: ""
+  mapping: 'root = "hello world"'
+`))
+
+	consumerStarted := make(chan struct{})
+	consumerCancelled := make(chan struct{})
+	require.NoError(t, b.AddConsumerFunc(func(ctx context.Context, m *service.Message) error {
+		close(consumerStarted)
+		<-ctx.Done()
+		close(consumerCancelled)
+		return ctx.Err()
+	}))
+
+	strm, err := b.Build()
+	require.NoError(t, err)
+
+	go func() {
+		_ = strm.Run(context.Background())
+	}()
+
+	select {
+	case <-consumerStarted:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for consumer")
+	}
+
+	// The consumer is unblocked once the graceful stop has timed out and
+	// therefore doesn't prevent the stream from stopping.
+	require.NoError(t, strm.StopWithin(time.Second*2))
+
+	select {
+	case <-consumerCancelled:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for consumer context to be cancelled")
+	}
+}
+
+func TestStreamBuilderConsumerFuncDrainedOnStop(t *testing.T) {
+	b := service.NewStreamBuilder()
+	require.NoError(t, b.SetLoggerYAML("level: NONE"))
+	require.NoError(t, b.AddInputYAML(`
+generate:
+  count: 1
+  interval: ""
+  mapping: 'root = "hello world"'
+`))
+
+	consumerStarted := make(chan struct{})
+	consumerResult := make(chan error, 1)
+	require.NoError(t, b.AddConsumerFunc(func(ctx context.Context, m *service.Message) error {
+		close(consumerStarted)
+		select {
+		case <-time.After(time.Millisecond * 200):
+			consumerResult <- nil
+			return nil
+		case <-ctx.Done():
+			consumerResult <- ctx.Err()
+			return ctx.Err()
+		}
+	}))
+
+	strm, err := b.Build()
+	require.NoError(t, err)
+
+	go func() {
+		_ = strm.Run(context.Background())
+	}()
+
+	select {
+	case <-consumerStarted:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for consumer")
+	}
+
+	// A graceful stop must allow the in-flight message to be delivered.
+	require.NoError(t, strm.StopWithin(time.Second*5))
+	require.NoError(t, <-consumerResult)
+}
+
+func TestStreamBuilderConsumerFuncCancelledOnRunReturn(t *testing.T) {
+	b := service.NewStreamBuilder()
+	require.NoError(t, b.SetLoggerYAML("level: NONE"))
+	require.NoError(t, b.AddInputYAML(`
+generate:
+  count: 1
+  interval: ""
+  mapping: 'root = "hello world"'
+`))
+
+	var consumerCtx context.Context
+	require.NoError(t, b.AddConsumerFunc(func(ctx context.Context, m *service.Message) error {
+		consumerCtx = ctx
+		return nil
+	}))
+
+	strm, err := b.Build()
+	require.NoError(t, err)
+
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	require.NoError(t, strm.Run(tCtx))
+	require.NotNil(t, consumerCtx)
+
+	select {
+	case <-consumerCtx.Done():
+	default:
+		t.Fatal("expected consumer context to be cancelled once Run returned")
+	}
+}
+
 func TestStreamBuilderConsumerFuncInlineProcs(t *testing.T) {
 	tmpDir := t.TempDir()
 